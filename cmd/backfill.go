@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/uchainorg/uscan/pkg"
+)
+
+// backfillCmd represents the backfill command
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "index a range of synced blocks again",
+	Long: `backfill fetches the blocks from --from to --to again and stores what is missing of them.
+Txs which have been indexed before are skipped, so a range can be backfilled more than once.
+With --processors only the given processors run again over the range, to index the blocks
+stored before they were added, e.g. --processors=log,approval. "nft" indexes the stored
+nft transfers of the blocks by token id.
+Stop the running node before backfilling its db.`,
+	Run: pkg.BackfillRun,
+}
+
+func init() {
+	backfillCmd.Flags().Uint64P("from", "", 0, "first block to backfill")
+	backfillCmd.Flags().Uint64P("to", "", 0, "last block to backfill")
+	backfillCmd.Flags().StringSliceP("processors", "", nil, "processors to run again over the blocks, nft indexes the nft transfers by token id")
+	backfillCmd.MarkFlagRequired("from")
+	backfillCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(backfillCmd)
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/uchainorg/uscan/pkg"
 
@@ -53,9 +54,20 @@ func init() {
 	rootCmd.Flags().StringP(share.HttpAddr, "", "0.0.0.0", "service boot with this address")
 	rootCmd.Flags().StringP(share.HttpPort, "", "4322", "service boot with this address")
 	rootCmd.Flags().StringSliceP(share.RpcUrls, "", []string{}, "get data from blockchain, use wsurl")
+	rootCmd.Flags().StringP(share.HeadSource, "", "auto", "how to track the chain head: auto (subscribe on ws urls, poll on http urls), subscribe or poll")
+	rootCmd.Flags().DurationP(share.PollInterval, "", 3*time.Second, "interval to poll eth_blockNumber")
 	rootCmd.Flags().Uint64P(share.WorkChan, "", 24, "Open multiple works to get data")
 	rootCmd.Flags().StringP(share.MdbxPath, "", "uscandb", "mdbx path")
 	rootCmd.Flags().Uint64P(share.ForkBlockNum, "", 12, "fork block number")
+	rootCmd.Flags().Uint64P(share.ReorgDepth, "", 128, "max depth of a chain reorg that can be rolled back")
+	rootCmd.Flags().Uint64P(share.StartBlock, "", 0, "first block to index on a fresh db, history before it is skipped")
+	rootCmd.Flags().StringP(share.TracingMode, "", "full", "tracing of txs: full, calls-only (no tracetx2) or off (no internal txs), falls back to off when the node has no debug api")
+	rootCmd.Flags().IntP(share.StreamBuffer, "", 1024, "latest blocks kept for the stream api, a client can resume from any of them after a reconnect")
+	rootCmd.Flags().IntP(share.GasTrackerBlocks, "", 200, "latest blocks kept for the gas tracker history, the estimates use the last 20")
+	rootCmd.Flags().StringP(share.PendingSource, "", "off", "how to watch the pending txs: off, auto (subscribe, poll when the node has no subscriptions), subscribe or poll (txpool_content every poll interval)")
+	rootCmd.Flags().IntP(share.PendingPoolSize, "", 10000, "pending txs kept in memory, the oldest are evicted first")
+	rootCmd.Flags().StringP(share.IpfsGateway, "", "https://ipfs.io/ipfs/", "http gateway the ipfs uris of the nft metadata are read through")
+	rootCmd.Flags().StringP(share.AdminToken, "", "", "bearer token of the admin api, the admin api is disabled when it is empty")
 
 	rootCmd.Flags().StringP(share.APPTitle, "", "", "app_title is a user-defined browser title, such as Coq, which displays Coq Chain Scan")
 	rootCmd.Flags().StringP(share.UnitDisplay, "", "", "unit_display indicates the unit specified by the user, for example, Eth, Peel, Bnb")
@@ -66,9 +78,20 @@ func init() {
 	viper.BindPFlag(share.HttpAddr, rootCmd.Flags().Lookup(share.HttpAddr))
 	viper.BindPFlag(share.HttpPort, rootCmd.Flags().Lookup(share.HttpPort))
 	viper.BindPFlag(share.RpcUrls, rootCmd.Flags().Lookup(share.RpcUrls))
+	viper.BindPFlag(share.HeadSource, rootCmd.Flags().Lookup(share.HeadSource))
+	viper.BindPFlag(share.PollInterval, rootCmd.Flags().Lookup(share.PollInterval))
 	viper.BindPFlag(share.WorkChan, rootCmd.Flags().Lookup(share.WorkChan))
 	viper.BindPFlag(share.MdbxPath, rootCmd.Flags().Lookup(share.MdbxPath))
 	viper.BindPFlag(share.ForkBlockNum, rootCmd.Flags().Lookup(share.ForkBlockNum))
+	viper.BindPFlag(share.ReorgDepth, rootCmd.Flags().Lookup(share.ReorgDepth))
+	viper.BindPFlag(share.StartBlock, rootCmd.Flags().Lookup(share.StartBlock))
+	viper.BindPFlag(share.TracingMode, rootCmd.Flags().Lookup(share.TracingMode))
+	viper.BindPFlag(share.StreamBuffer, rootCmd.Flags().Lookup(share.StreamBuffer))
+	viper.BindPFlag(share.GasTrackerBlocks, rootCmd.Flags().Lookup(share.GasTrackerBlocks))
+	viper.BindPFlag(share.PendingSource, rootCmd.Flags().Lookup(share.PendingSource))
+	viper.BindPFlag(share.PendingPoolSize, rootCmd.Flags().Lookup(share.PendingPoolSize))
+	viper.BindPFlag(share.IpfsGateway, rootCmd.Flags().Lookup(share.IpfsGateway))
+	viper.BindPFlag(share.AdminToken, rootCmd.Flags().Lookup(share.AdminToken))
 
 	viper.BindPFlag(share.APPTitle, rootCmd.Flags().Lookup(share.APPTitle))
 	viper.BindPFlag(share.UnitDisplay, rootCmd.Flags().Lookup(share.UnitDisplay))
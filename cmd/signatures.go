@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/uchainorg/uscan/pkg"
+)
+
+// signaturesCmd represents the signatures command
+var signaturesCmd = &cobra.Command{
+	Use:   "signatures",
+	Short: "import function and event signature lists",
+	Long: `signatures imports 4-byte function signatures and 32-byte event signatures from local files.
+A file is a plain text list with one signature per line, optionally prefixed by its selector,
+or a JSON dump in which every string that is a signature is imported.
+Signatures sharing a selector are all kept. Stop the running node before importing into its db,
+or use the admin api of the running node.`,
+	Run: pkg.SignaturesRun,
+}
+
+func init() {
+	signaturesCmd.Flags().StringSliceP("functions", "", []string{}, "function signature files")
+	signaturesCmd.Flags().StringSliceP("events", "", []string{}, "event signature files")
+
+	rootCmd.AddCommand(signaturesCmd)
+}
@@ -61,11 +61,19 @@ func Apis(ctx context.Context) error {
 	}))
 	SetupRouter(g)
 
+	go func() {
+		<-ctx.Done()
+		if err := svc.Shutdown(); err != nil {
+			log.Errorf("service shutdown with error: %s", err)
+		}
+	}()
+
 	addr := fmt.Sprintf("%s:%s", viper.GetString(share.HttpAddr), viper.GetString(share.HttpPort))
 	log.Infof("service boot with: %s \n", addr)
 	if err := svc.Listen(addr); err != nil {
 		log.Fatalf("service boot with error: %s", err)
 	}
+	log.Info("service stopped")
 
 	return nil
 }
@@ -1,9 +1,14 @@
 package apis
 
 import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -13,21 +18,28 @@ import (
 	"github.com/uchainorg/uscan/pkg/log"
 	"github.com/uchainorg/uscan/pkg/response"
 	"github.com/uchainorg/uscan/pkg/service"
+	"github.com/uchainorg/uscan/pkg/stream"
 	"github.com/uchainorg/uscan/pkg/types"
 	"github.com/uchainorg/uscan/share"
 )
 
 var ChainID uint64
 
+const streamKeepAlive = 15 * time.Second
+
 func SetupRouter(g fiber.Router) {
 	g.Get("/search", search)
 	g.Get("/home", getHome)
+	g.Get("/stats/daily", listDailyStats)
+	g.Get("/gas-tracker", getGasTracker)
+	g.Get("/logs", getLogs)
 
 	g.Get("/blocks", listBlocks)
 	g.Get("/blocks/:blockNum", getBlock)
 	g.Get("/blocks/:blockNum/txs", getBlockTxs)
 
 	g.Get("/txs", listTxs)
+	g.Get("/txs/pending", listPendingTxs)
 	g.Get("/txs/:txHash", getTx)
 	//g.Get("/internal-txs", getInternalTxs)
 	//g.Get("/txs/:txHash/internal", getInternalTx)
@@ -41,6 +53,11 @@ func SetupRouter(g fiber.Router) {
 	g.Get("/accounts/:address", getAccountInfo)
 	g.Get("/accounts/:address/txns", getAccountTxns)
 	g.Get("/accounts/:address/total", getAccountTotal)
+	g.Get("/accounts/:address/balance", getAccountBalance)
+	g.Get("/accounts/:address/balance-history", getAccountBalanceHistory)
+	g.Get("/accounts/:address/withdrawals", getAccountWithdrawals)
+	g.Get("/accounts/:address/events", getAccountEvents)
+	g.Get("/accounts/:address/approvals", getAccountApprovals)
 	//g.Get("/accounts/:address/txns/download", downloadAccountTxns)
 	g.Get("/accounts/:address/txns-erc20", getAccountErc20Txns)
 	//g.Get("/accounts/:address/txns-erc20/download", downloadAccountErc20Txns)
@@ -58,8 +75,10 @@ func SetupRouter(g fiber.Router) {
 	g.Get("/tokens/:address/transfers", listTokenTransfers)
 	//g.Get("/tokens/:address/transfers/download", downloadTokenTransfers)
 	g.Get("/tokens/:address/holders", listTokenHolders)
+	g.Get("/tokens/:address/holders/:holder/balance", getTokenHolderBalance)
+	g.Get("/tokens/:address/snapshot", listTokenSnapshot)
 	g.Get("/tokens/:address/inventory", listInventory)
-	//g.Get("/nfts/:address/:tokenID", getNft)
+	g.Get("/nfts/:address/:tokenID", getNft)
 	g.Post("/contracts/:address/verify", validateContract)
 	g.Get("/contracts-verify/:id/status", getValidateContractStatus)
 	g.Get("/contracts/metadata", ReadValidateContractMetadata)
@@ -67,7 +86,32 @@ func SetupRouter(g fiber.Router) {
 	g.Get("/contracts/:address/content", getValidateContract)
 	g.Get("/contracts/:address/abi", getContractABI)
 
+	g.Get("/stream", streamBlocks)
+
 	g.Get("/custom-params", getCustomParameters)
+
+	admin := g.Group("/admin", adminAuth)
+	admin.Post("/signatures", importSignatures)
+	admin.Get("/tokens/:address/snapshot/download", downloadTokenSnapshot)
+	admin.Post("/webhooks", createWebhook)
+	admin.Get("/webhooks", listWebhooks)
+	admin.Get("/webhooks/:id", getWebhook)
+	admin.Delete("/webhooks/:id", deleteWebhook)
+	admin.Get("/webhooks/:id/deliveries", listWebhookDeliveries)
+	admin.Post("/nfts/:address/:tokenID/refresh", refreshNft)
+}
+
+// adminAuth lets the requests carrying the admin token through, without a token the admin api is off
+func adminAuth(c *fiber.Ctx) error {
+	token := viper.GetString(share.AdminToken)
+	if token == "" {
+		return c.Status(http.StatusForbidden).JSON(response.Err(response.ErrUnauthorized))
+	}
+	given := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		return c.Status(http.StatusUnauthorized).JSON(response.Err(response.ErrUnauthorized))
+	}
+	return c.Next()
 }
 
 func getCustomParameters(c *fiber.Ctx) error {
@@ -108,6 +152,52 @@ func getHome(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response.Ok(resp))
 }
 
+func listDailyStats(c *fiber.Ctx) error {
+	f := &types.DailyStatsQuery{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.ListDailyStats(f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func listPendingTxs(c *fiber.Ctx) error {
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	return c.Status(http.StatusOK).JSON(response.Ok(service.ListPendingTxs(f)))
+}
+
+func getGasTracker(c *fiber.Ctx) error {
+	resp, err := service.GasTracker()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getLogs(c *fiber.Ctx) error {
+	q := &types.LogQuery{}
+	f := &types.Pager{}
+	if err := c.QueryParser(q); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.GetLogs(q, f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
 func listBlocks(c *fiber.Ctx) error {
 	log.Infof("listBlocks:%d", time.Now().UnixMilli())
 	f := &types.Pager{}
@@ -231,6 +321,97 @@ func getAccountInfo(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response.Ok(resp))
 }
 
+func getAccountBalance(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.BalanceQuery{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.GetAccountBalanceAt(common.HexToAddress(address), f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getAccountBalanceHistory(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.ListAccountBalanceHistory(f, common.HexToAddress(address))
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getAccountWithdrawals(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.ListAccountWithdrawals(f, common.HexToAddress(address))
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getAccountApprovals(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	q := &types.ApprovalQuery{}
+	if err := c.QueryParser(q); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.ListAccountApprovals(f, q, common.HexToAddress(address))
+	if err != nil {
+		if err == response.ErrInvalidParameter {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getAccountEvents(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.ListAccountEvents(f, common.HexToAddress(address))
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
 func getAccountTxns(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
@@ -326,7 +507,7 @@ func getAccountInternalTxns(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
 	}
-	return c.Status(http.StatusOK).JSON(response.Ok(map[string]interface{}{"items": resp, "total": total}))
+	return c.Status(http.StatusOK).JSON(response.Ok(map[string]interface{}{"items": resp, "total": total, "unavailable": service.InternalTxUnavailable()}))
 }
 
 func listTokenTxnsErc20(c *fiber.Ctx) error {
@@ -468,6 +649,62 @@ func listTokenHolders(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response.Ok(resp))
 }
 
+func getTokenHolderBalance(c *fiber.Ctx) error {
+	address := c.Params("address")
+	holder := c.Params("holder")
+	if address == "" || holder == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.TokenBalanceQuery{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.GetTokenBalanceAt(common.HexToAddress(address), common.HexToAddress(holder), f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func listTokenSnapshot(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	q := &types.TokenBalanceQuery{}
+	f := &types.Pager{}
+	if err := c.QueryParser(q); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.ListTokenHoldersAt(common.HexToAddress(address), q, f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func downloadTokenSnapshot(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	q := &types.TokenBalanceQuery{}
+	if err := c.QueryParser(q); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	blockNum, resp, err := service.ExportTokenHoldersAt(common.HexToAddress(address), q)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	c.Attachment(fmt.Sprintf("holders-%s-%d.csv", common.HexToAddress(address).Hex(), blockNum))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Status(http.StatusOK).Send(resp)
+}
+
 func listInventory(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
@@ -517,7 +754,7 @@ func validateContract(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
 	}
 	param := req.ToValidateContractReq()
-	if param.CompilerType == types.SolidityStandardJsonInput {
+	if param.CompilerType == types.SolidityStandardJsonInput || param.CompilerType == types.VyperJson {
 		f, err := getFile(value.File)
 		if err != nil {
 			return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
@@ -586,3 +823,171 @@ func getContractABI(c *fiber.Ctx) error {
 	}
 	return c.Status(http.StatusOK).JSON(response.Ok(resp))
 }
+
+func createWebhook(c *fiber.Ctx) error {
+	req := &types.WebhookReq{}
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.CreateWebhook(req)
+	if err != nil {
+		if err == response.ErrInvalidParameter {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func listWebhooks(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(response.Ok(service.ListWebhooks()))
+}
+
+func getWebhook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.GetWebhook(id)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func deleteWebhook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	if err = service.DeleteWebhook(id); err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(nil))
+}
+
+func listWebhookDeliveries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err = c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	resp, err := service.ListWebhookDeliveries(id, f)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+// streamBlocks pushes the committed blocks as server-sent events.
+// A client reconnecting with Last-Event-ID resumes after the last block it has received.
+func streamBlocks(c *fiber.Ctx) error {
+	f := &types.StreamFilter{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	if lastID := c.Get("Last-Event-ID"); lastID != "" && f.From == 0 {
+		last, err := strconv.ParseUint(lastID, 10, 64)
+		if err != nil {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+		}
+		f.From = last + 1
+	}
+	sub, err := service.SubscribeStream(f)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer service.UnsubscribeStream(sub)
+		for _, m := range sub.Replay {
+			if err := sub.Write(w, m); err != nil {
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case m, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				err = sub.Write(w, m)
+			case <-keepAlive.C:
+				err = stream.KeepAlive(w)
+			}
+			if err == nil {
+				err = w.Flush()
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+func importSignatures(c *fiber.Ctx) error {
+	req := &types.SignatureImportReq{}
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.ImportSignatures(req)
+	if err != nil {
+		if err == response.ErrInvalidParameter {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func getNft(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f := &types.Pager{}
+	if err := c.QueryParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	f.Complete()
+	q := &types.NftQuery{}
+	if err := c.QueryParser(q); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.GetNft(common.HexToAddress(address), c.Params("tokenID"), f, q)
+	if err != nil {
+		if err == response.ErrInvalidParameter {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
+
+func refreshNft(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(http.StatusBadRequest).JSON(response.Err(response.ErrInvalidParameter))
+	}
+	resp, err := service.RefreshNftMetadata(common.HexToAddress(address), c.Params("tokenID"))
+	if err != nil {
+		if err == response.ErrInvalidParameter {
+			return c.Status(http.StatusBadRequest).JSON(response.Err(err))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(response.Err(err))
+	}
+	return c.Status(http.StatusOK).JSON(response.Ok(resp))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2022 uscan team
+
+This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pkg
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/core"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/rpcclient"
+	"github.com/uchainorg/uscan/pkg/storage"
+	"github.com/uchainorg/uscan/share"
+)
+
+func BackfillRun(cmd *cobra.Command, args []string) {
+	from, _ := cmd.Flags().GetUint64("from")
+	to, _ := cmd.Flags().GetUint64("to")
+	processors, _ := cmd.Flags().GetStringSlice("processors")
+
+	storage := storage.NewStorage(viper.GetString(share.MdbxPath))
+	rpcMgr := rpcclient.NewRpcClient(viper.GetStringSlice(share.RpcUrls), viper.GetString(share.HeadSource), viper.GetDuration(share.PollInterval))
+	tracing, err := job.ResolveTracing(viper.GetString(share.TracingMode), rpcMgr.Capabilities())
+	if err != nil {
+		log.Fatalf("tracing mode: %v", err)
+	}
+
+	sync := core.NewSync(rpcMgr, contract.NewClient(rpcMgr), viper.GetInt64(share.ForkBlockNum), viper.GetUint64(share.ReorgDepth), viper.GetUint64(share.StartBlock), tracing, storage.FullDB, storage.ForkDB, viper.GetUint64(share.WorkChan))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if len(processors) > 0 {
+		if err := sync.Reindex(ctx, from, to, processors); err != nil {
+			log.Fatalf("reindex failed: %v", err)
+		}
+		return
+	}
+	if err := sync.Backfill(ctx, from, to); err != nil {
+		log.Fatalf("backfill failed: %v", err)
+	}
+}
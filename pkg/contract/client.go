@@ -28,6 +28,8 @@ var (
 	TransferBatchEventTopic  = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
 	TransferSingleEventTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
 	TransferEventTopic       = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	ApprovalEventTopic       = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	ApprovalForAllEventTopic = common.HexToHash("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31")
 
 	ErrinvalidTopic   = errors.New("invalid topic")
 	ErrNotNftContract = errors.New("non NFT contract")
@@ -258,6 +260,44 @@ func (e *Client) GetContractTotalSupply(contract string) (*big.Int, error) {
 	return totalSupply, nil
 }
 
+func (e *Client) GetContractTotalSupplyAt(contract common.Address, block *big.Int) (*big.Int, error) {
+	meta, err := eip.NewMeta(contract, e.client.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	return meta.TotalSupply(&bind.CallOpts{
+		BlockNumber: block,
+	})
+}
+
+func (e *Client) GetErc20BalanceAt(contract, owner common.Address, block *big.Int) (*big.Int, error) {
+	ctr, err := eip.NewErc20Caller(contract, e.client.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	return ctr.BalanceOf(&bind.CallOpts{
+		BlockNumber: block,
+	}, owner)
+}
+
+func (e *Client) GetErc20Allowance(contract, owner, spender common.Address) (*big.Int, error) {
+	ctr, err := eip.NewErc20Caller(contract, e.client.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	return ctr.Allowance(nil, owner, spender)
+}
+
+func (e *Client) GetErc721BalanceAt(contract, owner common.Address, block *big.Int) (*big.Int, error) {
+	ctr, err := eip.NewIeip721Caller(contract, e.client.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	return ctr.BalanceOf(&bind.CallOpts{
+		BlockNumber: block,
+	}, owner)
+}
+
 func (e *Client) GetNumWith721ByContactOwnerTokenID(owner, contract common.Address, tokenID *big.Int, block *big.Int) (*big.Int, error) {
 	ctr, err := eip.NewIeip721Caller(contract, e.client.GetClient())
 	if err != nil {
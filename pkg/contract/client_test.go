@@ -15,7 +15,7 @@ import (
 )
 
 var (
-	testClient            = NewClient(rpcclient.NewRpcClient([]string{"wss://testnet.ankr.com/ws"}))
+	testClient            = NewClient(rpcclient.NewRpcClient([]string{"wss://testnet.ankr.com/ws"}, rpcclient.HeadSourceAuto, 0))
 	testContract20        = "0x6a92f2e354228e866c44419860233cc23bec0d8a"
 	testContract721       = "0xB502432eD49b7c1AD5Cdca7C133F4334DD09e8cd"
 	testContract721Token  = "0x1348c63"
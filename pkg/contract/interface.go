@@ -3,6 +3,7 @@ package contract
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/uchainorg/uscan/pkg/contract/eip"
 )
@@ -20,5 +21,10 @@ type Contractor interface {
 	GetContractSymbol(contract string) (string, error)
 	GetContractDecimals(contract string) (*big.Int, error)
 	GetContractTotalSupply(contract string) (*big.Int, error)
+	GetContractTotalSupplyAt(contract common.Address, block *big.Int) (*big.Int, error)
+	GetErc20BalanceAt(contract, owner common.Address, block *big.Int) (*big.Int, error)
+	GetErc20Allowance(contract, owner, spender common.Address) (*big.Int, error)
+	GetErc721BalanceAt(contract, owner common.Address, block *big.Int) (*big.Int, error)
+	GetNumWith1155ByContactOwnerTokenID(owner, contract common.Address, tokenID *big.Int, block *big.Int) (*big.Int, error)
 	//CheckLog(log *types.Log) (*model.EventTransferData, error)
 }
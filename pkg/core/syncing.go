@@ -7,34 +7,59 @@ import (
 
 	"github.com/uchainorg/uscan/pkg/contract"
 	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/gasoracle"
 	"github.com/uchainorg/uscan/pkg/job"
 	"github.com/uchainorg/uscan/pkg/kv"
 	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/mempool"
 	"github.com/uchainorg/uscan/pkg/rpcclient"
+	"github.com/uchainorg/uscan/pkg/storage/forkdb"
 	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/stream"
 	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/webhook"
 	"github.com/uchainorg/uscan/pkg/workpool"
 )
 
 type Jobs struct {
 	Main *job.SyncJob
 	Fork *job.SyncJob
+
+	epoch uint64
+}
+
+// syncPoint is where Execute restarts from after a reorg has been rolled back
+type syncPoint struct {
+	begin     uint64
+	forkStart uint64
 }
 
 type Sync struct {
 	client         rpcclient.RpcClient
 	contractClient contract.Contractor
 	forkNum        int64
+	reorgDepth     uint64
+	startBlock     uint64
 	db             kv.Database
 	forkDb         kv.Database
 	jobChan        workpool.Dispathcher
 	storeChan      chan *Jobs
+	resetChan      chan *syncPoint
+	processors     []Processor
+	webhooks       *webhook.Dispatcher
+	stream         *stream.Hub
+	gasOracle      *gasoracle.Oracle
+	mempool        *mempool.Pool
+	done           chan struct{}
 }
 
 func NewSync(
 	client rpcclient.RpcClient,
 	contractClient contract.Contractor,
 	forkNum int64,
+	reorgDepth uint64,
+	startBlock uint64,
+	tracing string,
 	db kv.Database,
 	forkDB kv.Database,
 	chanSize uint64,
@@ -43,22 +68,56 @@ func NewSync(
 		client:         client,
 		contractClient: contractClient,
 		forkNum:        forkNum,
+		reorgDepth:     reorgDepth,
+		startBlock:     startBlock,
 		db:             db,
 		forkDb:         forkDB,
 		jobChan:        workpool.NewDispathcher(int(chanSize)),
 		storeChan:      make(chan *Jobs, chanSize*2),
+		resetChan:      make(chan *syncPoint, 1),
+		done:           make(chan struct{}),
 	}
-	job.GlobalInit(int(chanSize))
-	go s.storeEvent()
+	s.processors = append([]Processor{
+		&transferProcessor{sync: s},
+		&holderProcessor{sync: s},
+		&logProcessor{},
+		&approvalProcessor{},
+	}, processors...)
+	job.GlobalInit(int(chanSize), tracing)
 	return s
 }
 
-func (n *Sync) Execute(ctx context.Context) {
+// SetWebhooks makes the committed blocks be delivered to the webhooks
+func (n *Sync) SetWebhooks(d *webhook.Dispatcher) {
+	n.webhooks = d
+}
+
+// Execute syncs blocks until ctx is canceled or a block fails to be stored.
+// Blocks are committed one by one, so what is left in the queues is discarded on exit.
+func (n *Sync) Execute(ctx context.Context) (err error) {
 	var (
 		begin, lastBlock, end, forkStart uint64
+		epoch                            uint64
 	)
+	defer close(n.done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	begin = n.getBeginBlock()
+	if err = n.initStartBlock(); err != nil {
+		log.Errorf("init start block: %v", err)
+		return err
+	}
+	if err = n.loadGasOracle(); err != nil {
+		log.Errorf("load gas oracle: %v", err)
+	}
+	begin, forkStart = n.getBeginBlock()
+
+	storeErr := make(chan error, 1)
+	go func() {
+		storeErr <- n.storeEvent(ctx)
+		cancel()
+	}()
 
 	go func() {
 		for latestBlockNumber := range n.client.GetLatestBlockNumber(ctx) {
@@ -67,21 +126,32 @@ func (n *Sync) Execute(ctx context.Context) {
 		}
 	}()
 
+loop:
 	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case p := <-n.resetChan:
+			begin, forkStart = p.begin, p.forkStart
+			epoch++
+			log.Infof("resync from block: %d", begin)
+		default:
+		}
+
 		if begin <= lastBlock {
 			var mainJob, forkJob *job.SyncJob
 			end = lastBlock
 			if forkStart > 0 {
-				forkJob = job.NewSyncJob(begin, n.client)
+				forkJob = job.NewSyncJob(ctx, begin, n.client)
 				if int64(forkStart) <= int64(begin)-n.forkNum {
-					mainJob = job.NewSyncJob(forkStart, n.client)
+					mainJob = job.NewSyncJob(ctx, forkStart, n.client)
 					forkStart++
 				}
 			} else {
 				if begin <= end-uint64(n.forkNum) {
-					mainJob = job.NewSyncJob(begin, n.client)
+					mainJob = job.NewSyncJob(ctx, begin, n.client)
 				} else {
-					forkJob = job.NewSyncJob(begin, n.client)
+					forkJob = job.NewSyncJob(ctx, begin, n.client)
 					forkStart = begin
 				}
 			}
@@ -92,50 +162,206 @@ func (n *Sync) Execute(ctx context.Context) {
 				n.jobChan.AddJob(forkJob)
 			}
 
-			n.storeChan <- &Jobs{
-				Main: mainJob,
-				Fork: forkJob,
+			select {
+			case n.storeChan <- &Jobs{
+				Main:  mainJob,
+				Fork:  forkJob,
+				epoch: epoch,
+			}:
+			case <-ctx.Done():
 			}
 			begin++
 		} else {
-			time.Sleep(time.Millisecond * 100)
+			select {
+			case <-time.After(time.Millisecond * 100):
+			case <-ctx.Done():
+			}
 		}
 	}
 
+	n.jobChan.Stop()
+	job.TxJobChan.Stop()
+	job.DebugJobChan.Stop()
+	if err = <-storeErr; err != nil {
+		log.Errorf("sync failed: %v", err)
+	}
+
+	full, fork, _ := n.readSyncingBlocks()
+	log.Infof("sync stopped, last persisted block: %s, last fork block: %s", full.String(), fork.String())
+	return err
 }
 
-func (n *Sync) getBeginBlock() uint64 {
-	syncingBlock, err := fulldb.ReadSyncingBlock(context.Background(), n.db)
+// Stop waits for Execute to return after its context has been canceled
+func (n *Sync) Stop() error {
+	<-n.done
+	return nil
+}
+
+// getBeginBlock resumes after the last synced block, fork blocks kept from the last run are not synced again
+func (n *Sync) getBeginBlock() (begin, forkStart uint64) {
+	syncingBlock, forkSyncingBlock, err := n.readSyncingBlocks()
 	if err != nil {
-		if errors.Is(err, kv.NotFound) {
-			syncingBlock = field.NewInt(0)
-		} else {
-			log.Fatalf("get syncing block err: %v", err)
+		log.Fatalf("get syncing block err: %v", err)
+	}
+
+	if syncingBlock.ToUint64() == 0 && forkSyncingBlock.ToUint64() == 0 && n.startBlock > 0 {
+		return n.startBlock, 0
+	}
+	if forkSyncingBlock.Cmp(syncingBlock) > 0 {
+		log.Infof("resume fork blocks: %d - %d", syncingBlock.ToUint64()+1, forkSyncingBlock.ToUint64())
+		return forkSyncingBlock.ToUint64() + 1, syncingBlock.ToUint64() + 1
+	}
+	return syncingBlock.ToUint64() + 1, 0
+}
+
+// initStartBlock keeps the start block of a fresh db, the one of an existing db can not be changed
+func (n *Sync) initStartBlock() error {
+	start, err := fulldb.ReadStartBlock(context.Background(), n.db)
+	if err == nil {
+		if n.startBlock != start.ToUint64() {
+			log.Infof("start block %d is ignored, the db is indexed from block %d", n.startBlock, start.ToUint64())
 		}
+		n.startBlock = start.ToUint64()
+		return nil
+	}
+	if !errors.Is(err, kv.NotFound) {
+		return err
 	}
-	return syncingBlock.ToUint64() + 1
+
+	full, fork, err := n.readSyncingBlocks()
+	if err != nil {
+		return err
+	}
+	if full.ToUint64() > 0 || fork.ToUint64() > 0 {
+		// synced from genesis before the start block was recorded
+		n.startBlock = 0
+		return nil
+	}
+	if n.startBlock <= 1 {
+		n.startBlock = 0
+		return nil
+	}
+	log.Infof("index from block: %d", n.startBlock)
+	return fulldb.WriteStartBlock(context.Background(), n.db, field.NewInt(int64(n.startBlock)))
 }
 
-func (n *Sync) storeEvent() {
+// readSyncingBlocks returns the last block stored in the full db and in the fork db
+func (n *Sync) readSyncingBlocks() (full, fork *field.BigInt, err error) {
+	full, err = fulldb.ReadSyncingBlock(context.Background(), n.db)
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			return field.NewInt(0), field.NewInt(0), err
+		}
+		full = field.NewInt(0)
+	}
+	fork, err = forkdb.ReadSyncingBlock(context.Background(), n.forkDb)
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			return full, field.NewInt(0), err
+		}
+		fork = field.NewInt(0)
+	}
+	return full, fork, nil
+}
+
+// storeEvent stores the jobs in order, it returns once ctx is canceled or a job fails
+func (n *Sync) storeEvent(ctx context.Context) (err error) {
 	var (
-		err error
+		j     *Jobs
+		reorg bool
+		epoch uint64
 	)
-	for j := range n.storeChan {
-		for {
-			if ((j.Fork == nil) || (j.Fork != nil && j.Fork.Completed)) &&
-				((j.Main == nil) || (j.Main != nil && j.Main.Completed)) {
-				err = n.handleJobs(j)
-				if err != nil {
-					goto end
-				}
-				break
-			} else {
-				time.Sleep(time.Millisecond * 100)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case j = <-n.storeChan:
+		}
+
+		// jobs created before the last reorg was handled
+		if j.epoch != epoch {
+			continue
+		}
+		for !(((j.Fork == nil) || (j.Fork != nil && j.Fork.Completed)) &&
+			((j.Main == nil) || (j.Main != nil && j.Main.Completed))) {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Millisecond * 100):
 			}
 		}
+
+		reorg, err = n.checkReorg(context.Background(), j)
+		if err != nil {
+			log.Errorf("check reorg: %v", err)
+			return err
+		}
+		if reorg {
+			if err = n.handleReorg(j); err != nil {
+				// stored blocks which can not be rolled back are left, stop syncing on top of them
+				log.Errorf("handle reorg: %v", err)
+				return err
+			}
+			epoch++
+			continue
+		}
+		if err = n.handleJobs(j); err != nil {
+			return err
+		}
+		n.notifyWebhooks(j)
+		n.publishBlock(j)
+		n.trackGas(j)
+		n.evictPending(j)
+		resetTransfers()
+	}
+}
+
+// notifyWebhooks is called once the jobs are committed, the blocks of fork jobs are not confirmed yet
+func (n *Sync) notifyWebhooks(jobs *Jobs) {
+	if n.webhooks == nil {
+		return
+	}
+	if jobs.Main != nil {
+		n.webhooks.Notify(jobs.Main, true)
+	}
+	if jobs.Fork != nil {
+		n.webhooks.Notify(jobs.Fork, false)
 	}
-end:
-	log.Fatalf("handle failed")
+}
+
+// handleReorg rolls back to the common ancestor and tells Execute where to continue
+func (n *Sync) handleReorg(jobs *Jobs) (err error) {
+	head := jobsHead(jobs).Number.ToUint64()
+	ancestor, err := n.findCommonAncestor(context.Background(), head)
+	if err != nil {
+		return err
+	}
+	log.Infof("reorg detected at block %d, common ancestor: %d", head, ancestor)
+
+	if err = n.rollback(head-1, ancestor); err != nil {
+		return err
+	}
+	resetCaches()
+	if n.webhooks != nil {
+		n.webhooks.Reorged(ancestor+1, head-1)
+	}
+	if n.stream != nil {
+		n.stream.Reorg(ancestor)
+	}
+	if n.gasOracle != nil {
+		n.gasOracle.Reorg(ancestor)
+	}
+
+	fullSyncing, _, err := n.readSyncingBlocks()
+	if err != nil {
+		return err
+	}
+	p := &syncPoint{begin: ancestor + 1}
+	if ancestor > fullSyncing.ToUint64() {
+		p.forkStart = fullSyncing.ToUint64() + 1
+	}
+	n.resetChan <- p
+	return nil
 }
 
 func (n *Sync) handleJobs(jobs *Jobs) (err error) {
@@ -143,6 +369,9 @@ func (n *Sync) handleJobs(jobs *Jobs) (err error) {
 		ctxMain, ctxFork       context.Context
 		errMain, errFork       error
 		mainHandle, forkHandle *blockHandle
+
+		mainDb = newJournalDB(n.db)
+		forkDb = newJournalDB(n.forkDb)
 	)
 	ctxMain, errMain = n.db.BeginTx(context.Background())
 	if errMain != nil {
@@ -165,18 +394,7 @@ func (n *Sync) handleJobs(jobs *Jobs) (err error) {
 
 	if jobs.Fork != nil {
 		log.Infof("handle fork block: %s", jobs.Fork.BlockData.Number.String())
-		forkHandle = newBlockHandle(
-			jobs.Fork.BlockData,
-			jobs.Fork.TransactionDatas,
-			jobs.Fork.ReceiptDatas,
-			jobs.Fork.ContractOrMemberData,
-			jobs.Fork.ContractInfoMap,
-			jobs.Fork.ProxyContracts,
-			jobs.Fork.InternalTxs,
-			jobs.Fork.CallFrames,
-			n.contractClient,
-			n.forkDb,
-		)
+		forkHandle = newBlockHandle(jobs.Fork, n.contractClient, forkDb, n.startBlock, n.processors)
 		if errFork = forkHandle.handleFork(ctxFork); errFork != nil {
 			log.Errorf("handle fork data: %s", jobs.Fork.BlockData.Number.String())
 			return errFork
@@ -193,18 +411,7 @@ func (n *Sync) handleJobs(jobs *Jobs) (err error) {
 
 	if jobs.Main != nil {
 		log.Infof("handle main block: %s", jobs.Main.BlockData.Number.String())
-		mainHandle = newBlockHandle(
-			jobs.Main.BlockData,
-			jobs.Main.TransactionDatas,
-			jobs.Main.ReceiptDatas,
-			jobs.Main.ContractOrMemberData,
-			jobs.Main.ContractInfoMap,
-			jobs.Main.ProxyContracts,
-			jobs.Main.InternalTxs,
-			jobs.Main.CallFrames,
-			n.contractClient,
-			n.db,
-		)
+		mainHandle = newBlockHandle(jobs.Main, n.contractClient, mainDb, n.startBlock, n.processors)
 		if errMain = mainHandle.handleMain(ctxMain); errMain != nil {
 			log.Errorf("handle main data: %s", jobs.Main.BlockData.Number.String())
 			return errMain
@@ -214,21 +421,44 @@ func (n *Sync) handleJobs(jobs *Jobs) (err error) {
 	if forkHandle != nil {
 		if errMain = newContractHandle(
 			jobs.Fork.ContractInfoMap,
-			jobs.Fork.ProxyContracts,
-			n.db).handleContractData(ctxMain); errMain != nil {
+			jobs.Fork.Proxies,
+			mainDb).handleContractData(ctxMain); errMain != nil {
 			log.Errorf("handle contract data from fork: %s", forkHandle.blockData.Number.String())
 			return errMain
 		}
 	} else if mainHandle != nil {
 		if errMain = newContractHandle(
 			jobs.Main.ContractInfoMap,
-			jobs.Main.ProxyContracts,
-			n.db).handleContractData(ctxMain); errMain != nil {
-			log.Errorf("handle contract data from full: %s", forkHandle.blockData.Number.String())
+			jobs.Main.Proxies,
+			mainDb).handleContractData(ctxMain); errMain != nil {
+			log.Errorf("handle contract data from full: %s", mainHandle.blockData.Number.String())
 			return errMain
 		}
 	}
 
+	step := jobsHead(jobs).Number
+	if errMain = fulldb.WriteJournal(ctxMain, n.db, step, mainDb.journal); errMain != nil {
+		log.Errorf("write journal: %s", step.String())
+		return errMain
+	}
+	if errFork = forkdb.WriteJournal(ctxFork, n.forkDb, step, forkDb.journal); errFork != nil {
+		log.Errorf("write fork journal: %s", step.String())
+		return errFork
+	}
+
+	// journals out of the reorg depth are not needed anymore
+	if step.ToUint64() > n.reorgDepth {
+		expired := field.NewInt(int64(step.ToUint64() - n.reorgDepth))
+		if errMain = fulldb.DeleteJournal(ctxMain, n.db, expired); errMain != nil {
+			log.Errorf("delete journal: %s", expired.String())
+			return errMain
+		}
+		if errFork = forkdb.DeleteJournal(ctxFork, n.forkDb, expired); errFork != nil {
+			log.Errorf("delete fork journal: %s", expired.String())
+			return errFork
+		}
+	}
+
 	return nil
 }
 
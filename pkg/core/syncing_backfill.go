@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/log"
+)
+
+// Backfill fetches the blocks from `from` to `to` again and stores what is missing of them.
+// Only blocks which have been synced already can be backfilled, and the node must not be
+// running against the same db meanwhile.
+func (n *Sync) Backfill(ctx context.Context, from, to uint64) (err error) {
+	if err = n.checkSyncedRange(from, to); err != nil {
+		return err
+	}
+	if err = n.fetchBlocks(ctx, from, to, n.handleBackfill); err != nil {
+		return err
+	}
+	log.Infof("backfill done: %d - %d", from, to)
+	return nil
+}
+
+// checkSyncedRange fails unless the blocks from `from` to `to` have been synced
+func (n *Sync) checkSyncedRange(from, to uint64) (err error) {
+	if err = n.initStartBlock(); err != nil {
+		return err
+	}
+	syncing, _, err := n.readSyncingBlocks()
+	if err != nil {
+		return err
+	}
+	if from > to {
+		return fmt.Errorf("invalid range: %d - %d", from, to)
+	}
+	// balances have been bootstrapped from the chain at the start block
+	if from < n.startBlock {
+		return fmt.Errorf("block %d is before the start block %d", from, n.startBlock)
+	}
+	if to > syncing.ToUint64() {
+		return fmt.Errorf("block %d has not been synced yet, last synced block: %d", to, syncing.ToUint64())
+	}
+	return nil
+}
+
+// fetchBlocks fetches the blocks from `from` to `to` from the node and hands them to handle
+// in order, it stops early without an error when ctx is canceled
+func (n *Sync) fetchBlocks(ctx context.Context, from, to uint64, handle func(*job.SyncJob) error) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *job.SyncJob, cap(n.storeChan))
+	go func() {
+		defer close(jobs)
+		for number := from; number <= to; number++ {
+			j := job.NewSyncJob(ctx, number, n.client)
+			n.jobChan.AddJob(j)
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	defer func() {
+		n.jobChan.Stop()
+		job.TxJobChan.Stop()
+		job.DebugJobChan.Stop()
+	}()
+
+	for j := range jobs {
+		for !j.Completed {
+			select {
+			case <-ctx.Done():
+				log.Infof("stopped before block: %d", j.Block)
+				return nil
+			case <-time.After(time.Millisecond * 100):
+			}
+		}
+		if err = handle(j); err != nil {
+			log.Errorf("block %d: %v", j.Block, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Sync) handleBackfill(j *job.SyncJob) (err error) {
+	defer resetTransfers()
+	ctx, err := n.db.BeginTx(context.Background())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			n.db.Commit(ctx)
+		} else {
+			n.db.RollBack(ctx)
+		}
+	}()
+
+	log.Infof("backfill block: %s", j.BlockData.Number.String())
+	if err = newBlockHandle(j, n.contractClient, n.db, n.startBlock, n.processors).handleBackfill(ctx); err != nil {
+		return err
+	}
+
+	return newContractHandle(j.ContractInfoMap, j.Proxies, n.db).handleContractData(ctx)
+}
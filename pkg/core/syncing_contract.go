@@ -12,16 +12,16 @@ import (
 
 type contractHandle struct {
 	contractInfoMap map[common.Address]*types.Contract
-	proxyContracts  map[common.Address]common.Address
+	proxies         map[common.Address]*types.Proxy
 	db              kv.Database
 }
 
 func newContractHandle(contractInfoMap map[common.Address]*types.Contract,
-	proxyContracts map[common.Address]common.Address,
+	proxies map[common.Address]*types.Proxy,
 	db kv.Database) *contractHandle {
 	return &contractHandle{
 		contractInfoMap: contractInfoMap,
-		proxyContracts:  proxyContracts,
+		proxies:         proxies,
 		db:              db,
 	}
 }
@@ -33,8 +33,8 @@ func (n *contractHandle) handleContractData(ctx context.Context) (err error) {
 			return err
 		}
 	}
-	if len(n.proxyContracts) > 0 {
-		if err = n.writeProxyContract(ctx); err != nil {
+	if len(n.proxies) > 0 {
+		if err = n.writeProxies(ctx); err != nil {
 			log.Errorf("write proxy contract: %v", err)
 			return err
 		}
@@ -52,9 +52,9 @@ func (n *contractHandle) writeContract(ctx context.Context) (err error) {
 	return nil
 }
 
-func (n *contractHandle) writeProxyContract(ctx context.Context) (err error) {
-	for k, v := range n.proxyContracts {
-		if err = fulldb.WriteProxyContract(ctx, n.db, k, v); err != nil {
+func (n *contractHandle) writeProxies(ctx context.Context) (err error) {
+	for k, v := range n.proxies {
+		if err = fulldb.WriteProxy(ctx, n.db, k, v); err != nil {
 			log.Errorf("write proxy contract(%s): %v ", k, err)
 			return err
 		}
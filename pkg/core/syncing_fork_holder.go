@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// holder amounts are not kept for fork blocks, only the token accounts
+
+func (n *blockHandle) writeForkErc20Holders(ctx context.Context, data *types.Erc20Transfer) (err error) {
+	if data.From == (common.Address{}) && data.To != (common.Address{}) {
+		if err = n.updateForkErc20Account(ctx, data.Contract, &data.Amount, increase); err != nil {
+			log.Errorf("update fork erc20 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	if data.To == (common.Address{}) && data.From != (common.Address{}) {
+		if err = n.updateForkErc20Account(ctx, data.Contract, &data.Amount, decrease); err != nil {
+			log.Errorf("update fork erc20 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *blockHandle) writeForkErc721Holders(ctx context.Context, data *types.Erc721Transfer) (err error) {
+	if data.From == (common.Address{}) && data.To != (common.Address{}) {
+		if err = n.updateForkErc721Account(ctx, data.Contract, &data.TokenId, increase); err != nil {
+			log.Errorf("update fork erc721 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	if data.To == (common.Address{}) && data.From != (common.Address{}) {
+		if err = n.updateForkErc721Account(ctx, data.Contract, &data.TokenId, decrease); err != nil {
+			log.Errorf("update fork erc721 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *blockHandle) writeForkErc1155Holders(ctx context.Context, data *types.Erc1155Transfer) (err error) {
+	if data.From == (common.Address{}) {
+		if err = n.updateForkErc1155Account(ctx, data.Contract, &data.Quantity, decrease); err != nil {
+			log.Errorf("decrease fork erc1155 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	if data.To == (common.Address{}) {
+		if err = n.updateForkErc1155Account(ctx, data.Contract, &data.Quantity, increase); err != nil {
+			log.Errorf("increase fork erc1155 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
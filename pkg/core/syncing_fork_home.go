@@ -14,8 +14,8 @@ import (
 
 var forkHomeCache *types.Home
 
-func (n *blockHandle) updateForkHome(ctx context.Context) (err error) {
-	var home *types.Home
+// loadForkHome returns the cached fork home, it is read from the fork db the first time
+func (n *blockHandle) loadForkHome(ctx context.Context) (home *types.Home, err error) {
 	if forkHomeCache == nil {
 		home, err = forkdb.ReadHome(ctx, n.db)
 		if err != nil {
@@ -31,7 +31,7 @@ func (n *blockHandle) updateForkHome(ctx context.Context) (err error) {
 				err = nil
 				forkHomeCache = home
 			} else {
-				return err
+				return nil, err
 			}
 		} else {
 			forkHomeCache = home
@@ -39,14 +39,31 @@ func (n *blockHandle) updateForkHome(ctx context.Context) (err error) {
 	} else {
 		home = forkHomeCache
 	}
+	return home, nil
+}
+
+// addForkHomeTokens counts the token contracts seen for the first time in the fork block
+func (n *blockHandle) addForkHomeTokens(ctx context.Context) (err error) {
+	home, err := n.loadForkHome(ctx)
+	if err != nil {
+		return err
+	}
+	home.Erc20Total.Add(n.newErc20Total)
+	home.Erc721Total.Add(n.newErc721Total)
+	home.Erc1155Total.Add(n.newErc1155Total)
+	return forkdb.WriteHome(ctx, n.db, home)
+}
+
+func (n *blockHandle) updateForkHome(ctx context.Context) (err error) {
+	home, err := n.loadForkHome(ctx)
+	if err != nil {
+		return err
+	}
 
 	home.BlockNumber.SetBytes(n.blockData.Number.Bytes())
 
 	home.TxTotal.Add(field.NewInt(int64(len(n.transactionData))))
 	home.AddressTotal.Add(n.newAddrTotal)
-	home.Erc20Total.Add(n.newErc20Total)
-	home.Erc721Total.Add(n.newErc721Total)
-	home.Erc721Total.Add(n.newErc1155Total)
 	home.Blocks = append(home.Blocks, &types.BkSim{
 		Number:            *n.blockData.Number,
 		Timestamp:         n.blockData.TimeStamp,
@@ -15,11 +15,10 @@ import (
 )
 
 var (
-	forkAccountItxTotalMap    = utils.NewCache()
-	forkOldAccountITxTotalMap = utils.NewCache()
+	forkAccountItxTotalMap = utils.NewCache()
 )
 
-func (n *blockHandle) writeForkITx(ctx context.Context, itxmap map[common.Hash][]*types.InternalTx, deleteMap map[string][][]byte, indexMap, totalMap, iTxTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkITx(ctx context.Context, itxmap map[common.Hash][]*types.InternalTx, deleteMap map[string][][]byte, indexMap, totalMap map[string]*field.BigInt) (err error) {
 	var itxTotal *field.BigInt
 
 	for k, itxs := range itxmap {
@@ -49,26 +48,18 @@ func (n *blockHandle) writeForkITx(ctx context.Context, itxmap map[common.Hash][
 				Index:           *itxTotal,
 			}
 			if v.From != (common.Address{}) {
-				if err = n.writeForkAccountItx(ctx, v.From, key, deleteMap, indexMap, totalMap, iTxTotalMap); err != nil {
+				if err = n.writeForkAccountItx(ctx, v.From, key, deleteMap, indexMap, totalMap); err != nil {
 					log.Errorf("write fork account(from: %s) Itx: %v", v.From.Hex(), err)
 				}
 			}
 
 			if v.To != (common.Address{}) {
-				if err = n.writeForkAccountItx(ctx, v.To, key, deleteMap, indexMap, totalMap, iTxTotalMap); err != nil {
+				if err = n.writeForkAccountItx(ctx, v.To, key, deleteMap, indexMap, totalMap); err != nil {
 					log.Errorf("write fork account(to: %s) Itx: %v", v.To.Hex(), err)
 				}
 			}
 		}
 
-		var oldTotal = &field.BigInt{}
-		if bytesRes, ok := forkOldAccountITxTotalMap.Get("itxTotal:" + k.String()); ok {
-			oldTotal.SetBytes(bytesRes.([]byte))
-		} else {
-			oldTotal = field.NewInt(0)
-		}
-		itxTotal.Sub(oldTotal)
-
 		if err = forkdb.WriteItxTotal(ctx, n.db, k, itxTotal); err != nil {
 			log.Errorf("write fork itx total: %v", err)
 			return err
@@ -79,18 +70,18 @@ func (n *blockHandle) writeForkITx(ctx context.Context, itxmap map[common.Hash][
 			totalMap[share.ForkTxTbl+":"+string(key3)] = field.NewInt(0)
 		}
 		totalMap[share.ForkTxTbl+":"+string(key3)].Add(itxTotal)
-
-		itxTotal.Add(oldTotal)
-		if iTxTotalMap["itxTotal"+k.String()] == nil {
-			iTxTotalMap["itxTotal"+k.String()] = field.NewInt(0)
-		}
-		iTxTotalMap["itxTotal"+k.String()] = itxTotal
-
 	}
 	return nil
 }
 
-func (n *blockHandle) writeForkAccountItx(ctx context.Context, addr common.Address, data *types.InternalTxKey, deleteMap map[string][][]byte, indexMap, totalMap, iTxTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkAccountItx(ctx context.Context, addr common.Address, data *types.InternalTxKey, deleteMap map[string][][]byte, indexMap, totalMap map[string]*field.BigInt) (err error) {
+	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/itx/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("get fork account itx index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if bytesRes, ok := forkAccountItxTotalMap.Get(addr); ok {
 		total.SetBytes(bytesRes.([]byte))
@@ -105,6 +96,7 @@ func (n *blockHandle) writeForkAccountItx(ctx context.Context, addr common.Addre
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	err = forkdb.WriteAccountITxIndex(ctx, n.db, addr, total, data)
@@ -113,20 +105,12 @@ func (n *blockHandle) writeForkAccountItx(ctx context.Context, addr common.Addre
 		return err
 	}
 	deleteMap[share.ForkAccountsTbl] = append(deleteMap[share.ForkAccountsTbl], append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/itx/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/itx/index")...)
 	if indexMap[string(key)] == nil {
 		indexMap[string(key)] = field.NewInt(0)
 	}
 	indexMap[string(key)].Add(field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldAccountITxTotalMap.Get(addr.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
-
 	err = forkdb.WriteAccountITxTotal(ctx, n.db, addr, total)
 
 	key2 := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/itx/total")...)
@@ -136,11 +120,6 @@ func (n *blockHandle) writeForkAccountItx(ctx context.Context, addr common.Addre
 	totalMap[share.ForkAccountsTbl+":"+string(key2)].Add(field.NewInt(1))
 
 	total.Add(oldTotal)
-	if iTxTotalMap[addr.String()] == nil {
-		iTxTotalMap[addr.String()] = field.NewInt(0)
-	}
-	iTxTotalMap[addr.String()] = total
-
 	if err == nil {
 		forkAccountItxTotalMap.Add(addr, total.Bytes())
 	}
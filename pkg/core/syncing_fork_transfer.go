@@ -28,18 +28,10 @@ var (
 	forkErc20TransferContractTotalMap   = utils.NewCache()
 	forkErc721TransferContractTotalMap  = utils.NewCache()
 	forkErc1155TransferContractTotalMap = utils.NewCache()
-
-	forkOldErc20TransferAccountTotalMap   = utils.NewCache()
-	forkOldErc721TransferAccountTotalMap  = utils.NewCache()
-	forkOldErc1155TransferAccountTotalMap = utils.NewCache()
-
-	forkOldErc20TransferContractTotalMap   = utils.NewCache()
-	forkOldErc721TransferContractTotalMap  = utils.NewCache()
-	forkOldErc1155TransferContractTotalMap = utils.NewCache()
 )
 
 // ------------------- erc20 transfer -----------------
-func (n *blockHandle) writeForkErc20Transfer(ctx context.Context, data *types.Erc20Transfer, deleteMap map[string][][]byte, indexMap, totalMap, erc20TotalMap, erc20ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc20Transfer(ctx context.Context, data *types.Erc20Transfer, record *ForkRecorder) (err error) {
 	if forkErc20TrasferTotal == nil {
 		forkErc20TrasferTotal, err = forkdb.ReadErc20Total(ctx, n.db)
 		if err != nil {
@@ -51,65 +43,52 @@ func (n *blockHandle) writeForkErc20Transfer(ctx context.Context, data *types.Er
 				return err
 			}
 		}
+		var oldTotal *field.BigInt
+		if oldTotal, err = n.readForkIndex(ctx, []byte("/fork/erc20/index")); err != nil {
+			log.Errorf("get fork erc20 transfer index: %v", err)
+			return err
+		}
+		forkErc20TrasferTotal.Add(oldTotal)
 	}
 	forkErc20TrasferTotal.Add(field.NewInt(1))
 	err = forkdb.WriteErc20Transfer(ctx, n.db, forkErc20TrasferTotal, data)
 	if err != nil {
 		log.Errorf("write fork erc20 transfer: %v", err)
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append([]byte("/fork/erc20/"), forkErc20TrasferTotal.Bytes()...))
+	record.Delete(share.ForkTransferTbl, append([]byte("/fork/erc20/"), forkErc20TrasferTotal.Bytes()...))
 	key := []byte("/fork/erc20/index")
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Index(key, field.NewInt(1))
 
 	if data.From != (common.Address{}) {
-		if err = n.writeForkAccountErc20TransferIndex(ctx, data.From, forkErc20TrasferTotal, deleteMap, indexMap, totalMap, erc20TotalMap); err != nil {
+		if err = n.writeForkAccountErc20TransferIndex(ctx, data.From, forkErc20TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(From: %v) erc20 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-
-		//if err = n.writeForkErc20HolderAmount(ctx, data.Contract, data.From, &data.Amount, decrease); err != nil {
-		//	log.Errorf("decrease fork account(From: %v) erc20:%v", data.From.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if data.To != (common.Address{}) {
-			if err = n.updateForkErc20Account(ctx, data.Contract, &data.Amount, increase); err != nil {
-				log.Errorf("update fork erc20 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if data.To != (common.Address{}) {
-		if err = n.writeForkAccountErc20TransferIndex(ctx, data.To, forkErc20TrasferTotal, deleteMap, indexMap, totalMap, erc20TotalMap); err != nil {
+		if err = n.writeForkAccountErc20TransferIndex(ctx, data.To, forkErc20TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(to: %v) erc20 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		//if err = n.writeForkErc20HolderAmount(ctx, data.Contract, data.To, &data.Amount, increase); err != nil {
-		//	log.Errorf("increase fork account(to: %v) erc20:%v", data.From.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if data.From != (common.Address{}) {
-			if err = n.updateForkErc20Account(ctx, data.Contract, &data.Amount, decrease); err != nil {
-				log.Errorf("update fork erc20 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
-	if err = n.writeForkErc20ContractTransferIndex(ctx, data.Contract, forkErc20TrasferTotal, deleteMap, indexMap, totalMap, erc20ContractTotalMap); err != nil {
-		log.Errorf("write fork erc20 contract transfer index:%v", data.Contract.Hex(), err)
+	if err = n.writeForkErc20ContractTransferIndex(ctx, data.Contract, forkErc20TrasferTotal, record); err != nil {
+		log.Errorf("write fork erc20 contract(%s) transfer index: %v", data.Contract.Hex(), err)
 		return err
 	}
 
 	return nil
 }
 
-func (n *blockHandle) writeForkErc20ContractTransferIndex(ctx context.Context, contract common.Address, transfer20Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc20ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc20ContractTransferIndex(ctx context.Context, contract common.Address, transfer20Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/erc20/"), contract.Bytes()...), []byte("/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc20 contract transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc20TransferContractTotalMap.Get(contract); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -124,25 +103,16 @@ func (n *blockHandle) writeForkErc20ContractTransferIndex(ctx context.Context, c
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteErc20ContractTransfer(ctx, n.db, contract, total, transfer20Index); err != nil {
 		log.Errorf("write fork erc20 contract transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append(append([]byte("/fork/erc20/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/erc20/"), contract.Bytes()...), []byte("/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkTransferTbl, append(append([]byte("/fork/erc20/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc20TransferContractTotalMap.Get(contract.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteErc20ContractTotal(ctx, n.db, contract, total)
@@ -151,16 +121,9 @@ func (n *blockHandle) writeForkErc20ContractTransferIndex(ctx context.Context, c
 	//}
 
 	key2 := append(append([]byte("/fork/erc20/"), contract.Bytes()...), []byte("/total")...)
-	if totalMap[share.ForkTransferTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkTransferTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkTransferTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc20ContractTotalMap[contract.String()] == nil {
-		erc20ContractTotalMap[contract.String()] = field.NewInt(0)
-	}
-	erc20ContractTotalMap[contract.String()] = total
 
 	if err == nil {
 		forkErc20TransferContractTotalMap.Add(contract, total.Bytes())
@@ -169,7 +132,14 @@ func (n *blockHandle) writeForkErc20ContractTransferIndex(ctx context.Context, c
 	return err
 }
 
-func (n *blockHandle) writeForkAccountErc20TransferIndex(ctx context.Context, addr common.Address, transfer20Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc20TotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkAccountErc20TransferIndex(ctx context.Context, addr common.Address, transfer20Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc20/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc20 account transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc20TrasferAccountTotalMap.Get(addr); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -184,25 +154,16 @@ func (n *blockHandle) writeForkAccountErc20TransferIndex(ctx context.Context, ad
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteAccountErc20Index(ctx, n.db, addr, total, transfer20Index); err != nil {
 		log.Errorf("write fork account erc20 transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkAccountsTbl] = append(deleteMap[share.ForkAccountsTbl], append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc20/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc20/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkAccountsTbl, append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc20/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc20TransferAccountTotalMap.Get(addr.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteAccountErc20Total(ctx, n.db, addr, total)
@@ -211,16 +172,9 @@ func (n *blockHandle) writeForkAccountErc20TransferIndex(ctx context.Context, ad
 	//}
 
 	key2 := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc20/total")...)
-	if totalMap[share.ForkAccountsTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkAccountsTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkAccountsTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkAccountsTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc20TotalMap[addr.String()] == nil {
-		erc20TotalMap[addr.String()] = field.NewInt(0)
-	}
-	erc20TotalMap[addr.String()] = total
 
 	if err == nil {
 		forkErc20TrasferAccountTotalMap.Add(addr, total.Bytes())
@@ -278,7 +232,7 @@ func (n *blockHandle) updateForkErc20Account(ctx context.Context, addr common.Ad
 }
 
 // ------------------- erc721 transfer -----------------
-func (n *blockHandle) writeForkErc721Transfer(ctx context.Context, data *types.Erc721Transfer, deleteMap map[string][][]byte, indexMap, totalMap, erc721TotalMap, erc721ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc721Transfer(ctx context.Context, data *types.Erc721Transfer, record *ForkRecorder) (err error) {
 	if forkErc721TrasferTotal == nil {
 		forkErc721TrasferTotal, err = forkdb.ReadErc721Total(ctx, n.db)
 		if err != nil {
@@ -290,64 +244,52 @@ func (n *blockHandle) writeForkErc721Transfer(ctx context.Context, data *types.E
 				return err
 			}
 		}
+		var oldTotal *field.BigInt
+		if oldTotal, err = n.readForkIndex(ctx, []byte("/fork/erc721/index")); err != nil {
+			log.Errorf("get fork erc721 transfer index: %v", err)
+			return err
+		}
+		forkErc721TrasferTotal.Add(oldTotal)
 	}
 	forkErc721TrasferTotal.Add(field.NewInt(1))
 	err = forkdb.WriteErc721Transfer(ctx, n.db, forkErc721TrasferTotal, data)
 	if err != nil {
 		log.Errorf("write fork erc721 transfer: %v", err)
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append([]byte("/fork/erc721/"), forkErc721TrasferTotal.Bytes()...))
+	record.Delete(share.ForkTransferTbl, append([]byte("/fork/erc721/"), forkErc721TrasferTotal.Bytes()...))
 	key := []byte("/fork/erc721/index")
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Index(key, field.NewInt(1))
 
 	if data.From != (common.Address{}) {
-		if err = n.writeForkAccountErc721TransferIndex(ctx, data.From, forkErc721TrasferTotal, deleteMap, indexMap, totalMap, erc721TotalMap); err != nil {
+		if err = n.writeForkAccountErc721TransferIndex(ctx, data.From, forkErc721TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(From: %v) erc721 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-		//if err = n.writeErc721HolderAmount(ctx, data.Contract, data.From, &data.TokenId, decrease); err != nil {
-		//	log.Errorf("decrease account(From: %v) erc721 tokenId:%v", data.From.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if data.To != (common.Address{}) {
-			if err = n.updateForkErc721Account(ctx, data.Contract, &data.TokenId, increase); err != nil {
-				log.Errorf("update fork erc721 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if data.To != (common.Address{}) {
-		if err = n.writeForkAccountErc721TransferIndex(ctx, data.To, forkErc721TrasferTotal, deleteMap, indexMap, totalMap, erc721TotalMap); err != nil {
+		if err = n.writeForkAccountErc721TransferIndex(ctx, data.To, forkErc721TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(to: %v) erc721 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		//if err = n.writeErc721HolderAmount(ctx, data.Contract, data.To, &data.TokenId, increase); err != nil {
-		//	log.Errorf("increase account(to: %v) erc721 tokenId:%v", data.To.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if data.From != (common.Address{}) {
-			if err = n.updateForkErc721Account(ctx, data.Contract, &data.TokenId, decrease); err != nil {
-				log.Errorf("update fork erc721 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
-	if err = n.writeForkErc721ContractTransferIndex(ctx, data.Contract, forkErc721TrasferTotal, deleteMap, indexMap, totalMap, erc721ContractTotalMap); err != nil {
-		log.Errorf("write fork erc721 contract transfer index:%v", data.Contract.Hex(), err)
+	if err = n.writeForkErc721ContractTransferIndex(ctx, data.Contract, forkErc721TrasferTotal, record); err != nil {
+		log.Errorf("write fork erc721 contract(%s) transfer index: %v", data.Contract.Hex(), err)
 		return err
 	}
 
 	return nil
 }
 
-func (n *blockHandle) writeForkErc721ContractTransferIndex(ctx context.Context, contract common.Address, transfer721Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc721ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc721ContractTransferIndex(ctx context.Context, contract common.Address, transfer721Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/erc721/"), contract.Bytes()...), []byte("/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc721 contract transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc721TransferContractTotalMap.Get(contract); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -362,25 +304,16 @@ func (n *blockHandle) writeForkErc721ContractTransferIndex(ctx context.Context,
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteErc721ContractTransfer(ctx, n.db, contract, total, transfer721Index); err != nil {
 		log.Errorf("write fork erc721 contract transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append(append([]byte("/fork/erc721/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/erc721/"), contract.Bytes()...), []byte("/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkTransferTbl, append(append([]byte("/fork/erc721/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc721TransferContractTotalMap.Get(contract.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteErc721ContractTotal(ctx, n.db, contract, total)
@@ -389,16 +322,9 @@ func (n *blockHandle) writeForkErc721ContractTransferIndex(ctx context.Context,
 	//}
 
 	key2 := append(append([]byte("/fork/erc721/"), contract.Bytes()...), []byte("/total")...)
-	if totalMap[share.ForkTransferTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkTransferTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkTransferTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc721ContractTotalMap[contract.String()] == nil {
-		erc721ContractTotalMap[contract.String()] = field.NewInt(0)
-	}
-	erc721ContractTotalMap[contract.String()] = total
 
 	if err == nil {
 		forkErc721TransferContractTotalMap.Add(contract, total.Bytes())
@@ -407,7 +333,14 @@ func (n *blockHandle) writeForkErc721ContractTransferIndex(ctx context.Context,
 	return err
 }
 
-func (n *blockHandle) writeForkAccountErc721TransferIndex(ctx context.Context, addr common.Address, transfer721Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc721TotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkAccountErc721TransferIndex(ctx context.Context, addr common.Address, transfer721Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc721/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc721 account transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc721TrasferAccountTotalMap.Get(addr); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -422,25 +355,16 @@ func (n *blockHandle) writeForkAccountErc721TransferIndex(ctx context.Context, a
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteAccountErc721Index(ctx, n.db, addr, total, transfer721Index); err != nil {
 		log.Errorf("write fork account erc721 transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkAccountsTbl] = append(deleteMap[share.ForkAccountsTbl], append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc721/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc721/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkAccountsTbl, append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc721/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc721TransferAccountTotalMap.Get(addr.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteAccountErc721Total(ctx, n.db, addr, total)
@@ -449,16 +373,9 @@ func (n *blockHandle) writeForkAccountErc721TransferIndex(ctx context.Context, a
 	//}
 
 	key2 := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc721/total")...)
-	if totalMap[share.ForkAccountsTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkAccountsTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkAccountsTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkAccountsTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc721TotalMap[addr.String()] == nil {
-		erc721TotalMap[addr.String()] = field.NewInt(0)
-	}
-	erc721TotalMap[addr.String()] = total
 
 	if err == nil {
 		forkErc721TrasferAccountTotalMap.Add(addr, total.Bytes())
@@ -510,7 +427,7 @@ func (n *blockHandle) updateForkErc721Account(ctx context.Context, addr common.A
 }
 
 // ------------------- erc1155 transfer -----------------
-func (n *blockHandle) writeForkErc1155Transfer(ctx context.Context, data *types.Erc1155Transfer, deleteMap map[string][][]byte, indexMap, totalMap, erc1155TotalMap, erc1155ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc1155Transfer(ctx context.Context, data *types.Erc1155Transfer, record *ForkRecorder) (err error) {
 	if forkErc1155TrasferTotal == nil {
 		forkErc1155TrasferTotal, err = forkdb.ReadErc1155Total(ctx, n.db)
 		if err != nil {
@@ -522,60 +439,52 @@ func (n *blockHandle) writeForkErc1155Transfer(ctx context.Context, data *types.
 				return err
 			}
 		}
+		var oldTotal *field.BigInt
+		if oldTotal, err = n.readForkIndex(ctx, []byte("/fork/erc1155/index")); err != nil {
+			log.Errorf("get fork erc1155 transfer index: %v", err)
+			return err
+		}
+		forkErc1155TrasferTotal.Add(oldTotal)
 	}
 	forkErc1155TrasferTotal.Add(field.NewInt(1))
 	err = forkdb.WriteErc1155Transfer(ctx, n.db, forkErc1155TrasferTotal, data)
 	if err != nil {
 		log.Errorf("write fork erc1155 transfer: %v", err)
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append([]byte("/fork/erc1155/"), forkErc1155TrasferTotal.Bytes()...))
+	record.Delete(share.ForkTransferTbl, append([]byte("/fork/erc1155/"), forkErc1155TrasferTotal.Bytes()...))
 	key := []byte("/fork/erc1155/index")
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Index(key, field.NewInt(1))
 
 	if data.From != (common.Address{}) {
-		if err = n.writeForkAccountErc1155TransferIndex(ctx, data.From, forkErc1155TrasferTotal, deleteMap, indexMap, totalMap, erc1155TotalMap); err != nil {
+		if err = n.writeForkAccountErc1155TransferIndex(ctx, data.From, forkErc1155TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(From: %v) erc1155 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-		//if err = n.writeForkErc1155HolderAmount(ctx, data.Contract, data.From, &data.TokenID, &data.Quantity, decrease); err != nil {
-		//	log.Errorf("decrease fork account(From: %v) erc1155 tokenId:%v", data.From.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if err = n.updateForkErc1155Account(ctx, data.Contract, &data.Quantity, decrease); err != nil {
-			log.Errorf("decrease fork erc1155 account(%s): %v", data.Contract.Hex(), err)
-			return err
-		}
 	}
 
 	if data.To != (common.Address{}) {
-		if err = n.writeForkAccountErc1155TransferIndex(ctx, data.To, forkErc1155TrasferTotal, deleteMap, indexMap, totalMap, erc1155TotalMap); err != nil {
+		if err = n.writeForkAccountErc1155TransferIndex(ctx, data.To, forkErc1155TrasferTotal, record); err != nil {
 			log.Errorf("write fork account(to: %v) erc1155 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		//if err = n.writeForkErc1155HolderAmount(ctx, data.Contract, data.To, &data.TokenID, &data.Quantity, increase); err != nil {
-		//	log.Errorf("increase fork account(to: %v) erc1155 tokenId:%v", data.From.Hex(), err)
-		//	return err
-		//}
-	} else {
-		if err = n.updateForkErc1155Account(ctx, data.Contract, &data.Quantity, increase); err != nil {
-			log.Errorf("increase fork erc1155 account(%s): %v", data.Contract.Hex(), err)
-			return err
-		}
 	}
 
-	if err = n.writeForkErc1155ContractTransferIndex(ctx, data.Contract, forkErc1155TrasferTotal, deleteMap, indexMap, totalMap, erc1155ContractTotalMap); err != nil {
-		log.Errorf("write fork erc1155 contract transfer index:%v", data.Contract.Hex(), err)
+	if err = n.writeForkErc1155ContractTransferIndex(ctx, data.Contract, forkErc1155TrasferTotal, record); err != nil {
+		log.Errorf("write fork erc1155 contract(%s) transfer index: %v", data.Contract.Hex(), err)
 		return err
 	}
 
 	return nil
 }
 
-func (n *blockHandle) writeForkErc1155ContractTransferIndex(ctx context.Context, contract common.Address, transfer1155Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc1155ContractTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkErc1155ContractTransferIndex(ctx context.Context, contract common.Address, transfer1155Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/erc1155/"), contract.Bytes()...), []byte("/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc1155 contract transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc1155TransferContractTotalMap.Get(contract); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -590,25 +499,16 @@ func (n *blockHandle) writeForkErc1155ContractTransferIndex(ctx context.Context,
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteErc1155ContractTransfer(ctx, n.db, contract, total, transfer1155Index); err != nil {
 		log.Errorf("write fork erc1155 contract transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkTransferTbl] = append(deleteMap[share.ForkTransferTbl], append(append([]byte("/fork/erc1155/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/erc1155/"), contract.Bytes()...), []byte("/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkTransferTbl, append(append([]byte("/fork/erc1155/"), contract.Bytes()...), append([]byte("/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc1155TransferContractTotalMap.Get(contract.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteErc1155ContractTotal(ctx, n.db, contract, total)
@@ -617,16 +517,9 @@ func (n *blockHandle) writeForkErc1155ContractTransferIndex(ctx context.Context,
 	//}
 
 	key2 := append(append([]byte("/fork/erc1155/"), contract.Bytes()...), []byte("/total")...)
-	if totalMap[share.ForkTransferTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkTransferTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkTransferTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc1155ContractTotalMap[contract.String()] == nil {
-		erc1155ContractTotalMap[contract.String()] = field.NewInt(0)
-	}
-	erc1155ContractTotalMap[contract.String()] = total
 
 	if err == nil {
 		forkErc1155TransferContractTotalMap.Add(contract, total.Bytes())
@@ -635,7 +528,14 @@ func (n *blockHandle) writeForkErc1155ContractTransferIndex(ctx context.Context,
 	return err
 }
 
-func (n *blockHandle) writeForkAccountErc1155TransferIndex(ctx context.Context, addr common.Address, transfer1155Index *field.BigInt, deleteMap map[string][][]byte, indexMap, totalMap, erc1155TotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkAccountErc1155TransferIndex(ctx context.Context, addr common.Address, transfer1155Index *field.BigInt, record *ForkRecorder) (err error) {
+	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc1155/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("read fork erc1155 account transfer index: %v", err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if BytesRes, ok := forkErc1155TrasferAccountTotalMap.Get(addr); ok {
 		total.SetBytes(BytesRes.([]byte))
@@ -650,25 +550,16 @@ func (n *blockHandle) writeForkAccountErc1155TransferIndex(ctx context.Context,
 				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 	total.Add(field.NewInt(1))
 	if err = forkdb.WriteAccountErc1155Index(ctx, n.db, addr, total, transfer1155Index); err != nil {
 		log.Errorf("write fork account erc1155 transfer index: %v", err)
 		return err
 	}
-	deleteMap[share.ForkAccountsTbl] = append(deleteMap[share.ForkAccountsTbl], append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc1155/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc1155/index")...)
-	if indexMap[string(key)] == nil {
-		indexMap[string(key)] = field.NewInt(0)
-	}
-	indexMap[string(key)].Add(field.NewInt(1))
+	record.Delete(share.ForkAccountsTbl, append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/erc1155/"), total.Bytes()...)...))
+	record.Index(key, field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldErc1155TransferAccountTotalMap.Get(addr.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
 
 	err = forkdb.WriteAccountErc1155Total(ctx, n.db, addr, total)
@@ -677,16 +568,9 @@ func (n *blockHandle) writeForkAccountErc1155TransferIndex(ctx context.Context,
 	//}
 
 	key2 := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/erc1155/total")...)
-	if totalMap[share.ForkAccountsTbl+":"+string(key2)] == nil {
-		totalMap[share.ForkAccountsTbl+":"+string(key2)] = field.NewInt(0)
-	}
-	totalMap[share.ForkAccountsTbl+":"+string(key2)].Add(field.NewInt(1))
+	record.Total(share.ForkAccountsTbl, key2, field.NewInt(1))
 
 	total.Add(oldTotal)
-	if erc1155TotalMap[addr.String()] == nil {
-		erc1155TotalMap[addr.String()] = field.NewInt(0)
-	}
-	erc1155TotalMap[addr.String()] = total
 
 	if err == nil {
 		forkErc1155TrasferAccountTotalMap.Add(addr, total.Bytes())
@@ -738,127 +622,67 @@ func (n *blockHandle) updateForkErc1155Account(ctx context.Context, addr common.
 }
 
 // write total for erc20
-func (n *blockHandle) updateForkErc20TrasferTotal(ctx context.Context, totalMap, erc20TotalMap map[string]*field.BigInt) error {
+func (n *blockHandle) updateForkErc20TrasferTotal(ctx context.Context, record *ForkRecorder) error {
 	if forkErc20TrasferTotal != nil {
-		//oldTotal, err := forkdb.ReadErc20Total(ctx, n.db)
-		//if err != nil {
-		//	if errors.Is(err, kv.NotFound) {
-		//		oldTotal = field.NewInt(0)
-		//		err = nil
-		//	} else {
-		//		log.Errorf("get erc20 fork transfer total: %v", err)
-		//		return err
-		//	}
-		//}
-
-		var oldTotal = &field.BigInt{}
-		if bytesRes, ok := forkOldErc20TransferAccountTotalMap.Get("erc20Total"); ok {
-			oldTotal.SetBytes(bytesRes.([]byte))
-		} else {
-			oldTotal = field.NewInt(0)
-		}
-		forkErc20TrasferTotal.Sub(oldTotal)
-
-		err := forkdb.WriteErc20Total(ctx, n.db, forkErc20TrasferTotal)
+		key := []byte("/fork/erc20/index")
+		oldTotal, err := n.readForkIndex(ctx, key)
 		if err != nil {
 			return err
 		}
 
-		key := []byte("/fork/erc20/total")
-		if totalMap[share.ForkTransferTbl+":"+string(key)] == nil {
-			totalMap[share.ForkTransferTbl+":"+string(key)] = field.NewInt(0)
+		total := field.NewInt(0).Add(forkErc20TrasferTotal).Sub(oldTotal)
+		if err = forkdb.WriteErc20Total(ctx, n.db, total); err != nil {
+			return err
 		}
-		totalMap[share.ForkTransferTbl+":"+string(key)].Add(forkErc20TrasferTotal)
 
-		forkErc20TrasferTotal.Add(oldTotal)
-		if erc20TotalMap["erc20Total"] == nil {
-			erc20TotalMap["erc20Total"] = field.NewInt(0)
+		if record.indexMap[string(key)] != nil {
+			key2 := []byte("/fork/erc20/total")
+			record.totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0).Add(record.indexMap[string(key)])
 		}
-		erc20TotalMap["erc20Total"] = forkTxTotal
 	}
 	return nil
 }
 
 // write total for erc721
-func (n *blockHandle) updateForkErc721TrasferTotal(ctx context.Context, totalMap, erc721TotalMap map[string]*field.BigInt) error {
+func (n *blockHandle) updateForkErc721TrasferTotal(ctx context.Context, record *ForkRecorder) error {
 	if forkErc721TrasferTotal != nil {
-		//oldTotal, err := forkdb.ReadErc721Total(ctx, n.db)
-		//if err != nil {
-		//	if errors.Is(err, kv.NotFound) {
-		//		oldTotal = field.NewInt(0)
-		//		err = nil
-		//	} else {
-		//		log.Errorf("get erc721 fork transfer total: %v", err)
-		//		return err
-		//	}
-		//}
-
-		var oldTotal = &field.BigInt{}
-		if bytesRes, ok := forkOldErc721TransferAccountTotalMap.Get("erc721Total"); ok {
-			oldTotal.SetBytes(bytesRes.([]byte))
-		} else {
-			oldTotal = field.NewInt(0)
-		}
-		forkErc721TrasferTotal.Sub(oldTotal)
-
-		err := forkdb.WriteErc721Total(ctx, n.db, forkErc721TrasferTotal)
+		key := []byte("/fork/erc721/index")
+		oldTotal, err := n.readForkIndex(ctx, key)
 		if err != nil {
 			return err
 		}
 
-		key := []byte("/fork/erc721/total")
-		if totalMap[share.ForkTransferTbl+":"+string(key)] == nil {
-			totalMap[share.ForkTransferTbl+":"+string(key)] = field.NewInt(0)
+		total := field.NewInt(0).Add(forkErc721TrasferTotal).Sub(oldTotal)
+		if err = forkdb.WriteErc721Total(ctx, n.db, total); err != nil {
+			return err
 		}
-		totalMap[share.ForkTransferTbl+":"+string(key)].Add(forkErc721TrasferTotal)
 
-		forkErc721TrasferTotal.Add(oldTotal)
-		if erc721TotalMap["erc721Total"] == nil {
-			erc721TotalMap["erc721Total"] = field.NewInt(0)
+		if record.indexMap[string(key)] != nil {
+			key2 := []byte("/fork/erc721/total")
+			record.totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0).Add(record.indexMap[string(key)])
 		}
-		erc721TotalMap["erc721Total"] = forkErc721TrasferTotal
 	}
 	return nil
 }
 
 // write total for erc155
-func (n *blockHandle) updateForkErc1155TrasferTotal(ctx context.Context, totalMap, erc1155TotalMap map[string]*field.BigInt) error {
+func (n *blockHandle) updateForkErc1155TrasferTotal(ctx context.Context, record *ForkRecorder) error {
 	if forkErc1155TrasferTotal != nil {
-		//oldTotal, err := forkdb.ReadErc1155Total(ctx, n.db)
-		//if err != nil {
-		//	if errors.Is(err, kv.NotFound) {
-		//		oldTotal = field.NewInt(0)
-		//		err = nil
-		//	} else {
-		//		log.Errorf("get erc1155 fork transfer total: %v", err)
-		//		return err
-		//	}
-		//}
-
-		var oldTotal = &field.BigInt{}
-		if bytesRes, ok := forkOldErc1155TransferAccountTotalMap.Get("erc1155Total"); ok {
-			oldTotal.SetBytes(bytesRes.([]byte))
-		} else {
-			oldTotal = field.NewInt(0)
-		}
-		forkErc1155TrasferTotal.Sub(oldTotal)
-
-		err := forkdb.WriteErc1155Total(ctx, n.db, forkErc1155TrasferTotal)
+		key := []byte("/fork/erc1155/index")
+		oldTotal, err := n.readForkIndex(ctx, key)
 		if err != nil {
 			return err
 		}
 
-		key := []byte("/fork/erc1155/total")
-		if totalMap[share.ForkTransferTbl+":"+string(key)] == nil {
-			totalMap[share.ForkTransferTbl+":"+string(key)] = field.NewInt(0)
+		total := field.NewInt(0).Add(forkErc1155TrasferTotal).Sub(oldTotal)
+		if err = forkdb.WriteErc1155Total(ctx, n.db, total); err != nil {
+			return err
 		}
-		totalMap[share.ForkTransferTbl+":"+string(key)].Add(forkErc1155TrasferTotal)
 
-		forkErc1155TrasferTotal.Add(oldTotal)
-		if erc1155TotalMap["erc1155Total"] == nil {
-			erc1155TotalMap["erc1155Total"] = field.NewInt(0)
+		if record.indexMap[string(key)] != nil {
+			key2 := []byte("/fork/erc1155/total")
+			record.totalMap[share.ForkTransferTbl+":"+string(key2)] = field.NewInt(0).Add(record.indexMap[string(key)])
 		}
-		erc1155TotalMap["erc1155Total"] = forkErc1155TrasferTotal
 	}
 	return nil
 }
@@ -15,12 +15,11 @@ import (
 )
 
 var (
-	forkTxTotal              *field.BigInt
-	forkAccountTxTotalMap    = utils.NewCache()
-	forkOldAccountTxTotalMap = utils.NewCache()
+	forkTxTotal           *field.BigInt
+	forkAccountTxTotalMap = utils.NewCache()
 )
 
-func (n *blockHandle) writeForkTxAndRt(ctx context.Context, tx *types.Tx, rt *types.Rt, deleteMap map[string][][]byte, indexMap, totalMap, accountTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkTxAndRt(ctx context.Context, tx *types.Tx, rt *types.Rt, deleteMap map[string][][]byte, indexMap, totalMap map[string]*field.BigInt) (err error) {
 	if forkTxTotal == nil {
 		forkTxTotal, err = forkdb.ReadTxTotal(ctx, n.db)
 		if err != nil {
@@ -31,6 +30,12 @@ func (n *blockHandle) writeForkTxAndRt(ctx context.Context, tx *types.Tx, rt *ty
 				return err
 			}
 		}
+		var oldTotal *field.BigInt
+		if oldTotal, err = n.readForkIndex(ctx, []byte("/fork/all/tx/index")); err != nil {
+			log.Errorf("get fork tx index: %v", err)
+			return err
+		}
+		forkTxTotal.Add(oldTotal)
 	}
 
 	if err = forkdb.WriteTx(ctx, n.db, tx.Hash, tx); err != nil {
@@ -57,21 +62,21 @@ func (n *blockHandle) writeForkTxAndRt(ctx context.Context, tx *types.Tx, rt *ty
 	deleteMap[share.ForkTxTbl] = append(deleteMap[share.ForkTxTbl], append([]byte("/fork/rt/"), tx.Hash.Bytes()...))
 
 	if tx.From != (common.Address{}) {
-		if err = n.writeForkAccountTx(ctx, tx.From, tx.Hash, deleteMap, indexMap, totalMap, accountTotalMap); err != nil {
+		if err = n.writeForkAccountTx(ctx, tx.From, tx.Hash, deleteMap, indexMap, totalMap); err != nil {
 			log.Errorf("write fork account(%s) tx: %v", tx.From, err)
 			return err
 		}
 	}
 
 	if tx.To != nil && tx.To.Hex() != (common.Address{}).Hex() {
-		if err = n.writeForkAccountTx(ctx, *tx.To, tx.Hash, deleteMap, indexMap, totalMap, accountTotalMap); err != nil {
+		if err = n.writeForkAccountTx(ctx, *tx.To, tx.Hash, deleteMap, indexMap, totalMap); err != nil {
 			log.Errorf("write fork account(%s) tx: %v", tx.To.Hex(), err)
 			return err
 		}
 	}
 
 	if rt.ContractAddress != nil && rt.ContractAddress.Hex() != (common.Address{}).Hex() {
-		if err = n.writeForkAccountTx(ctx, *rt.ContractAddress, tx.Hash, deleteMap, indexMap, totalMap, accountTotalMap); err != nil {
+		if err = n.writeForkAccountTx(ctx, *rt.ContractAddress, tx.Hash, deleteMap, indexMap, totalMap); err != nil {
 			log.Errorf("write fork account(%s) tx: %v", rt.ContractAddress.Hex(), err)
 			return err
 		}
@@ -80,7 +85,14 @@ func (n *blockHandle) writeForkTxAndRt(ctx context.Context, tx *types.Tx, rt *ty
 	return nil
 }
 
-func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Address, hash common.Hash, deleteMap map[string][][]byte, indexMap, totalMap, accountTotalMap map[string]*field.BigInt) (err error) {
+func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Address, hash common.Hash, deleteMap map[string][][]byte, indexMap, totalMap map[string]*field.BigInt) (err error) {
+	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/tx/index")...)
+	oldTotal, err := n.readForkIndex(ctx, key)
+	if err != nil {
+		log.Errorf("get fork account(%s) tx index: %v", addr.Hex(), err)
+		return err
+	}
+
 	var total = &field.BigInt{}
 	if bytesRes, ok := forkAccountTxTotalMap.Get(addr); ok {
 		total.SetBytes(bytesRes.([]byte))
@@ -90,8 +102,12 @@ func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Addres
 			if errors.Is(err, kv.NotFound) {
 				total = field.NewInt(0)
 				err = nil
+			} else {
+				log.Errorf("get fork account(%s) tx total: %v", addr.Hex(), err)
+				return err
 			}
 		}
+		total.Add(oldTotal)
 	}
 
 	total.Add(field.NewInt(1))
@@ -101,24 +117,13 @@ func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Addres
 		return err
 	}
 	deleteMap[share.ForkAccountsTbl] = append(deleteMap[share.ForkAccountsTbl], append(append([]byte("/fork/"), addr.Bytes()...), append([]byte("/tx/"), total.Bytes()...)...))
-	key := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/tx/index")...)
 	if indexMap[string(key)] == nil {
 		indexMap[string(key)] = field.NewInt(0)
 	}
 	indexMap[string(key)].Add(field.NewInt(1))
 
-	var oldTotal = &field.BigInt{}
-	if bytesRes, ok := forkOldAccountTxTotalMap.Get(addr.String()); ok {
-		oldTotal.SetBytes(bytesRes.([]byte))
-	} else {
-		oldTotal = field.NewInt(0)
-	}
 	total.Sub(oldTotal)
-
 	err = forkdb.WriteAccountTxTotal(ctx, n.db, addr, total)
-	//if err == nil {
-	//	forkAccountTxTotalMap.Add(addr, total.Bytes())
-	//}
 
 	key2 := append(append([]byte("/fork/"), addr.Bytes()...), []byte("/tx/total")...)
 	if totalMap[share.ForkAccountsTbl+":"+string(key2)] == nil {
@@ -127,11 +132,6 @@ func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Addres
 	totalMap[share.ForkAccountsTbl+":"+string(key2)].Add(field.NewInt(1))
 
 	total.Add(oldTotal)
-	if accountTotalMap[addr.String()] == nil {
-		accountTotalMap[addr.String()] = field.NewInt(0)
-	}
-	accountTotalMap[addr.String()] = total
-
 	if err == nil {
 		forkAccountTxTotalMap.Add(addr, total.Bytes())
 	}
@@ -139,43 +139,45 @@ func (n *blockHandle) writeForkAccountTx(ctx context.Context, addr common.Addres
 	return
 }
 
-func (n *blockHandle) writeForkTxTotal(ctx context.Context, totalMap map[string]*field.BigInt, accountTotalMap map[string]*field.BigInt) error {
+func (n *blockHandle) writeForkTxTotal(ctx context.Context, indexMap, totalMap map[string]*field.BigInt) error {
 	if forkTxTotal != nil {
-		//oldTotal, err := forkdb.ReadTxTotal(ctx, n.db)
-		//if err != nil {
-		//	if errors.Is(err, kv.NotFound) {
-		//		oldTotal = field.NewInt(0)
-		//		err = nil
-		//	} else {
-		//		log.Errorf("get fork tx total: %v", err)
-		//		return err
-		//	}
-		//}
-
-		var oldTotal = &field.BigInt{}
-		if bytesRes, ok := forkOldAccountTxTotalMap.Get("txTotal"); ok {
-			oldTotal.SetBytes(bytesRes.([]byte))
-		} else {
-			oldTotal = field.NewInt(0)
-		}
-		forkTxTotal.Sub(oldTotal)
-
-		err := forkdb.WriteTxTotal(ctx, n.db, forkTxTotal)
+		key := []byte("/fork/all/tx/index")
+		oldTotal, err := n.readForkIndex(ctx, key)
 		if err != nil {
 			return err
 		}
 
-		key := []byte("/fork/all/tx/total")
-		if totalMap[share.ForkTxTbl+":"+string(key)] == nil {
-			totalMap[share.ForkTxTbl+":"+string(key)] = field.NewInt(0)
+		total := field.NewInt(0).Add(forkTxTotal).Sub(oldTotal)
+		if err = forkdb.WriteTxTotal(ctx, n.db, total); err != nil {
+			return err
 		}
-		totalMap[share.ForkTxTbl+":"+string(key)].Add(forkTxTotal)
 
-		forkTxTotal.Add(oldTotal)
-		if accountTotalMap["txTotal"] == nil {
-			accountTotalMap["txTotal"] = field.NewInt(0)
+		// the total shrinks by the txs of this block once it leaves the fork window
+		if indexMap[string(key)] != nil {
+			key2 := []byte("/fork/all/tx/total")
+			totalMap[share.ForkTxTbl+":"+string(key2)] = field.NewInt(0).Add(indexMap[string(key)])
 		}
-		accountTotalMap["txTotal"] = forkTxTotal
 	}
 	return nil
 }
+
+// readForkIndex returns how many entries under an index key have already been moved out of the fork window
+func (n *blockHandle) readForkIndex(ctx context.Context, key []byte) (index *field.BigInt, err error) {
+	return ReadForkIndex(ctx, n.db, key)
+}
+
+// ReadForkIndex returns the fork index stored under key, the count of ForkRecorder.Index
+// over the fork blocks which have left the fork db
+func ReadForkIndex(ctx context.Context, db kv.Reader, key []byte) (index *field.BigInt, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, key, &kv.ReadOption{Table: share.ForkIndexTbl})
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return field.NewInt(0), nil
+		}
+		return nil, err
+	}
+	index = &field.BigInt{}
+	index.SetBytes(bytesRes)
+	return
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/gasoracle"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+)
+
+// SetGasOracle makes the committed blocks be tracked by the gas oracle
+func (n *Sync) SetGasOracle(o *gasoracle.Oracle) {
+	n.gasOracle = o
+}
+
+// loadGasOracle fills the gas oracle with the latest stored blocks, so that the estimates
+// are served before the sync has caught up again
+func (n *Sync) loadGasOracle() error {
+	if n.gasOracle == nil {
+		return nil
+	}
+	full, _, err := n.readSyncingBlocks()
+	if err != nil {
+		return err
+	}
+	last := full.ToUint64()
+	first := n.startBlock
+	if size := uint64(n.gasOracle.Size()); last >= first+size {
+		first = last - size + 1
+	}
+	if last == 0 || last < first {
+		return nil
+	}
+
+	ctx := context.Background()
+	for i := first; i <= last; i++ {
+		number := field.NewInt(int64(i))
+		bk, err := fulldb.ReadBlock(ctx, n.db, number)
+		if err != nil {
+			// not stored yet, it is tracked when it is synced
+			continue
+		}
+		bk.Number = number
+		data := &job.SyncJob{BlockData: bk}
+		for j := uint64(1); j <= bk.TransactionTotal.ToUint64(); j++ {
+			tx, err := fulldb.ReadBlockTxByIndex(ctx, n.db, number, field.NewInt(int64(j)))
+			if err != nil {
+				return err
+			}
+			rt, err := fulldb.ReadRt(ctx, n.db, tx.Hash)
+			if err != nil {
+				return err
+			}
+			data.TransactionDatas = append(data.TransactionDatas, tx)
+			data.ReceiptDatas = append(data.ReceiptDatas, rt)
+		}
+		n.gasOracle.Add(newGasBlock(data))
+	}
+	return nil
+}
+
+// trackGas adds the newest block of the jobs to the gas oracle, as publishBlock does for the stream
+func (n *Sync) trackGas(jobs *Jobs) {
+	if n.gasOracle == nil {
+		return
+	}
+	data := jobs.Fork
+	if data == nil {
+		data = jobs.Main
+	}
+	n.gasOracle.Add(newGasBlock(data))
+}
+
+// newGasBlock takes the priority fee of a tx from the effective gas price of its receipt,
+// or from the gas price of the tx when the node does not return it
+func newGasBlock(data *job.SyncJob) *gasoracle.Block {
+	baseFee := new(big.Int).Set((*big.Int)(&data.BlockData.BaseFee))
+	b := &gasoracle.Block{
+		Number:       data.BlockData.Number.ToUint64(),
+		Timestamp:    data.BlockData.TimeStamp.ToUint64(),
+		BaseFee:      baseFee,
+		GasUsed:      data.BlockData.GasUsed.ToUint64(),
+		GasLimit:     data.BlockData.GasLimit.ToUint64(),
+		PriorityFees: make([]*big.Int, 0, len(data.TransactionDatas)),
+	}
+	for i, v := range data.TransactionDatas {
+		price := new(big.Int).Set((*big.Int)(&v.GasPrice))
+		if rt := data.ReceiptDatas[i]; (*big.Int)(&rt.EffectiveGasPrice).Sign() > 0 {
+			price.Set((*big.Int)(&rt.EffectiveGasPrice))
+		}
+		fee := price.Sub(price, baseFee)
+		if fee.Sign() < 0 {
+			fee.SetInt64(0)
+		}
+		b.PriorityFees = append(b.PriorityFees, fee)
+	}
+	return b
+}
@@ -7,8 +7,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/uchainorg/uscan/pkg/contract"
-	"github.com/uchainorg/uscan/pkg/contract/eip"
 	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
 	"github.com/uchainorg/uscan/pkg/kv"
 	"github.com/uchainorg/uscan/pkg/log"
 	"github.com/uchainorg/uscan/pkg/storage/forkdb"
@@ -17,59 +17,52 @@ import (
 	"github.com/uchainorg/uscan/share"
 )
 
-var blockDeleteMap = make(map[*field.BigInt]map[string][][]byte, 0)               // block number => table/key
-var blockIndexMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)           // block number => key/index
-var blockTotalMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)           // block number => table:key/total
-var blockAccountMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)         // block number => account, total tx/total
-var blockItxMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)             // block number => account, total itx/total
-var blockErc20Map = make(map[*field.BigInt]map[string]*field.BigInt, 0)           // block number => erc20 account, total tx/total
-var blockErc721Map = make(map[*field.BigInt]map[string]*field.BigInt, 0)          // block number => erc721 account, total tx/total
-var blockErc1155Map = make(map[*field.BigInt]map[string]*field.BigInt, 0)         // block number => erc1155 account, total tx/total
-var blockErc20ContractMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)   // block number => erc20 contract account, total tx/total
-var blockErc721ContractMap = make(map[*field.BigInt]map[string]*field.BigInt, 0)  // block number => erc721 contract account, total tx/total
-var blockErc1155ContractMap = make(map[*field.BigInt]map[string]*field.BigInt, 0) // block number => erc1155 contract account, total tx/total
-
 type blockHandle struct {
+	data                 *job.SyncJob
 	blockData            *types.Block
 	transactionData      []*types.Tx
 	receiptData          []*types.Rt
 	contractOrMemberData map[common.Address]*types.Account
 	contractInfoMap      map[common.Address]*types.Contract
-	proxyContracts       map[common.Address]common.Address
+	proxies              map[common.Address]*types.Proxy
 	internalTxs          map[common.Hash][]*types.InternalTx
 	callFrames           map[common.Hash]*types.CallFrame
 	contractClient       contract.Contractor
 	db                   kv.Database
+	startBlock           uint64
+	processors           []Processor
 
 	newAddrTotal    *field.BigInt
 	newErc20Total   *field.BigInt
 	newErc721Total  *field.BigInt
 	newErc1155Total *field.BigInt
+
+	backfill  bool                     // the block is below the syncing block
+	storedTxs map[common.Hash]struct{} // txs indexed before, skipped on backfill
+	txsData   *job.SyncJob             // the block without storedTxs
 }
 
 func newBlockHandle(
-	blockData *types.Block,
-	transactionData []*types.Tx,
-	receiptData []*types.Rt,
-	contractOrMemberData map[common.Address]*types.Account,
-	contractInfoMap map[common.Address]*types.Contract,
-	proxyContracts map[common.Address]common.Address,
-	internalTxs map[common.Hash][]*types.InternalTx,
-	callFrames map[common.Hash]*types.CallFrame,
+	data *job.SyncJob,
 	contractClient contract.Contractor,
 	db kv.Database,
+	startBlock uint64,
+	processors []Processor,
 ) *blockHandle {
 	return &blockHandle{
-		blockData:            blockData,
-		transactionData:      transactionData,
-		receiptData:          receiptData,
-		contractOrMemberData: contractOrMemberData,
-		contractInfoMap:      contractInfoMap,
-		proxyContracts:       proxyContracts,
-		internalTxs:          internalTxs,
-		callFrames:           callFrames,
+		data:                 data,
+		blockData:            data.BlockData,
+		transactionData:      data.TransactionDatas,
+		receiptData:          data.ReceiptDatas,
+		contractOrMemberData: data.ContractOrMemberData,
+		contractInfoMap:      data.ContractInfoMap,
+		proxies:              data.Proxies,
+		internalTxs:          data.InternalTxs,
+		callFrames:           data.CallFrames,
 		contractClient:       contractClient,
 		db:                   db,
+		startBlock:           startBlock,
+		processors:           processors,
 		newAddrTotal:         field.NewInt(0),
 		newErc20Total:        field.NewInt(0),
 		newErc721Total:       field.NewInt(0),
@@ -84,6 +77,7 @@ func (n *blockHandle) handleMain(ctx context.Context) (err error) {
 		return err
 	}
 
+	balances := n.blockBalances()
 	n.newAddrTotal, err = n.checkNewAddr(ctx)
 	if err != nil {
 		log.Errorf("read acccount to merge: %v", err)
@@ -120,12 +114,24 @@ func (n *blockHandle) handleMain(ctx context.Context) (err error) {
 		}
 	}
 
+	if err = n.runProcessors(ctx); err != nil {
+		return err
+	}
+
 	// all account about block write to kv
 	if err = n.updateAccounts(ctx); err != nil {
 		log.Errorf("write account : %v", err)
 		return err
 	}
 
+	if err = n.writeBalanceHistory(ctx, balances); err != nil {
+		return err
+	}
+
+	if err = n.writeWithdrawals(ctx); err != nil {
+		return err
+	}
+
 	if err = n.updateHome(ctx); err != nil {
 		log.Errorf("write home : %v", err)
 		return err
@@ -134,160 +140,96 @@ func (n *blockHandle) handleMain(ctx context.Context) (err error) {
 	return nil
 }
 
-func (n *blockHandle) handleDeleteFork(ctx context.Context, blockNumber *field.BigInt) (err error) {
-
-	for k, v := range blockDeleteMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				for _, v2 := range v1 {
-					_, err = n.db.Get(ctx, v2, &kv.ReadOption{Table: k1})
-					if err == nil {
-						err = n.db.Del(ctx, v2, &kv.WriteOption{Table: k1})
-						if err != nil {
-							return err
-						}
-					}
-				}
-			}
-			delete(blockDeleteMap, k)
+// handleBackfill stores a block below the syncing block again. Txs which have been
+// indexed before are skipped, so the cumulative totals are only bumped once per tx.
+func (n *blockHandle) handleBackfill(ctx context.Context) (err error) {
+	n.backfill = true
+	n.storedTxs = make(map[common.Hash]struct{})
+	for _, v := range n.transactionData {
+		_, err = fulldb.ReadTx(ctx, n.db, v.Hash)
+		if err == nil {
+			n.storedTxs[v.Hash] = struct{}{}
+			continue
 		}
-	}
-
-	for k, v := range blockIndexMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				i := &field.BigInt{}
-				bytesRes, err := n.db.Get(ctx, []byte(k1), &kv.ReadOption{Table: share.ForkIndexTbl})
-				if err != nil {
-					if errors.Is(err, kv.NotFound) {
-						i = field.NewInt(0)
-						err = nil
-					} else {
-						return err
-					}
-				} else {
-					i.SetBytes(bytesRes)
-				}
-				i.Add(v1)
-				err = n.db.Put(ctx, []byte(k1), i.Bytes(), &kv.WriteOption{Table: share.ForkIndexTbl})
-				if err != nil {
-					return err
-				}
-			}
-			delete(blockIndexMap, k)
-		}
-	}
-
-	for k, v := range blockTotalMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				i := &field.BigInt{}
-				arr := strings.Split(k1, ":")
-				tableName := arr[0]
-				key := []byte(arr[1])
-				bytesRes, err := n.db.Get(ctx, key, &kv.ReadOption{Table: tableName})
-				if err != nil {
-					return err
-				}
-				i.SetBytes(bytesRes)
-				i.Sub(v1)
-				err = n.db.Put(ctx, key, i.Bytes(), &kv.WriteOption{Table: tableName})
-				if err != nil {
-					return err
-				}
-			}
-			delete(blockTotalMap, k)
+		if !errors.Is(err, kv.NotFound) {
+			log.Errorf("read tx(%s): %v", v.Hash.Hex(), err)
+			return err
 		}
 	}
-
-	for k, v := range blockAccountMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldAccountTxTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockAccountMap, k)
-		}
+	for k := range n.storedTxs {
+		delete(n.internalTxs, k)
 	}
 
-	for k, v := range blockItxMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldAccountITxTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockItxMap, k)
-		}
-	}
+	return n.handleMain(ctx)
+}
 
-	for k, v := range blockErc20Map {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc20TransferAccountTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockErc20Map, k)
+func (n *blockHandle) handleDeleteFork(ctx context.Context, blockNumber *field.BigInt) (err error) {
+	record, err := forkdb.ReadForkRecord(ctx, n.db, blockNumber)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil
 		}
+		return err
 	}
 
-	for k, v := range blockErc721Map {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc721TransferAccountTotalMap.Add(k1, v1.Bytes())
+	for _, v1 := range record.Deletes {
+		for _, v2 := range v1.Keys {
+			_, err = n.db.Get(ctx, v2, &kv.ReadOption{Table: v1.Table})
+			if err == nil {
+				err = n.db.Del(ctx, v2, &kv.WriteOption{Table: v1.Table})
+				if err != nil {
+					return err
+				}
 			}
-			delete(blockErc721Map, k)
 		}
 	}
 
-	for k, v := range blockErc1155Map {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc1155TransferAccountTotalMap.Add(k1, v1.Bytes())
+	for _, v1 := range record.Indexes {
+		i := &field.BigInt{}
+		bytesRes, err := n.db.Get(ctx, []byte(v1.Key), &kv.ReadOption{Table: share.ForkIndexTbl})
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				i = field.NewInt(0)
+				err = nil
+			} else {
+				return err
 			}
-			delete(blockErc1155Map, k)
+		} else {
+			i.SetBytes(bytesRes)
 		}
-	}
-
-	for k, v := range blockErc20ContractMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc20TransferContractTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockErc20ContractMap, k)
+		i.Add(&v1.Count)
+		err = n.db.Put(ctx, []byte(v1.Key), i.Bytes(), &kv.WriteOption{Table: share.ForkIndexTbl})
+		if err != nil {
+			return err
 		}
 	}
 
-	for k, v := range blockErc721ContractMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc721TransferContractTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockErc721ContractMap, k)
+	for _, v1 := range record.Totals {
+		i := &field.BigInt{}
+		arr := strings.SplitN(v1.Key, ":", 2)
+		tableName := arr[0]
+		key := []byte(arr[1])
+		bytesRes, err := n.db.Get(ctx, key, &kv.ReadOption{Table: tableName})
+		if err != nil {
+			return err
 		}
-	}
-
-	for k, v := range blockErc1155ContractMap {
-		if k.Cmp(blockNumber) == 0 {
-			for k1, v1 := range v {
-				forkOldErc1155TransferContractTotalMap.Add(k1, v1.Bytes())
-			}
-			delete(blockErc1155ContractMap, k)
+		i.SetBytes(bytesRes)
+		i.Sub(&v1.Count)
+		err = n.db.Put(ctx, key, i.Bytes(), &kv.WriteOption{Table: tableName})
+		if err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return forkdb.DeleteForkRecord(ctx, n.db, blockNumber)
 }
 
 func (n *blockHandle) handleFork(ctx context.Context) (err error) {
 
-	var deleteMap = make(map[string][][]byte, 0)                    // table => key
-	var indexMap = make(map[string]*field.BigInt, 0)                // key => index
-	var totalMap = make(map[string]*field.BigInt, 0)                // table:key => total
-	var accountTotalMap = make(map[string]*field.BigInt, 0)         // account/total tx => total
-	var iTxTotalMap = make(map[string]*field.BigInt, 0)             // account/total itx  => total
-	var erc20TotalMap = make(map[string]*field.BigInt, 0)           // erc20 transfer tx  => total
-	var erc721TotalMap = make(map[string]*field.BigInt, 0)          // erc721 transfer tx  => total
-	var erc1155TotalMap = make(map[string]*field.BigInt, 0)         // erc1155 transfer tx  => total
-	var erc20ContractTotalMap = make(map[string]*field.BigInt, 0)   // erc20 transfer contract tx  => total
-	var erc721ContractTotalMap = make(map[string]*field.BigInt, 0)  // erc20 transfer contract tx  => total
-	var erc1155ContractTotalMap = make(map[string]*field.BigInt, 0) // erc20 transfer contract tx  => total
+	var (
+		record                        = newForkRecorder()
+		deleteMap, indexMap, totalMap = record.deleteMap, record.indexMap, record.totalMap
+	)
 
 	err = forkdb.WriteBlock(ctx, n.db, n.blockData.Number, n.blockData)
 	if err != nil {
@@ -316,12 +258,12 @@ func (n *blockHandle) handleFork(ctx context.Context) (err error) {
 	//}
 
 	if len(n.transactionData) > 0 {
-		if err = n.writeForkTxAndRtLog(ctx, n.transactionData, n.receiptData, deleteMap, indexMap, totalMap, accountTotalMap, erc20TotalMap, erc721TotalMap, erc1155TotalMap, erc20ContractTotalMap, erc721ContractTotalMap, erc1155ContractTotalMap); err != nil {
+		if err = n.writeForkTxAndRtLog(ctx, n.transactionData, n.receiptData, deleteMap, indexMap, totalMap); err != nil {
 			log.Errorf("write fork tx and rt: %v", err)
 			return err
 		}
 
-		if err = n.writeForkITx(ctx, n.internalTxs, deleteMap, indexMap, totalMap, iTxTotalMap); err != nil {
+		if err = n.writeForkITx(ctx, n.internalTxs, deleteMap, indexMap, totalMap); err != nil {
 			log.Errorf("write fork itxs: %v", err)
 			return err
 		}
@@ -332,6 +274,10 @@ func (n *blockHandle) handleFork(ctx context.Context) (err error) {
 		}
 	}
 
+	if err = n.runForkProcessors(ctx, record); err != nil {
+		return err
+	}
+
 	// all account about block write to kv
 	if err = n.updateForkAccounts(ctx); err != nil {
 		log.Errorf("write fork account : %v", err)
@@ -343,18 +289,55 @@ func (n *blockHandle) handleFork(ctx context.Context) (err error) {
 		return err
 	}
 
-	blockDeleteMap[n.blockData.Number] = deleteMap
-	blockIndexMap[n.blockData.Number] = indexMap
-	blockTotalMap[n.blockData.Number] = totalMap
-	blockAccountMap[n.blockData.Number] = accountTotalMap
-	blockItxMap[n.blockData.Number] = iTxTotalMap
-	blockErc20Map[n.blockData.Number] = erc20TotalMap
-	blockErc721Map[n.blockData.Number] = erc721TotalMap
-	blockErc1155Map[n.blockData.Number] = erc1155TotalMap
-	blockErc20ContractMap[n.blockData.Number] = erc20ContractTotalMap
-	blockErc721ContractMap[n.blockData.Number] = erc721ContractTotalMap
-	blockErc1155ContractMap[n.blockData.Number] = erc1155ContractTotalMap
+	if err = forkdb.WriteForkRecord(ctx, n.db, n.blockData.Number, newForkRecord(deleteMap, indexMap, totalMap)); err != nil {
+		log.Errorf("write fork record : %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// newTxsData returns the block without the txs indexed before on backfill
+func (n *blockHandle) newTxsData() *job.SyncJob {
+	if len(n.storedTxs) == 0 {
+		return n.data
+	}
+	// the same data is handed to the processors and the stats, its transfers are decoded once
+	if n.txsData != nil {
+		return n.txsData
+	}
+	filtered := *n.data
+	filtered.TransactionDatas = make([]*types.Tx, 0, len(n.transactionData))
+	filtered.ReceiptDatas = make([]*types.Rt, 0, len(n.receiptData))
+	for i, v := range n.transactionData {
+		if _, ok := n.storedTxs[v.Hash]; !ok {
+			filtered.TransactionDatas = append(filtered.TransactionDatas, v)
+			filtered.ReceiptDatas = append(filtered.ReceiptDatas, n.receiptData[i])
+		}
+	}
+	n.txsData = &filtered
+	return n.txsData
+}
+
+// runProcessors runs the processors on the block, txs indexed before are left out on backfill
+func (n *blockHandle) runProcessors(ctx context.Context) (err error) {
+	data := n.newTxsData()
+	for _, p := range n.processors {
+		if err = p.HandleMain(ctx, n.db, data); err != nil {
+			log.Errorf("processor %s: %v, block: %s", p.Name(), err, n.blockData.Number.String())
+			return err
+		}
+	}
+	return nil
+}
 
+func (n *blockHandle) runForkProcessors(ctx context.Context, record *ForkRecorder) (err error) {
+	for _, p := range n.processors {
+		if err = p.HandleFork(ctx, n.db, n.data, record); err != nil {
+			log.Errorf("fork processor %s: %v, block: %s", p.Name(), err, n.blockData.Number.String())
+			return err
+		}
+	}
 	return nil
 }
 
@@ -365,8 +348,8 @@ func (n *blockHandle) handleContractData(ctx context.Context) (err error) {
 			return err
 		}
 	}
-	if len(n.proxyContracts) > 0 {
-		if err = n.writeProxyContract(ctx, n.proxyContracts); err != nil {
+	if len(n.proxies) > 0 {
+		if err = n.writeProxies(ctx, n.proxies); err != nil {
 			log.Errorf("write proxy contract: %v", err)
 			return err
 		}
@@ -382,111 +365,13 @@ func (n *blockHandle) writeTxAndRtLog(ctx context.Context, transactionData []*ty
 			log.Errorf("write block index(%d): %v", i, err)
 			return err
 		}
+		if _, ok := n.storedTxs[v.Hash]; ok {
+			continue
+		}
 		if err = n.writeTxAndRt(ctx, v, receiptData[i]); err != nil {
 			log.Errorf("writeTxAndRt tx(%s): %v", v.Hash.Hex(), err)
 			return err
 		}
-
-		var (
-			erc20Transfer         *eip.Erc20Transfer
-			erc721Transfer        *eip.Ieip721Transfer
-			erc1155TransferSignle *eip.Ieip1155TransferSingle
-			erc1155TransferBatch  *eip.Ieip1155TransferBatch
-		)
-		for _, rtLog := range receiptData[i].Logs {
-			if len(rtLog.Topics) >= 3 {
-				switch rtLog.Topics[0] {
-				case contract.TransferEventTopic:
-					if len(rtLog.Data) > 0 {
-						erc20Transfer, err = n.contractClient.Erc20Transfer(rtLog.Address.Hex(), rtLog.ToEthLog())
-						if err == nil {
-							if err = n.writeErc20Transfer(ctx, &types.Erc20Transfer{
-								TransactionHash: v.Hash,
-								BlockNumber:     v.BlockNum,
-								Contract:        rtLog.Address,
-								Method:          v.Method,
-								From:            erc20Transfer.From,
-								To:              erc20Transfer.To,
-								Amount:          (field.BigInt)(*erc20Transfer.Value),
-								TimeStamp:       n.blockData.TimeStamp,
-							}); err != nil {
-								log.Errorf("write erc20Transfer: %v", err)
-								return err
-							}
-						}
-					} else {
-						erc721Transfer, err = n.contractClient.Erc721Transfer(rtLog.Address.Hex(), rtLog.ToEthLog())
-						if err == nil {
-							if err = n.writeErc721Transfer(ctx, &types.Erc721Transfer{
-								TransactionHash: v.Hash,
-								BlockNumber:     v.BlockNum,
-								Contract:        rtLog.Address,
-								Method:          v.Method,
-								From:            erc721Transfer.From,
-								To:              erc721Transfer.To,
-								TokenId:         (field.BigInt)(*erc721Transfer.TokenId),
-								TimeStamp:       n.blockData.TimeStamp,
-							}); err != nil {
-								log.Errorf("write erc721Transfer: %v", err)
-								return err
-							}
-						}
-					}
-
-				case contract.TransferSingleEventTopic:
-					erc1155TransferSignle, err = n.contractClient.Erc1155TransferSingle(rtLog.Address.Hex(), rtLog.ToEthLog())
-					if err == nil {
-						if err = n.writeErc1155Transfer(ctx, &types.Erc1155Transfer{
-							TransactionHash: v.Hash,
-							BlockNumber:     v.BlockNum,
-							Contract:        rtLog.Address,
-							Method:          v.Method,
-							From:            erc1155TransferSignle.From,
-							To:              erc1155TransferSignle.To,
-							TokenID:         (field.BigInt)(*erc1155TransferSignle.Id),
-							Quantity:        (field.BigInt)(*erc1155TransferSignle.Value),
-							TimeStamp:       n.blockData.TimeStamp,
-						}); err != nil {
-							log.Errorf("write erc1155Transfer single: %v", err)
-							return err
-						}
-					}
-				case contract.TransferBatchEventTopic:
-					erc1155TransferBatch, err = n.contractClient.Erc1155TransferBatch(rtLog.Address.Hex(), rtLog.ToEthLog())
-					if err == nil {
-						for i := range erc1155TransferBatch.Ids {
-							if err = n.writeErc1155Transfer(ctx, &types.Erc1155Transfer{
-								TransactionHash: v.Hash,
-								BlockNumber:     v.BlockNum,
-								Contract:        rtLog.Address,
-								Method:          v.Method,
-								From:            erc1155TransferBatch.From,
-								To:              erc1155TransferBatch.To,
-								TokenID:         (field.BigInt)(*erc1155TransferBatch.Ids[i]),
-								Quantity:        (field.BigInt)(*erc1155TransferBatch.Values[i]),
-								TimeStamp:       n.blockData.TimeStamp,
-							}); err != nil {
-								log.Errorf("write erc1155Transfer single: %v", err)
-								return err
-							}
-						}
-					}
-				}
-			}
-		}
-
-		if err = n.updateErc20TrasferTotal(ctx); err != nil {
-			log.Errorf("update erc20 transfer total: %v", err)
-			return err
-		}
-		if err = n.updateErc721TrasferTotal(ctx); err != nil {
-			log.Errorf("update erc721 transfer total: %v", err)
-			return err
-		}
-		if err = n.updateErc1155TrasferTotal(ctx); err != nil {
-			log.Errorf("update erc1155 transfer total: %v", err)
-			return err
-		}
 	}
 
 	return n.writeTxTotal(ctx)
@@ -503,3 +388,21 @@ func (n *blockHandle) writeTraceTx2(ctx context.Context, callFrames map[common.H
 	}
 	return nil
 }
+
+func newForkRecord(deleteMap map[string][][]byte, indexMap, totalMap map[string]*field.BigInt) *types.ForkRecord {
+	record := &types.ForkRecord{
+		Deletes: make([]*types.ForkRecordKeys, 0, len(deleteMap)),
+		Indexes: make([]*types.ForkRecordCount, 0, len(indexMap)),
+		Totals:  make([]*types.ForkRecordCount, 0, len(totalMap)),
+	}
+	for k, v := range deleteMap {
+		record.Deletes = append(record.Deletes, &types.ForkRecordKeys{Table: k, Keys: v})
+	}
+	for k, v := range indexMap {
+		record.Indexes = append(record.Indexes, &types.ForkRecordCount{Key: k, Count: *v})
+	}
+	for k, v := range totalMap {
+		record.Totals = append(record.Totals, &types.ForkRecordCount{Key: k, Count: *v})
+	}
+	return record
+}
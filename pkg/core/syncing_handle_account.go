@@ -23,6 +23,9 @@ func (n *blockHandle) checkNewAddr(ctx context.Context) (*field.BigInt, error) {
 			}
 			newAddrTotal.Add(field.NewInt(1))
 			account = &types.Account{}
+		} else if n.backfill {
+			// the stored balance is from a later block
+			v.Balance = account.Balance
 		}
 
 		n.contractOrMemberData[k] = n.mergeAccount(account, v)
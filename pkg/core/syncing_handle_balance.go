@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// blockBalances returns the balances fetched at the block, they must be taken before
+// the accounts are merged as a backfill keeps the stored balance of a later block
+func (n *blockHandle) blockBalances() map[common.Address]field.BigInt {
+	balances := make(map[common.Address]field.BigInt, len(n.contractOrMemberData))
+	for k, v := range n.contractOrMemberData {
+		balances[k] = v.Balance
+	}
+	return balances
+}
+
+// writeBalanceHistory records the balances which differ from the one of the account at the previous block.
+// Only blocks out of the fork window are recorded.
+func (n *blockHandle) writeBalanceHistory(ctx context.Context, balances map[common.Address]field.BigInt) error {
+	number := n.blockData.Number.ToUint64()
+	for addr, balance := range balances {
+		balance := balance
+		if number > 0 {
+			last, err := fulldb.ReadBalanceAt(ctx, n.db, addr, number-1)
+			if err != nil && !errors.Is(err, kv.NotFound) {
+				log.Errorf("read balance of %s at %d: %v", addr.Hex(), number-1, err)
+				return err
+			}
+			if last != nil && last.Balance.Cmp(&balance) == 0 {
+				continue
+			}
+		}
+		if err := fulldb.WriteBalanceChange(ctx, n.db, addr, n.balanceChange(&balance)); err != nil {
+			log.Errorf("write balance of %s at %d: %v", addr.Hex(), number, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// balanceChange is a balance at the end of the block. Histories are only written by the
+// main blocks, a main block reorged out within reorg_depth has its entries removed by
+// the rollback of its journal.
+func (n *blockHandle) balanceChange(balance *field.BigInt) *types.BalanceChange {
+	return &types.BalanceChange{
+		BlockNumber: n.blockData.Number.ToUint64(),
+		TimeStamp:   n.blockData.TimeStamp.ToUint64(),
+		Balance:     *balance,
+	}
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// When the db is indexed from a start block, the token state built up before it is missing.
+// Tokens and holders seen for the first time are seeded with the chain state of the block
+// before the current one, then the transfers of the block are applied on top of it.
+// The calls need an archive node, if they fail (or the contract did not exist yet) the
+// state is built from zero like before.
+
+func (n *blockHandle) bootstrapped() bool {
+	return n.startBlock > 0
+}
+
+func (n *blockHandle) bootstrapBlock() *big.Int {
+	return new(big.Int).SetUint64(n.blockData.Number.ToUint64() - 1)
+}
+
+func (n *blockHandle) bootstrapTotalSupply(addr common.Address, acc *types.Account) {
+	if !n.bootstrapped() {
+		return
+	}
+	supply, err := n.contractClient.GetContractTotalSupplyAt(addr, n.bootstrapBlock())
+	if err != nil {
+		return
+	}
+	acc.TokenTotalSupply.SetBytes(supply.Bytes())
+}
+
+func (n *blockHandle) bootstrapErc20Holder(contract, addr common.Address) *field.BigInt {
+	amount := field.NewInt(0)
+	if !n.bootstrapped() {
+		return amount
+	}
+	balance, err := n.contractClient.GetErc20BalanceAt(contract, addr, n.bootstrapBlock())
+	if err != nil {
+		return amount
+	}
+	amount.SetBytes(balance.Bytes())
+	return amount
+}
+
+func (n *blockHandle) bootstrapErc721Holder(contract, addr common.Address) *field.BigInt {
+	amount := field.NewInt(0)
+	if !n.bootstrapped() {
+		return amount
+	}
+	balance, err := n.contractClient.GetErc721BalanceAt(contract, addr, n.bootstrapBlock())
+	if err != nil {
+		return amount
+	}
+	amount.SetBytes(balance.Bytes())
+	return amount
+}
+
+func (n *blockHandle) bootstrapErc1155Holder(contract, addr common.Address, tokenId *field.BigInt) *field.BigInt {
+	quantity := field.NewInt(0)
+	if !n.bootstrapped() {
+		return quantity
+	}
+	balance, err := n.contractClient.GetNumWith1155ByContactOwnerTokenID(addr, contract, new(big.Int).SetBytes(tokenId.Bytes()), n.bootstrapBlock())
+	if err != nil {
+		return quantity
+	}
+	quantity.SetBytes(balance.Bytes())
+	return quantity
+}
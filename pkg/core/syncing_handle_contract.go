@@ -19,9 +19,9 @@ func (n *blockHandle) writeContract(ctx context.Context, data map[common.Address
 	return nil
 }
 
-func (n *blockHandle) writeProxyContract(ctx context.Context, data map[common.Address]common.Address) (err error) {
+func (n *blockHandle) writeProxies(ctx context.Context, data map[common.Address]*types.Proxy) (err error) {
 	for k, v := range data {
-		if err = fulldb.WriteProxyContract(ctx, n.db, k, v); err != nil {
+		if err = fulldb.WriteProxy(ctx, n.db, k, v); err != nil {
 			log.Errorf("write proxy contract(%s): %v ", k, err)
 			return err
 		}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// holders and the supply of the token are moved by a transfer, a mint or a burn
+
+func (n *blockHandle) writeErc20Holders(ctx context.Context, data *types.Erc20Transfer) (err error) {
+	if data.From != (common.Address{}) {
+		if err = n.writeErc20HolderAmount(ctx, data.Contract, data.From, &data.Amount, decrease); err != nil {
+			log.Errorf("decrease account(From: %v) erc20:%v", data.From.Hex(), err)
+			return err
+		}
+	} else if data.To != (common.Address{}) {
+		if err = n.updateErc20Account(ctx, data.Contract, &data.Amount, increase); err != nil {
+			log.Errorf("update erc20 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+
+	if data.To != (common.Address{}) {
+		if err = n.writeErc20HolderAmount(ctx, data.Contract, data.To, &data.Amount, increase); err != nil {
+			log.Errorf("increase account(to: %v) erc20:%v", data.To.Hex(), err)
+			return err
+		}
+	} else if data.From != (common.Address{}) {
+		if err = n.updateErc20Account(ctx, data.Contract, &data.Amount, decrease); err != nil {
+			log.Errorf("update erc20 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *blockHandle) writeErc721Holders(ctx context.Context, data *types.Erc721Transfer) (err error) {
+	if data.From != (common.Address{}) {
+		if err = n.writeErc721HolderAmount(ctx, data.Contract, data.From, &data.TokenId, decrease); err != nil {
+			log.Errorf("decrease account(From: %v) erc721 tokenId:%v", data.From.Hex(), err)
+			return err
+		}
+	} else if data.To != (common.Address{}) {
+		if err = n.updateErc721Account(ctx, data.Contract, &data.TokenId, increase); err != nil {
+			log.Errorf("update erc721 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+
+	if data.To != (common.Address{}) {
+		if err = n.writeErc721HolderAmount(ctx, data.Contract, data.To, &data.TokenId, increase); err != nil {
+			log.Errorf("increase account(to: %v) erc721 tokenId:%v", data.To.Hex(), err)
+			return err
+		}
+	} else if data.From != (common.Address{}) {
+		if err = n.updateErc721Account(ctx, data.Contract, &data.TokenId, decrease); err != nil {
+			log.Errorf("update erc721 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *blockHandle) writeErc1155Holders(ctx context.Context, data *types.Erc1155Transfer) (err error) {
+	if data.From != (common.Address{}) {
+		if err = n.writeErc1155HolderAmount(ctx, data.Contract, data.From, &data.TokenID, &data.Quantity, decrease); err != nil {
+			log.Errorf("decrease account(From: %v) erc1155 tokenId:%v", data.From.Hex(), err)
+			return err
+		}
+	} else {
+		if err = n.updateErc1155Account(ctx, data.Contract, &data.Quantity, decrease); err != nil {
+			log.Errorf("decrease erc1155 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+
+	if data.To != (common.Address{}) {
+		if err = n.writeErc1155HolderAmount(ctx, data.Contract, data.To, &data.TokenID, &data.Quantity, increase); err != nil {
+			log.Errorf("increase account(to: %v) erc1155 tokenId:%v", data.To.Hex(), err)
+			return err
+		}
+	} else {
+		if err = n.updateErc1155Account(ctx, data.Contract, &data.Quantity, increase); err != nil {
+			log.Errorf("increase erc1155 account(%s): %v", data.Contract.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
@@ -14,8 +14,8 @@ import (
 
 var homeCache *types.Home
 
-func (n *blockHandle) updateHome(ctx context.Context) (err error) {
-	var home *types.Home
+// loadHome returns the cached home, it is read from the db the first time
+func (n *blockHandle) loadHome(ctx context.Context) (home *types.Home, err error) {
 	if homeCache == nil {
 		home, err = fulldb.ReadHome(ctx, n.db)
 		if err != nil {
@@ -31,7 +31,7 @@ func (n *blockHandle) updateHome(ctx context.Context) (err error) {
 				err = nil
 				homeCache = home
 			} else {
-				return err
+				return nil, err
 			}
 		} else {
 			homeCache = home
@@ -39,14 +39,39 @@ func (n *blockHandle) updateHome(ctx context.Context) (err error) {
 	} else {
 		home = homeCache
 	}
+	return home, nil
+}
 
-	home.BlockNumber.SetBytes(n.blockData.Number.Bytes())
-
-	home.TxTotal.Add(field.NewInt(int64(len(n.transactionData))))
-	home.AddressTotal.Add(n.newAddrTotal)
+// addHomeTokens counts the token contracts seen for the first time in the block
+func (n *blockHandle) addHomeTokens(ctx context.Context) (err error) {
+	home, err := n.loadHome(ctx)
+	if err != nil {
+		return err
+	}
 	home.Erc20Total.Add(n.newErc20Total)
 	home.Erc721Total.Add(n.newErc721Total)
 	home.Erc1155Total.Add(n.newErc1155Total)
+	return fulldb.WriteHome(ctx, n.db, home)
+}
+
+func (n *blockHandle) updateHome(ctx context.Context) (err error) {
+	home, err := n.loadHome(ctx)
+	if err != nil {
+		return err
+	}
+
+	home.TxTotal.Add(field.NewInt(int64(len(n.transactionData) - len(n.storedTxs))))
+	home.AddressTotal.Add(n.newAddrTotal)
+	if err = n.updateDailyStats(ctx); err != nil {
+		log.Errorf("update daily stats: %v", err)
+		return err
+	}
+	if n.backfill {
+		// latest blocks and the syncing block are left as they are
+		return fulldb.WriteHome(ctx, n.db, home)
+	}
+
+	home.BlockNumber.SetBytes(n.blockData.Number.Bytes())
 	home.Blocks = append(home.Blocks, &types.BkSim{
 		Number:            *n.blockData.Number,
 		Timestamp:         n.blockData.TimeStamp,
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// updateDailyStats adds the block to the stats of its day. A block handled again on backfill
+// only adds the txs which were not indexed before, so the stats do not depend on the sync order.
+func (n *blockHandle) updateDailyStats(ctx context.Context) (err error) {
+	ts := n.blockData.TimeStamp.ToUint64()
+	date := time.Unix(int64(ts), 0).UTC().Format("20060102")
+	stats, err := fulldb.ReadDailyStats(ctx, n.db, date)
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			return err
+		}
+		stats = &types.DailyStats{Date: date}
+	}
+
+	added, err := fulldb.AddDailyBlock(ctx, n.db, date, n.blockData.Number.ToUint64())
+	if err != nil {
+		return err
+	}
+	if added {
+		stats.BlockCount++
+		stats.GasUsed.Add(&n.blockData.GasUsed)
+		burned := new(big.Int).Mul((*big.Int)(&n.blockData.BaseFee), (*big.Int)(&n.blockData.GasUsed))
+		stats.BaseFeeBurned.Add((*field.BigInt)(burned))
+		if stats.FirstBlockTime == 0 || ts < stats.FirstBlockTime {
+			stats.FirstBlockTime = ts
+		}
+		if ts > stats.LastBlockTime {
+			stats.LastBlockTime = ts
+		}
+	}
+
+	data := n.newTxsData()
+	stats.NewAddresses += n.newAddrTotal.ToUint64()
+	stats.TxCount += uint64(len(data.TransactionDatas))
+	for i, tx := range data.TransactionDatas {
+		rt := data.ReceiptDatas[i]
+		if rt.EffectiveGasPrice.Cmp(field.NewInt(0)) > 0 {
+			stats.GasPriceTotal.Add(&rt.EffectiveGasPrice)
+		} else {
+			stats.GasPriceTotal.Add(&tx.GasPrice)
+		}
+		if rt.ContractAddress != nil && *rt.ContractAddress != (common.Address{}) {
+			stats.NewContracts++
+		}
+
+		addrs := []common.Address{tx.From}
+		if tx.To != nil {
+			addrs = append(addrs, *tx.To)
+		}
+		for _, addr := range addrs {
+			if added, err = fulldb.AddDailyActiveAddress(ctx, n.db, date, addr); err != nil {
+				return err
+			}
+			if added {
+				stats.ActiveAddresses++
+			}
+		}
+	}
+
+	transfers := blockTransfers(n.contractClient, data)
+	stats.Erc20Transfers += uint64(len(transfers.erc20))
+	stats.Erc721Transfers += uint64(len(transfers.erc721))
+	stats.Erc1155Transfers += uint64(len(transfers.erc1155))
+
+	return fulldb.WriteDailyStats(ctx, n.db, stats)
+}
@@ -58,18 +58,6 @@ func (n *blockHandle) writeErc20Transfer(ctx context.Context, data *types.Erc20T
 			log.Errorf("write account(From: %v) erc20 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-
-		if err = n.writeErc20HolderAmount(ctx, data.Contract, data.From, &data.Amount, decrease); err != nil {
-			log.Errorf("decrease account(From: %v) erc20:%v", data.From.Hex(), err)
-			return err
-		}
-	} else {
-		if data.To != (common.Address{}) {
-			if err = n.updateErc20Account(ctx, data.Contract, &data.Amount, increase); err != nil {
-				log.Errorf("update erc20 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if data.To != (common.Address{}) {
@@ -77,21 +65,10 @@ func (n *blockHandle) writeErc20Transfer(ctx context.Context, data *types.Erc20T
 			log.Errorf("write account(to: %v) erc20 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		if err = n.writeErc20HolderAmount(ctx, data.Contract, data.To, &data.Amount, increase); err != nil {
-			log.Errorf("increase account(to: %v) erc20:%v", data.From.Hex(), err)
-			return err
-		}
-	} else {
-		if data.From != (common.Address{}) {
-			if err = n.updateErc20Account(ctx, data.Contract, &data.Amount, decrease); err != nil {
-				log.Errorf("update erc20 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if err = n.writeErc20ContractTransferIndex(ctx, data.Contract, erc20TrasferTotal); err != nil {
-		log.Errorf("write erc20 contract transfer index:%v", data.Contract.Hex(), err)
+		log.Errorf("write erc20 contract(%s) transfer index: %v", data.Contract.Hex(), err)
 		return err
 	}
 
@@ -165,6 +142,7 @@ func (n *blockHandle) updateErc20Account(ctx context.Context, addr common.Addres
 	if !acc.Erc20 {
 		acc.Erc20 = true
 		n.newErc20Total.Add(field.NewInt(1))
+		n.bootstrapTotalSupply(addr, acc)
 	}
 
 	if acc.Retry.Cmp(field.NewInt(6)) < 0 {
@@ -208,7 +186,7 @@ func (n *blockHandle) writeErc20HolderAmount(ctx context.Context, contract commo
 	oriAmount, err = fulldb.ReadErc20HolderAmount(ctx, n.db, contract, addr)
 	if err != nil {
 		if errors.Is(err, kv.NotFound) {
-			oriAmount = &field.BigInt{}
+			oriAmount = n.bootstrapErc20Holder(contract, addr)
 			err = nil
 		} else {
 			return err
@@ -227,7 +205,10 @@ func (n *blockHandle) writeErc20HolderAmount(ctx context.Context, contract commo
 			oriAmount = field.NewInt(0)
 		}
 	}
-	return fulldb.WriteErc20HolderAmount(ctx, n.db, contract, &types.Holder{Addr: addr, Quantity: *oriAmount})
+	if err = fulldb.WriteErc20HolderAmount(ctx, n.db, contract, &types.Holder{Addr: addr, Quantity: *oriAmount}); err != nil {
+		return err
+	}
+	return fulldb.WriteErc20BalanceChange(ctx, n.db, contract, addr, n.balanceChange(oriAmount))
 }
 
 // ------------------- erc721 transfer -----------------
@@ -255,17 +236,6 @@ func (n *blockHandle) writeErc721Transfer(ctx context.Context, data *types.Erc72
 			log.Errorf("write account(From: %v) erc721 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-		if err = n.writeErc721HolderAmount(ctx, data.Contract, data.From, &data.TokenId, decrease); err != nil {
-			log.Errorf("decrease account(From: %v) erc721 tokenId:%v", data.From.Hex(), err)
-			return err
-		}
-	} else {
-		if data.To != (common.Address{}) {
-			if err = n.updateErc721Account(ctx, data.Contract, &data.TokenId, increase); err != nil {
-				log.Errorf("update erc721 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if data.To != (common.Address{}) {
@@ -273,21 +243,15 @@ func (n *blockHandle) writeErc721Transfer(ctx context.Context, data *types.Erc72
 			log.Errorf("write account(to: %v) erc721 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		if err = n.writeErc721HolderAmount(ctx, data.Contract, data.To, &data.TokenId, increase); err != nil {
-			log.Errorf("increase account(to: %v) erc721 tokenId:%v", data.To.Hex(), err)
-			return err
-		}
-	} else {
-		if data.From != (common.Address{}) {
-			if err = n.updateErc721Account(ctx, data.Contract, &data.TokenId, decrease); err != nil {
-				log.Errorf("update erc721 account(%s): %v", data.Contract.Hex(), err)
-				return err
-			}
-		}
 	}
 
 	if err = n.writeErc721ContractTransferIndex(ctx, data.Contract, erc721TrasferTotal); err != nil {
-		log.Errorf("write erc721 contract transfer index:%v", data.Contract.Hex(), err)
+		log.Errorf("write erc721 contract(%s) transfer index: %v", data.Contract.Hex(), err)
+		return err
+	}
+
+	if err = fulldb.WriteErc721TokenTransfer(ctx, n.db, data.Contract, &data.TokenId, erc721TrasferTotal); err != nil {
+		log.Errorf("write erc721 token(%s) transfer index: %v", data.TokenId.String(), err)
 		return err
 	}
 
@@ -361,6 +325,7 @@ func (n *blockHandle) updateErc721Account(ctx context.Context, addr common.Addre
 	if !acc.Erc721 {
 		acc.Erc721 = true
 		n.newErc721Total.Add(field.NewInt(1))
+		n.bootstrapTotalSupply(addr, acc)
 	}
 
 	if acc.Retry.Cmp(field.NewInt(6)) < 0 {
@@ -400,7 +365,7 @@ func (n *blockHandle) writeErc721HolderAmount(ctx context.Context, contract comm
 		if !errors.Is(err, kv.NotFound) {
 			return err
 		}
-		oriAmount = field.NewInt(0)
+		oriAmount = n.bootstrapErc721Holder(contract, addr)
 	} else {
 		err = fulldb.DelErc721HolderAmount(ctx, n.db, contract, &types.Holder{Addr: addr, Quantity: *oriAmount})
 		if err != nil {
@@ -451,15 +416,6 @@ func (n *blockHandle) writeErc1155Transfer(ctx context.Context, data *types.Erc1
 			log.Errorf("write account(From: %v) erc1155 transfer index:%v", data.From.Hex(), err)
 			return err
 		}
-		if err = n.writeErc1155HolderAmount(ctx, data.Contract, data.From, &data.TokenID, &data.Quantity, decrease); err != nil {
-			log.Errorf("decrease account(From: %v) erc1155 tokenId:%v", data.From.Hex(), err)
-			return err
-		}
-	} else {
-		if err = n.updateErc1155Account(ctx, data.Contract, &data.Quantity, decrease); err != nil {
-			log.Errorf("decrease erc1155 account(%s): %v", data.Contract.Hex(), err)
-			return err
-		}
 	}
 
 	if data.To != (common.Address{}) {
@@ -467,19 +423,15 @@ func (n *blockHandle) writeErc1155Transfer(ctx context.Context, data *types.Erc1
 			log.Errorf("write account(to: %v) erc1155 transfer index:%v", data.To.Hex(), err)
 			return err
 		}
-		if err = n.writeErc1155HolderAmount(ctx, data.Contract, data.To, &data.TokenID, &data.Quantity, increase); err != nil {
-			log.Errorf("increase account(to: %v) erc1155 tokenId:%v", data.From.Hex(), err)
-			return err
-		}
-	} else {
-		if err = n.updateErc1155Account(ctx, data.Contract, &data.Quantity, increase); err != nil {
-			log.Errorf("increase erc1155 account(%s): %v", data.Contract.Hex(), err)
-			return err
-		}
 	}
 
 	if err = n.writeErc1155ContractTransferIndex(ctx, data.Contract, erc1155TrasferTotal); err != nil {
-		log.Errorf("write erc1155 contract transfer index:%v", data.Contract.Hex(), err)
+		log.Errorf("write erc1155 contract(%s) transfer index: %v", data.Contract.Hex(), err)
+		return err
+	}
+
+	if err = fulldb.WriteErc1155TokenTransfer(ctx, n.db, data.Contract, &data.TokenID, erc1155TrasferTotal); err != nil {
+		log.Errorf("write erc1155 token(%s) transfer index: %v", data.TokenID.String(), err)
 		return err
 	}
 
@@ -606,7 +558,7 @@ func (n *blockHandle) writeErc1155HolderAmount(ctx context.Context, contract com
 		if !errors.Is(err, kv.NotFound) {
 			return err
 		}
-		oriQuantity = field.NewInt(0)
+		oriQuantity = n.bootstrapErc1155Holder(contract, addr, tokenId)
 	}
 	if inde == increase {
 		oriQuantity.Add(quantity)
@@ -626,6 +578,9 @@ func (n *blockHandle) writeErc1155HolderAmount(ctx context.Context, contract com
 		}
 		oriAmount.Sub(quantity)
 	}
+	if err = fulldb.WriteErc1155BalanceChange(ctx, n.db, contract, tokenId, addr, n.balanceChange(oriQuantity)); err != nil {
+		return err
+	}
 	if oriAmount.Cmp(field.NewInt(0)) < 0 {
 		oriAmount = field.NewInt(0)
 	}
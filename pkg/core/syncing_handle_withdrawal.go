@@ -0,0 +1,28 @@
+package core
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// writeWithdrawals indexes the withdrawals of the block by recipient. The recipients balances
+// are fetched with the block, so they are updated even when the block has no txs.
+func (n *blockHandle) writeWithdrawals(ctx context.Context) error {
+	for _, w := range n.blockData.Withdrawals {
+		record := &types.AccountWithdrawal{
+			Index:       uint64(w.Index),
+			BlockNumber: n.blockData.Number.ToUint64(),
+			TimeStamp:   n.blockData.TimeStamp.ToUint64(),
+			Validator:   uint64(w.Validator),
+			Amount:      uint64(w.Amount),
+		}
+		if err := fulldb.WriteAccountWithdrawal(ctx, n.db, w.Address, record); err != nil {
+			log.Errorf("write withdrawal %d of %s: %v", record.Index, w.Address.Hex(), err)
+			return err
+		}
+	}
+	return nil
+}
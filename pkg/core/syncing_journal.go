@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// journalDB records the previous state of every key written through it,
+// so that the writes of a block can be undone when the block is reorged out.
+type journalDB struct {
+	kv.Database
+	journal *types.Journal
+	seen    map[string]struct{}
+}
+
+func newJournalDB(db kv.Database) *journalDB {
+	return &journalDB{
+		Database: db,
+		journal:  &types.Journal{Entries: make([]*types.JournalEntry, 0)},
+		seen:     make(map[string]struct{}),
+	}
+}
+
+func (j *journalDB) Put(ctx context.Context, key, val []byte, opts *kv.WriteOption) error {
+	if err := j.record(ctx, key, opts.Table); err != nil {
+		return err
+	}
+	return j.Database.Put(ctx, key, val, opts)
+}
+
+func (j *journalDB) Del(ctx context.Context, key []byte, opts *kv.WriteOption) error {
+	if err := j.record(ctx, key, opts.Table); err != nil {
+		return err
+	}
+	return j.Database.Del(ctx, key, opts)
+}
+
+func (j *journalDB) SPut(ctx context.Context, key, val []byte, opts *kv.WriteOption) error {
+	has, err := j.Database.SHas(ctx, key, val, &kv.ReadOption{Table: opts.Table})
+	if err != nil {
+		return err
+	}
+	if !has {
+		j.journal.Entries = append(j.journal.Entries, &types.JournalEntry{
+			Op:    types.JournalSDel,
+			Table: opts.Table,
+			Key:   copyBytes(key),
+			Val:   copyBytes(val),
+		})
+	}
+	return j.Database.SPut(ctx, key, val, opts)
+}
+
+func (j *journalDB) SDel(ctx context.Context, key, val []byte, opts *kv.WriteOption) error {
+	has, err := j.Database.SHas(ctx, key, val, &kv.ReadOption{Table: opts.Table})
+	if err != nil {
+		return err
+	}
+	if has {
+		j.journal.Entries = append(j.journal.Entries, &types.JournalEntry{
+			Op:    types.JournalSPut,
+			Table: opts.Table,
+			Key:   copyBytes(key),
+			Val:   copyBytes(val),
+		})
+	}
+	return j.Database.SDel(ctx, key, val, opts)
+}
+
+// record keeps the value a key had before its first write in this block
+func (j *journalDB) record(ctx context.Context, key []byte, table string) error {
+	id := table + ":" + string(key)
+	if _, ok := j.seen[id]; ok {
+		return nil
+	}
+
+	bytesRes, err := j.Database.Get(ctx, key, &kv.ReadOption{Table: table})
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			return err
+		}
+		j.journal.Entries = append(j.journal.Entries, &types.JournalEntry{
+			Op:    types.JournalDel,
+			Table: table,
+			Key:   copyBytes(key),
+		})
+	} else {
+		j.journal.Entries = append(j.journal.Entries, &types.JournalEntry{
+			Op:    types.JournalPut,
+			Table: table,
+			Key:   copyBytes(key),
+			Val:   copyBytes(bytesRes),
+		})
+	}
+	j.seen[id] = struct{}{}
+	return nil
+}
+
+// revertJournal replays a block journal backwards
+func revertJournal(ctx context.Context, db kv.Database, journal *types.Journal) (err error) {
+	for i := len(journal.Entries) - 1; i >= 0; i-- {
+		entry := journal.Entries[i]
+		opts := &kv.WriteOption{Table: entry.Table}
+		switch entry.Op {
+		case types.JournalPut:
+			err = db.Put(ctx, entry.Key, entry.Val, opts)
+		case types.JournalDel:
+			err = db.Del(ctx, entry.Key, opts)
+		case types.JournalSPut:
+			err = db.SPut(ctx, entry.Key, entry.Val, opts)
+		case types.JournalSDel:
+			err = db.SDel(ctx, entry.Key, entry.Val, opts)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/mempool"
+)
+
+// SetMempool makes the txs of the committed blocks be evicted from the pending pool
+func (n *Sync) SetMempool(p *mempool.Pool) {
+	n.mempool = p
+}
+
+func (n *Sync) evictPending(jobs *Jobs) {
+	if n.mempool == nil {
+		return
+	}
+	for _, data := range []*job.SyncJob{jobs.Main, jobs.Fork} {
+		if data == nil {
+			continue
+		}
+		hashes := make([]common.Hash, 0, len(data.TransactionDatas))
+		for _, v := range data.TransactionDatas {
+			hashes = append(hashes, v.Hash)
+		}
+		n.mempool.Remove(hashes...)
+	}
+}
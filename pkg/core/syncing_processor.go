@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+)
+
+// Processor indexes a block into the db of uscan, in the same tx the block is stored in.
+// Blocks, txs, receipts and accounts have been written already when a processor runs.
+//
+// Everything written through db is journaled, so it is rolled back by a reorg without
+// any work of the processor. Blocks inside the fork window are written into the fork db,
+// a processor records there what has to be removed once the block is promoted to the
+// full db, where HandleMain is called for it again.
+type Processor interface {
+	// Name identifies the processor in logs
+	Name() string
+	// HandleMain indexes a block of the full db. On backfill data only holds the txs
+	// which have not been indexed before.
+	HandleMain(ctx context.Context, db kv.Database, data *job.SyncJob) error
+	// HandleFork indexes a block of the fork window into the fork db
+	HandleFork(ctx context.Context, db kv.Database, data *job.SyncJob, record *ForkRecorder) error
+}
+
+var processors []Processor
+
+// RegisterProcessor adds p to the processors run on every block after the built-in ones,
+// it must be called before the sync is created, in an init function for example.
+func RegisterProcessor(p Processor) {
+	processors = append(processors, p)
+}
+
+// ForkRecorder collects what a fork block leaves in the fork db, it is undone once the
+// block is promoted to the full db
+type ForkRecorder struct {
+	deleteMap map[string][][]byte      // table => key
+	indexMap  map[string]*field.BigInt // key => index
+	totalMap  map[string]*field.BigInt // table:key => total
+}
+
+func newForkRecorder() *ForkRecorder {
+	return &ForkRecorder{
+		deleteMap: make(map[string][][]byte),
+		indexMap:  make(map[string]*field.BigInt),
+		totalMap:  make(map[string]*field.BigInt),
+	}
+}
+
+// Delete removes key from table
+func (r *ForkRecorder) Delete(table string, key []byte) {
+	r.deleteMap[table] = append(r.deleteMap[table], key)
+}
+
+// Index adds count to the fork index stored under key. A fork block numbers what it appends
+// from its total plus the index read with ReadForkIndex, so the numbers of the promoted and
+// removed fork blocks are not used again.
+func (r *ForkRecorder) Index(key []byte, count *field.BigInt) {
+	if r.indexMap[string(key)] == nil {
+		r.indexMap[string(key)] = field.NewInt(0)
+	}
+	r.indexMap[string(key)].Add(count)
+}
+
+// Total subtracts count from the total stored under key in table
+func (r *ForkRecorder) Total(table string, key []byte, count *field.BigInt) {
+	k := table + ":" + string(key)
+	if r.totalMap[k] == nil {
+		r.totalMap[k] = field.NewInt(0)
+	}
+	r.totalMap[k].Add(count)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// approvalProcessor keeps the latest erc20 allowance and erc721/erc1155 ApprovalForAll of
+// every owner and spender of a token
+type approvalProcessor struct{}
+
+func (p *approvalProcessor) Name() string {
+	return "approval"
+}
+
+func (p *approvalProcessor) HandleMain(ctx context.Context, db kv.Database, data *job.SyncJob) (err error) {
+	number := data.BlockData.Number.ToUint64()
+	for i, tx := range data.TransactionDatas {
+		for _, rtLog := range data.ReceiptDatas[i].Logs {
+			approval := decodeApproval(rtLog)
+			if approval == nil {
+				continue
+			}
+			approval.BlockNumber = number
+			approval.TxHash = tx.Hash
+			approval.TimeStamp = data.BlockData.TimeStamp.ToUint64()
+			if err = fulldb.WriteApproval(ctx, db, approval); err != nil {
+				log.Errorf("write approval of log %d of block %d: %v", approval.LogIndex, number, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HandleFork leaves the approvals of the fork window out, they are indexed once the block is confirmed.
+// The approvals api returns the block they are indexed up to as indexedBlock.
+func (p *approvalProcessor) HandleFork(ctx context.Context, db kv.Database, data *job.SyncJob, record *ForkRecorder) error {
+	return nil
+}
+
+// decodeApproval decodes an erc20 Approval or an ApprovalForAll, nil for any other log.
+// The Approval of an erc721 token id has the id as a fourth topic and is skipped.
+// The amount is what was approved, transferFrom spends the allowance without an event,
+// so the allowance left is read from the token when the approvals are listed.
+func decodeApproval(l *types.Log) *types.Approval {
+	if len(l.Topics) != 3 || len(l.Data) != common.HashLength {
+		return nil
+	}
+	approval := &types.Approval{
+		Token:    l.Address,
+		Owner:    common.BytesToAddress(l.Topics[1].Bytes()),
+		Spender:  common.BytesToAddress(l.Topics[2].Bytes()),
+		LogIndex: l.LogIndex.ToUint64(),
+	}
+	value := new(big.Int).SetBytes(l.Data)
+	switch l.Topics[0] {
+	case contract.ApprovalEventTopic:
+		approval.Amount = field.BigInt(*value)
+	case contract.ApprovalForAllEventTopic:
+		approval.ForAll = true
+		approval.Approved = value.Sign() != 0
+	default:
+		return nil
+	}
+	return approval
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// logProcessor indexes the event logs of a block by emitting address and by topic
+type logProcessor struct{}
+
+func (p *logProcessor) Name() string {
+	return "log"
+}
+
+func (p *logProcessor) HandleMain(ctx context.Context, db kv.Database, data *job.SyncJob) (err error) {
+	number := data.BlockData.Number.ToUint64()
+	for i, tx := range data.TransactionDatas {
+		for _, rtLog := range data.ReceiptDatas[i].Logs {
+			entry := &types.LogEntry{
+				BlockNumber: number,
+				LogIndex:    rtLog.LogIndex.ToUint64(),
+				TxHash:      tx.Hash,
+			}
+			if err = fulldb.WriteLog(ctx, db, rtLog, entry); err != nil {
+				log.Errorf("write log %d of block %d: %v", entry.LogIndex, number, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HandleFork leaves the logs of the fork window out, they are indexed once the block is confirmed.
+// The log apis return the block the logs are indexed up to as indexedBlock.
+func (p *logProcessor) HandleFork(ctx context.Context, db kv.Database, data *job.SyncJob, record *ForkRecorder) error {
+	return nil
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// tokenTransfers are the erc20, erc721 and erc1155 transfers of a block in log order
+type tokenTransfers struct {
+	erc20   []*types.Erc20Transfer
+	erc721  []*types.Erc721Transfer
+	erc1155 []*types.Erc1155Transfer
+}
+
+// decodedTransfers keeps the transfers of the jobs being stored, so that a block is decoded
+// once for the processors, the daily stats and the stream. It is only used by the goroutine
+// which stores the blocks and is reset before the next step.
+var decodedTransfers = make(map[*job.SyncJob]*tokenTransfers)
+
+// blockTransfers returns the transfers of data, decoding them on the first call
+func blockTransfers(client contract.Contractor, data *job.SyncJob) *tokenTransfers {
+	if res, ok := decodedTransfers[data]; ok {
+		return res
+	}
+	res := decodeTransfers(client, data)
+	decodedTransfers[data] = res
+	return res
+}
+
+// resetTransfers drops the transfers decoded for the last step
+func resetTransfers() {
+	decodedTransfers = make(map[*job.SyncJob]*tokenTransfers)
+}
+
+// decodeTransfers decodes the transfer events of the txs of data, logs which do not decode are skipped
+func decodeTransfers(client contract.Contractor, data *job.SyncJob) *tokenTransfers {
+	res := &tokenTransfers{}
+	for i, v := range data.TransactionDatas {
+		for _, rtLog := range data.ReceiptDatas[i].Logs {
+			if len(rtLog.Topics) < 3 {
+				continue
+			}
+			switch rtLog.Topics[0] {
+			case contract.TransferEventTopic:
+				if len(rtLog.Data) > 0 {
+					erc20Transfer, err := client.Erc20Transfer(rtLog.Address.Hex(), rtLog.ToEthLog())
+					if err != nil {
+						continue
+					}
+					res.erc20 = append(res.erc20, &types.Erc20Transfer{
+						TransactionHash: v.Hash,
+						BlockNumber:     v.BlockNum,
+						Contract:        rtLog.Address,
+						Method:          v.Method,
+						From:            erc20Transfer.From,
+						To:              erc20Transfer.To,
+						Amount:          (field.BigInt)(*erc20Transfer.Value),
+						TimeStamp:       data.BlockData.TimeStamp,
+					})
+				} else {
+					erc721Transfer, err := client.Erc721Transfer(rtLog.Address.Hex(), rtLog.ToEthLog())
+					if err != nil {
+						continue
+					}
+					res.erc721 = append(res.erc721, &types.Erc721Transfer{
+						TransactionHash: v.Hash,
+						BlockNumber:     v.BlockNum,
+						Contract:        rtLog.Address,
+						Method:          v.Method,
+						From:            erc721Transfer.From,
+						To:              erc721Transfer.To,
+						TokenId:         (field.BigInt)(*erc721Transfer.TokenId),
+						TimeStamp:       data.BlockData.TimeStamp,
+					})
+				}
+			case contract.TransferSingleEventTopic:
+				erc1155TransferSignle, err := client.Erc1155TransferSingle(rtLog.Address.Hex(), rtLog.ToEthLog())
+				if err != nil {
+					continue
+				}
+				res.erc1155 = append(res.erc1155, &types.Erc1155Transfer{
+					TransactionHash: v.Hash,
+					BlockNumber:     v.BlockNum,
+					Contract:        rtLog.Address,
+					Method:          v.Method,
+					From:            erc1155TransferSignle.From,
+					To:              erc1155TransferSignle.To,
+					TokenID:         (field.BigInt)(*erc1155TransferSignle.Id),
+					Quantity:        (field.BigInt)(*erc1155TransferSignle.Value),
+					TimeStamp:       data.BlockData.TimeStamp,
+				})
+			case contract.TransferBatchEventTopic:
+				erc1155TransferBatch, err := client.Erc1155TransferBatch(rtLog.Address.Hex(), rtLog.ToEthLog())
+				if err != nil {
+					continue
+				}
+				for i := range erc1155TransferBatch.Ids {
+					res.erc1155 = append(res.erc1155, &types.Erc1155Transfer{
+						TransactionHash: v.Hash,
+						BlockNumber:     v.BlockNum,
+						Contract:        rtLog.Address,
+						Method:          v.Method,
+						From:            erc1155TransferBatch.From,
+						To:              erc1155TransferBatch.To,
+						TokenID:         (field.BigInt)(*erc1155TransferBatch.Ids[i]),
+						Quantity:        (field.BigInt)(*erc1155TransferBatch.Values[i]),
+						TimeStamp:       data.BlockData.TimeStamp,
+					})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// transferProcessor indexes the token transfers of a block by token and by account
+type transferProcessor struct {
+	sync *Sync
+}
+
+func (p *transferProcessor) Name() string {
+	return "transfer"
+}
+
+func (p *transferProcessor) HandleMain(ctx context.Context, db kv.Database, data *job.SyncJob) (err error) {
+	n := newBlockHandle(data, p.sync.contractClient, db, p.sync.startBlock, nil)
+	transfers := blockTransfers(n.contractClient, data)
+	for _, v := range transfers.erc20 {
+		if err = n.writeErc20Transfer(ctx, v); err != nil {
+			log.Errorf("write erc20Transfer: %v", err)
+			return err
+		}
+	}
+	for _, v := range transfers.erc721 {
+		if err = n.writeErc721Transfer(ctx, v); err != nil {
+			log.Errorf("write erc721Transfer: %v", err)
+			return err
+		}
+	}
+	for _, v := range transfers.erc1155 {
+		if err = n.writeErc1155Transfer(ctx, v); err != nil {
+			log.Errorf("write erc1155Transfer: %v", err)
+			return err
+		}
+	}
+
+	if err = n.updateErc20TrasferTotal(ctx); err != nil {
+		log.Errorf("update erc20 transfer total: %v", err)
+		return err
+	}
+	if err = n.updateErc721TrasferTotal(ctx); err != nil {
+		log.Errorf("update erc721 transfer total: %v", err)
+		return err
+	}
+	if err = n.updateErc1155TrasferTotal(ctx); err != nil {
+		log.Errorf("update erc1155 transfer total: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (p *transferProcessor) HandleFork(ctx context.Context, db kv.Database, data *job.SyncJob, record *ForkRecorder) (err error) {
+	n := newBlockHandle(data, p.sync.contractClient, db, p.sync.startBlock, nil)
+	transfers := blockTransfers(n.contractClient, data)
+	for _, v := range transfers.erc20 {
+		if err = n.writeForkErc20Transfer(ctx, v, record); err != nil {
+			log.Errorf("write fork erc20Transfer: %v", err)
+			return err
+		}
+	}
+	for _, v := range transfers.erc721 {
+		if err = n.writeForkErc721Transfer(ctx, v, record); err != nil {
+			log.Errorf("write fork erc721Transfer: %v", err)
+			return err
+		}
+	}
+	for _, v := range transfers.erc1155 {
+		if err = n.writeForkErc1155Transfer(ctx, v, record); err != nil {
+			log.Errorf("write fork erc1155Transfer: %v", err)
+			return err
+		}
+	}
+
+	if err = n.updateForkErc20TrasferTotal(ctx, record); err != nil {
+		log.Errorf("update fork erc20 transfer total: %v", err)
+		return err
+	}
+	if err = n.updateForkErc721TrasferTotal(ctx, record); err != nil {
+		log.Errorf("update fork erc721 transfer total: %v", err)
+		return err
+	}
+	if err = n.updateForkErc1155TrasferTotal(ctx, record); err != nil {
+		log.Errorf("update fork erc1155 transfer total: %v", err)
+		return err
+	}
+	return nil
+}
+
+// holderProcessor keeps the token holders and the supply and count of the tokens
+type holderProcessor struct {
+	sync *Sync
+}
+
+func (p *holderProcessor) Name() string {
+	return "holder"
+}
+
+func (p *holderProcessor) HandleMain(ctx context.Context, db kv.Database, data *job.SyncJob) (err error) {
+	n := newBlockHandle(data, p.sync.contractClient, db, p.sync.startBlock, nil)
+	transfers := blockTransfers(n.contractClient, data)
+	for _, v := range transfers.erc20 {
+		if err = n.writeErc20Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range transfers.erc721 {
+		if err = n.writeErc721Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range transfers.erc1155 {
+		if err = n.writeErc1155Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	// token accounts are written with the other accounts of the block
+	return n.addHomeTokens(ctx)
+}
+
+func (p *holderProcessor) HandleFork(ctx context.Context, db kv.Database, data *job.SyncJob, record *ForkRecorder) (err error) {
+	n := newBlockHandle(data, p.sync.contractClient, db, p.sync.startBlock, nil)
+	transfers := blockTransfers(n.contractClient, data)
+	for _, v := range transfers.erc20 {
+		if err = n.writeForkErc20Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range transfers.erc721 {
+		if err = n.writeForkErc721Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range transfers.erc1155 {
+		if err = n.writeForkErc1155Holders(ctx, v); err != nil {
+			return err
+		}
+	}
+	return n.addForkHomeTokens(ctx)
+}
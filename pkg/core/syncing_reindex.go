@@ -0,0 +1,206 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+)
+
+// NftIndex names the index of the erc721 and erc1155 transfers by token id on reindex,
+// it is built from the stored transfers instead of a processor
+const NftIndex = "nft"
+
+// transfers indexed by token id in a db transaction on reindex
+const reindexBatch = 1000
+
+// processors which count what they index, running them again over a block indexes it twice
+var countingProcessors = map[string]struct{}{
+	"transfer": {},
+	"holder":   {},
+}
+
+// Reindex runs the named processors again over the synced blocks from `from` to `to`, so the
+// blocks stored before a processor was added get indexed by it. The blocks are fetched from
+// the node again and nothing but the processors writes to the db, so they have to be able to
+// handle a block twice. The node must not be running against the same db meanwhile.
+func (n *Sync) Reindex(ctx context.Context, from, to uint64, names []string) (err error) {
+	if err = n.checkSyncedRange(from, to); err != nil {
+		return err
+	}
+
+	var (
+		selected []Processor
+		nft      bool
+	)
+	for _, name := range names {
+		if name == NftIndex {
+			nft = true
+			continue
+		}
+		if _, ok := countingProcessors[name]; ok {
+			return fmt.Errorf("processor %s can not run again over indexed blocks", name)
+		}
+		p := n.processor(name)
+		if p == nil {
+			return fmt.Errorf("unknown processor: %s", name)
+		}
+		selected = append(selected, p)
+	}
+
+	if nft {
+		if err = n.reindexNftTransfers(ctx, from, to); err != nil {
+			return err
+		}
+	}
+	if len(selected) > 0 {
+		err = n.fetchBlocks(ctx, from, to, func(j *job.SyncJob) error {
+			return n.handleReindex(j, selected)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("reindex done: %d - %d", from, to)
+	return nil
+}
+
+func (n *Sync) processor(name string) Processor {
+	for _, p := range n.processors {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (n *Sync) handleReindex(j *job.SyncJob, selected []Processor) (err error) {
+	ctx, err := n.db.BeginTx(context.Background())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			n.db.Commit(ctx)
+		} else {
+			n.db.RollBack(ctx)
+		}
+	}()
+
+	log.Infof("reindex block: %s", j.BlockData.Number.String())
+	for _, p := range selected {
+		if err = p.HandleMain(ctx, n.db, j); err != nil {
+			log.Errorf("processor %s: %v, block: %s", p.Name(), err, j.BlockData.Number.String())
+			return err
+		}
+	}
+	return nil
+}
+
+// storedTransfer reads the block of a stored transfer and returns how to index it by token id
+type storedTransfer func(ctx context.Context, index *field.BigInt) (number uint64, write func(ctx context.Context) error, err error)
+
+// reindexNftTransfers indexes the stored erc721 and erc1155 transfers of the blocks by token id
+func (n *Sync) reindexNftTransfers(ctx context.Context, from, to uint64) error {
+	erc721 := func(ctx context.Context, index *field.BigInt) (uint64, func(context.Context) error, error) {
+		data, err := fulldb.ReadErc721Transfer(ctx, n.db, index)
+		if err != nil {
+			return 0, nil, err
+		}
+		return data.BlockNumber.ToUint64(), func(ctx context.Context) error {
+			return fulldb.WriteErc721TokenTransfer(ctx, n.db, data.Contract, &data.TokenId, index)
+		}, nil
+	}
+	erc1155 := func(ctx context.Context, index *field.BigInt) (uint64, func(context.Context) error, error) {
+		data, err := fulldb.ReadErc1155Transfer(ctx, n.db, index)
+		if err != nil {
+			return 0, nil, err
+		}
+		return data.BlockNumber.ToUint64(), func(ctx context.Context) error {
+			return fulldb.WriteErc1155TokenTransfer(ctx, n.db, data.Contract, &data.TokenID, index)
+		}, nil
+	}
+
+	if err := n.reindexTransfers(ctx, "erc721", from, to, fulldb.ReadErc721Total, erc721); err != nil {
+		return err
+	}
+	return n.reindexTransfers(ctx, "erc1155", from, to, fulldb.ReadErc1155Total, erc1155)
+}
+
+// reindexTransfers indexes the transfers of the blocks by token id, reindexBatch of them per db transaction.
+// The transfers are numbered block by block, so the first one of the blocks is found by a binary search.
+// Transfers stored by a backfill follow the later blocks, they have been indexed by token id by it.
+func (n *Sync) reindexTransfers(ctx context.Context, name string, from, to uint64, readTotal func(context.Context, kv.Reader) (*field.BigInt, error), read storedTransfer) error {
+	total, err := readTotal(context.Background(), n.db)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var searchErr error
+	first := sort.Search(int(total.ToUint64()), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		number, _, err := read(context.Background(), field.NewInt(int64(i+1)))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return number >= from
+	})
+	if searchErr != nil {
+		return searchErr
+	}
+
+	next, indexed := uint64(first+1), 0
+	for done := false; !done && next <= total.ToUint64(); {
+		if ctx.Err() != nil {
+			log.Infof("reindex %s transfers stopped at: %d", name, next)
+			return nil
+		}
+		if next, done, err = n.reindexTransferBatch(next, to, total.ToUint64(), read); err != nil {
+			return err
+		}
+		indexed = int(next) - first - 1
+	}
+	log.Infof("%s transfers indexed: %d, blocks: %d - %d", name, indexed, from, to)
+	return nil
+}
+
+// reindexTransferBatch indexes the transfers from index next on, it is done at the first transfer after block to
+func (n *Sync) reindexTransferBatch(next, to, total uint64, read storedTransfer) (_ uint64, done bool, err error) {
+	ctx, err := n.db.BeginTx(context.Background())
+	if err != nil {
+		return next, false, err
+	}
+	defer func() {
+		if err == nil {
+			n.db.Commit(ctx)
+		} else {
+			n.db.RollBack(ctx)
+		}
+	}()
+
+	for end := next + reindexBatch; next < end && next <= total; next++ {
+		number, write, err := read(ctx, field.NewInt(int64(next)))
+		if err != nil {
+			return next, false, err
+		}
+		if number > to {
+			return next, true, nil
+		}
+		if err = write(ctx); err != nil {
+			return next, false, err
+		}
+	}
+	return next, false, nil
+}
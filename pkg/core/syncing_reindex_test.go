@@ -0,0 +1,44 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/storage"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+func TestReindexNftTransfers(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		store    = storage.NewStorage(t.TempDir())
+		contract = common.HexToAddress("0x721")
+		n        = &Sync{db: store.FullDB}
+	)
+	// token id i is transferred by transfer i
+	blocks := []int64{1, 2, 2, 3, 4}
+	for i, number := range blocks {
+		index := field.NewInt(int64(i + 1))
+		assert.NoError(t, fulldb.WriteErc721Transfer(ctx, store.FullDB, index, &types.Erc721Transfer{
+			BlockNumber: *field.NewInt(number),
+			Contract:    contract,
+			TokenId:     *index,
+		}))
+	}
+	assert.NoError(t, fulldb.WriteErc721Total(ctx, store.FullDB, field.NewInt(int64(len(blocks)))))
+
+	assert.NoError(t, n.reindexNftTransfers(ctx, 2, 3))
+	for i := range blocks {
+		count, err := fulldb.GetErc721TokenTransferCount(ctx, store.FullDB, contract, field.NewInt(int64(i+1)))
+		assert.NoError(t, err)
+		if i >= 1 && i <= 3 {
+			assert.Equal(t, uint64(1), count, "token %d", i+1)
+		} else {
+			assert.Equal(t, uint64(0), count, "token %d", i+1)
+		}
+	}
+}
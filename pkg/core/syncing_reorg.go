@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/forkdb"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// ErrReorgTooDeep is returned when no common ancestor is found within the reorg depth,
+// the journals of older blocks are gone so the db has to be resynced
+var ErrReorgTooDeep = errors.New("reorg deeper than the reorg depth")
+
+// jobsHead returns the highest block of a step, the step is journaled under its number
+func jobsHead(jobs *Jobs) *types.Block {
+	if jobs.Fork != nil {
+		return jobs.Fork.BlockData
+	}
+	return jobs.Main.BlockData
+}
+
+// readStoredBlock looks for the block in the fork db first, then in the full db
+func (n *Sync) readStoredBlock(ctx context.Context, number *field.BigInt) (*types.Block, error) {
+	bk, err := forkdb.ReadBlock(ctx, n.forkDb, number)
+	if err == nil || !errors.Is(err, kv.NotFound) {
+		return bk, err
+	}
+	return fulldb.ReadBlock(ctx, n.db, number)
+}
+
+// checkReorg reports whether the blocks of the step do not link to what has been stored
+func (n *Sync) checkReorg(ctx context.Context, jobs *Jobs) (bool, error) {
+	head := jobsHead(jobs)
+	if head.Number.ToUint64() == 0 {
+		return false, nil
+	}
+
+	parentNum := field.NewInt(int64(head.Number.ToUint64() - 1))
+	parent, err := n.readStoredBlock(ctx, parentNum)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if parent.Hash != head.ParentHash {
+		log.Infof("block %s parent hash mismatch: stored %s, got %s", head.Number.String(), parent.Hash.Hex(), head.ParentHash.Hex())
+		return true, nil
+	}
+
+	// the main block has been stored as a fork block before
+	if jobs.Fork != nil && jobs.Main != nil {
+		bk, err := forkdb.ReadBlock(ctx, n.forkDb, jobs.Main.BlockData.Number)
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		if bk.Hash != jobs.Main.BlockData.Hash {
+			log.Infof("block %s hash mismatch: stored %s, got %s", bk.Number.String(), bk.Hash.Hex(), jobs.Main.BlockData.Hash.Hex())
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findCommonAncestor walks back from the block below head until the stored block matches the chain
+func (n *Sync) findCommonAncestor(ctx context.Context, head uint64) (uint64, error) {
+	for number := head - 1; ; number-- {
+		if head-1-number > n.reorgDepth {
+			return 0, fmt.Errorf("%w(%d) at block %d, resync the db or raise reorg_depth", ErrReorgTooDeep, n.reorgDepth, head)
+		}
+
+		stored, err := n.readStoredBlock(ctx, field.NewInt(int64(number)))
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				return number, nil
+			}
+			return 0, err
+		}
+		bk, err := n.client.GetBlockByNumber(ctx, hexutil.EncodeUint64(number))
+		if err != nil {
+			return 0, err
+		}
+		if bk.Hash == stored.Hash {
+			return number, nil
+		}
+		if number == 0 {
+			return 0, fmt.Errorf("no common ancestor below block %d", head)
+		}
+	}
+}
+
+// rollback undoes every step above ancestor, up to and including head
+func (n *Sync) rollback(head, ancestor uint64) (err error) {
+	var (
+		ctxMain, ctxFork context.Context
+		j                *types.Journal
+	)
+	ctxMain, err = n.db.BeginTx(context.Background())
+	if err != nil {
+		return err
+	}
+	ctxFork, err = n.forkDb.BeginTx(context.Background())
+	if err != nil {
+		n.db.RollBack(ctxMain)
+		return err
+	}
+	defer func() {
+		if err == nil {
+			n.db.Commit(ctxMain)
+			n.forkDb.Commit(ctxFork)
+		} else {
+			n.db.RollBack(ctxMain)
+			n.forkDb.RollBack(ctxFork)
+		}
+	}()
+
+	for number := head; number > ancestor; number-- {
+		step := field.NewInt(int64(number))
+		log.Infof("roll back block: %d", number)
+
+		if j, err = forkdb.ReadJournal(ctxFork, n.forkDb, step); err != nil {
+			log.Errorf("read fork journal(%d): %v", number, err)
+			return err
+		}
+		if err = revertJournal(ctxFork, n.forkDb, j); err != nil {
+			log.Errorf("revert fork journal(%d): %v", number, err)
+			return err
+		}
+		if err = forkdb.DeleteJournal(ctxFork, n.forkDb, step); err != nil {
+			return err
+		}
+
+		if j, err = fulldb.ReadJournal(ctxMain, n.db, step); err != nil {
+			log.Errorf("read journal(%d): %v", number, err)
+			return err
+		}
+		if err = revertJournal(ctxMain, n.db, j); err != nil {
+			log.Errorf("revert journal(%d): %v", number, err)
+			return err
+		}
+		if err = fulldb.DeleteJournal(ctxMain, n.db, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetCaches drops every cached total, they are read back from the db on the next block
+func resetCaches() {
+	txTotal = nil
+	erc20TrasferTotal = nil
+	erc721TrasferTotal = nil
+	erc1155TrasferTotal = nil
+	homeCache = nil
+	resetTransfers()
+
+	forkTxTotal = nil
+	forkErc20TrasferTotal = nil
+	forkErc721TrasferTotal = nil
+	forkErc1155TrasferTotal = nil
+	forkHomeCache = nil
+
+	accountTxTotalMap.Purge()
+	accountItxTotalMap.Purge()
+	erc20TrasferAccountTotalMap.Purge()
+	erc721TrasferAccountTotalMap.Purge()
+	erc1155TrasferAccountTotalMap.Purge()
+	erc20TransferContractTotalMap.Purge()
+	erc721TransferContractTotalMap.Purge()
+	erc1155TransferContractTotalMap.Purge()
+
+	forkAccountTxTotalMap.Purge()
+	forkAccountItxTotalMap.Purge()
+	forkErc20TrasferAccountTotalMap.Purge()
+	forkErc721TrasferAccountTotalMap.Purge()
+	forkErc1155TrasferAccountTotalMap.Purge()
+	forkErc20TransferContractTotalMap.Purge()
+	forkErc721TransferContractTotalMap.Purge()
+	forkErc1155TransferContractTotalMap.Purge()
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/storage"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+func testMainJobs(number uint64, parent common.Hash, miner common.Address, balance int64) *Jobs {
+	bk := &types.Block{
+		Number:     field.NewInt(int64(number)),
+		Hash:       common.BigToHash(new(big.Int).SetUint64(number*100 + uint64(balance))),
+		ParentHash: parent,
+		Coinbase:   miner,
+	}
+	j := job.NewSyncJob(context.Background(), number, nil)
+	j.BlockData = bk
+	j.ContractOrMemberData[miner] = &types.Account{Owner: miner, Balance: *field.NewInt(balance)}
+	j.Completed = true
+	return &Jobs{Main: j}
+}
+
+func TestRollback(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = storage.NewStorage(t.TempDir())
+		miner = common.HexToAddress("0x1")
+		n     = &Sync{db: store.FullDB, forkDb: store.ForkDB, forkNum: 2, reorgDepth: 8}
+	)
+	resetCaches()
+
+	var parent common.Hash
+	for number := uint64(1); number <= 3; number++ {
+		jobs := testMainJobs(number, parent, miner, int64(number))
+		assert.NoError(t, n.handleJobs(jobs))
+		parent = jobs.Main.BlockData.Hash
+	}
+	acc, err := fulldb.ReadAccount(ctx, store.FullDB, miner)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), acc.Balance.ToUint64())
+
+	// a block 3 which does not link to the stored block 2
+	reorged := testMainJobs(3, common.HexToHash("0xbad"), miner, 30)
+	reorg, err := n.checkReorg(ctx, reorged)
+	assert.NoError(t, err)
+	assert.True(t, reorg)
+
+	// block 1 is the common ancestor
+	assert.NoError(t, n.rollback(3, 1))
+	resetCaches()
+
+	_, err = fulldb.ReadBlock(ctx, store.FullDB, field.NewInt(2))
+	assert.ErrorIs(t, err, kv.NotFound)
+	_, err = fulldb.ReadBlock(ctx, store.FullDB, field.NewInt(3))
+	assert.ErrorIs(t, err, kv.NotFound)
+	_, err = fulldb.ReadJournal(ctx, store.FullDB, field.NewInt(3))
+	assert.ErrorIs(t, err, kv.NotFound)
+	bk, err := fulldb.ReadBlock(ctx, store.FullDB, field.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, common.Hash{}, bk.ParentHash)
+
+	acc, err = fulldb.ReadAccount(ctx, store.FullDB, miner)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), acc.Balance.ToUint64())
+	full, _, err := n.readSyncingBlocks()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), full.ToUint64())
+
+	// the chain goes on from the common ancestor
+	block1 := bk.Hash
+	jobs := testMainJobs(2, block1, miner, 20)
+	reorg, err = n.checkReorg(ctx, jobs)
+	assert.NoError(t, err)
+	assert.False(t, reorg)
+	assert.NoError(t, n.handleJobs(jobs))
+	acc, err = fulldb.ReadAccount(ctx, store.FullDB, miner)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), acc.Balance.ToUint64())
+}
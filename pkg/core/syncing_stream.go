@@ -0,0 +1,94 @@
+package core
+
+import (
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/stream"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// SetStream makes the committed blocks be pushed to the stream subscribers
+func (n *Sync) SetStream(h *stream.Hub) {
+	n.stream = h
+}
+
+// publishBlock pushes the newest block of the jobs. A fork block handled again as a main block
+// has been pushed already, so the main block is only pushed when there is no fork job.
+func (n *Sync) publishBlock(jobs *Jobs) {
+	if n.stream == nil {
+		return
+	}
+	data := jobs.Fork
+	if data == nil {
+		data = jobs.Main
+	}
+	n.stream.Publish(newStreamBlock(n.contractClient, data))
+}
+
+func newStreamBlock(client contract.Contractor, data *job.SyncJob) *stream.Block {
+	b := &stream.Block{
+		Number: data.BlockData.Number.ToUint64(),
+		Block: &types.BkSim{
+			Number:            *data.BlockData.Number,
+			Timestamp:         data.BlockData.TimeStamp,
+			Miner:             data.BlockData.Coinbase,
+			GasUsed:           data.BlockData.GasUsed,
+			TransactionsTotal: *field.NewInt(int64(len(data.TransactionDatas))),
+		},
+		Txs:       make([]*types.TxSim, 0, len(data.TransactionDatas)),
+		Transfers: make([]*stream.Transfer, 0),
+	}
+	for i, v := range data.TransactionDatas {
+		tx := &types.TxSim{
+			Hash:      v.Hash,
+			From:      v.From,
+			GasPrice:  v.GasPrice,
+			Gas:       v.Gas,
+			Timestamp: data.BlockData.TimeStamp,
+		}
+		if v.To != nil {
+			tx.To = *v.To
+		} else if data.ReceiptDatas[i].ContractAddress != nil {
+			tx.To = *data.ReceiptDatas[i].ContractAddress
+		}
+		b.Txs = append(b.Txs, tx)
+	}
+
+	transfers := blockTransfers(client, data)
+	for _, v := range transfers.erc20 {
+		amount := v.Amount
+		b.Transfers = append(b.Transfers, &stream.Transfer{
+			Type:            stream.TransferErc20,
+			TransactionHash: v.TransactionHash,
+			Contract:        v.Contract,
+			From:            v.From,
+			To:              v.To,
+			Amount:          &amount,
+		})
+	}
+	for _, v := range transfers.erc721 {
+		tokenID := v.TokenId
+		b.Transfers = append(b.Transfers, &stream.Transfer{
+			Type:            stream.TransferErc721,
+			TransactionHash: v.TransactionHash,
+			Contract:        v.Contract,
+			From:            v.From,
+			To:              v.To,
+			TokenID:         &tokenID,
+		})
+	}
+	for _, v := range transfers.erc1155 {
+		tokenID, quantity := v.TokenID, v.Quantity
+		b.Transfers = append(b.Transfers, &stream.Transfer{
+			Type:            stream.TransferErc1155,
+			TransactionHash: v.TransactionHash,
+			Contract:        v.Contract,
+			From:            v.From,
+			To:              v.To,
+			Amount:          &quantity,
+			TokenID:         &tokenID,
+		})
+	}
+	return b
+}
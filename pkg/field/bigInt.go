@@ -64,7 +64,7 @@ func (b *BigInt) MarshalJSON() ([]byte, error) {
 
 func (b *BigInt) UnmarshalJSON(bs []byte) error {
 	input := string(bytes.Trim(bs, "\""))
-	if input == "0x" {
+	if input == "0x" || input == "null" {
 		return nil
 	}
 	bi, err := hexutil.DecodeBig(input)
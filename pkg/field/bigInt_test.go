@@ -1,6 +1,7 @@
 package field
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -42,3 +43,11 @@ func TestAddNum(t *testing.T) {
 	assert.Equal(t, biRes.ToUint64(), uint64(332))
 	assert.Equal(t, bi.ToUint64(), uint64(332))
 }
+
+func TestUnmarshalNull(t *testing.T) {
+	var tx struct {
+		BlockNumber BigInt `json:"blockNumber"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"blockNumber":null}`), &tx))
+	assert.Equal(t, uint64(0), tx.BlockNumber.ToUint64())
+}
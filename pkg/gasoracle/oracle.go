@@ -0,0 +1,201 @@
+package gasoracle
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+const (
+	// percentiles of the priority fees of a block paid by the slow, standard and fast estimates
+	slowPercentile     = 25
+	standardPercentile = 50
+	fastPercentile     = 90
+
+	// latest blocks the estimates are taken from, the older ones are only kept for the history
+	estimateBlocks = 20
+
+	// base fee change denominator and elasticity multiplier of eip-1559
+	baseFeeChangeDenominator = 8
+	elasticityMultiplier     = 2
+)
+
+var ErrNoBlocks = errors.New("no block has been tracked yet")
+
+// Block is a committed block with the priority fees paid by its txs
+type Block struct {
+	Number       uint64
+	Timestamp    uint64
+	BaseFee      *big.Int
+	GasUsed      uint64
+	GasLimit     uint64
+	PriorityFees []*big.Int
+}
+
+// BlockFees are the fees of a block kept in the window
+type BlockFees struct {
+	Number       uint64
+	Timestamp    uint64
+	BaseFee      *big.Int
+	GasUsed      uint64
+	GasLimit     uint64
+	GasUsedRatio float64
+	TxCount      int
+	Slow         *big.Int
+	Standard     *big.Int
+	Fast         *big.Int
+}
+
+type Estimate struct {
+	BlockNumber uint64
+	BaseFee     *big.Int
+	NextBaseFee *big.Int
+	Slow        *big.Int // priority fees
+	Standard    *big.Int
+	Fast        *big.Int
+}
+
+// Oracle keeps the fees of a rolling window of the latest blocks
+type Oracle struct {
+	mu     sync.RWMutex
+	size   int
+	blocks []*BlockFees
+}
+
+func New(size int) *Oracle {
+	if size < estimateBlocks {
+		size = estimateBlocks
+	}
+	return &Oracle{
+		size:   size,
+		blocks: make([]*BlockFees, 0, size),
+	}
+}
+
+// Size is the number of blocks kept
+func (o *Oracle) Size() int {
+	return o.size
+}
+
+// Add appends a block, the blocks from its number on are replaced
+func (o *Oracle) Add(b *Block) {
+	fees := newBlockFees(b)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.truncate(b.Number - 1)
+	if len(o.blocks) == o.size {
+		copy(o.blocks, o.blocks[1:])
+		o.blocks = o.blocks[:o.size-1]
+	}
+	o.blocks = append(o.blocks, fees)
+}
+
+// Reorg drops the blocks after ancestor
+func (o *Oracle) Reorg(ancestor uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.truncate(ancestor)
+}
+
+func (o *Oracle) truncate(last uint64) {
+	for len(o.blocks) > 0 && o.blocks[len(o.blocks)-1].Number > last {
+		o.blocks = o.blocks[:len(o.blocks)-1]
+	}
+}
+
+// Estimate returns the priority fees to pay for a tx to be mined slowly, normally or quickly.
+// They are the medians of the percentiles of the latest blocks which have txs.
+func (o *Oracle) Estimate() (*Estimate, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if len(o.blocks) == 0 {
+		return nil, ErrNoBlocks
+	}
+
+	last := o.blocks[len(o.blocks)-1]
+	est := &Estimate{
+		BlockNumber: last.Number,
+		BaseFee:     last.BaseFee,
+		NextBaseFee: calcNextBaseFee(last.BaseFee, last.GasUsed, last.GasLimit),
+	}
+	slow, standard, fast := make([]*big.Int, 0), make([]*big.Int, 0), make([]*big.Int, 0)
+	for i := len(o.blocks) - 1; i >= 0 && i >= len(o.blocks)-estimateBlocks; i-- {
+		if o.blocks[i].TxCount == 0 {
+			continue
+		}
+		slow = append(slow, o.blocks[i].Slow)
+		standard = append(standard, o.blocks[i].Standard)
+		fast = append(fast, o.blocks[i].Fast)
+	}
+	est.Slow, est.Standard, est.Fast = median(slow), median(standard), median(fast)
+	return est, nil
+}
+
+// History returns the fees of the blocks of the window, the oldest first
+func (o *Oracle) History() []*BlockFees {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return append([]*BlockFees{}, o.blocks...)
+}
+
+func newBlockFees(b *Block) *BlockFees {
+	fees := &BlockFees{
+		Number:    b.Number,
+		Timestamp: b.Timestamp,
+		BaseFee:   b.BaseFee,
+		GasUsed:   b.GasUsed,
+		GasLimit:  b.GasLimit,
+		TxCount:   len(b.PriorityFees),
+	}
+	if fees.BaseFee == nil {
+		fees.BaseFee = new(big.Int)
+	}
+	if b.GasLimit > 0 {
+		fees.GasUsedRatio = float64(b.GasUsed) / float64(b.GasLimit)
+	}
+	sorted := append([]*big.Int{}, b.PriorityFees...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	fees.Slow = percentile(sorted, slowPercentile)
+	fees.Standard = percentile(sorted, standardPercentile)
+	fees.Fast = percentile(sorted, fastPercentile)
+	return fees
+}
+
+func percentile(sorted []*big.Int, p int) *big.Int {
+	if len(sorted) == 0 {
+		return new(big.Int)
+	}
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func median(values []*big.Int) *big.Int {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	return percentile(values, 50)
+}
+
+// calcNextBaseFee is the base fee of the child of a block as of eip-1559
+func calcNextBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	target := gasLimit / elasticityMultiplier
+	if baseFee.Sign() == 0 || target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+	if gasUsed > target {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(delta, baseFee)
+	}
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+	return delta.Sub(baseFee, delta)
+}
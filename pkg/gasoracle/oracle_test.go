@@ -0,0 +1,65 @@
+package gasoracle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testBlock(number uint64, fees ...int64) *Block {
+	b := &Block{
+		Number:   number,
+		BaseFee:  big.NewInt(1000),
+		GasUsed:  15000000,
+		GasLimit: 30000000,
+	}
+	for _, v := range fees {
+		b.PriorityFees = append(b.PriorityFees, big.NewInt(v))
+	}
+	return b
+}
+
+func TestEstimate(t *testing.T) {
+	o := New(30)
+	_, err := o.Estimate()
+	assert.ErrorIs(t, err, ErrNoBlocks)
+
+	for i := uint64(1); i <= 5; i++ {
+		o.Add(testBlock(i, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10))
+	}
+	o.Add(testBlock(6)) // empty blocks are left out of the estimates
+
+	est, err := o.Estimate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), est.BlockNumber)
+	assert.Equal(t, int64(1000), est.NextBaseFee.Int64())
+	assert.Equal(t, int64(3), est.Slow.Int64())
+	assert.Equal(t, int64(5), est.Standard.Int64())
+	assert.Equal(t, int64(9), est.Fast.Int64())
+}
+
+func TestReplace(t *testing.T) {
+	o := New(20)
+	for i := uint64(1); i <= 25; i++ {
+		o.Add(testBlock(i, 1))
+	}
+	history := o.History()
+	assert.Equal(t, 20, len(history))
+	assert.Equal(t, uint64(6), history[0].Number)
+
+	o.Add(testBlock(23, 2))
+	history = o.History()
+	assert.Equal(t, uint64(23), history[len(history)-1].Number)
+	assert.Equal(t, int64(2), history[len(history)-1].Standard.Int64())
+
+	o.Reorg(20)
+	assert.Equal(t, uint64(20), o.History()[len(o.History())-1].Number)
+}
+
+func TestNextBaseFee(t *testing.T) {
+	base := big.NewInt(1000000000)
+	assert.Equal(t, int64(1125000000), calcNextBaseFee(base, 30000000, 30000000).Int64())
+	assert.Equal(t, int64(875000000), calcNextBaseFee(base, 0, 30000000).Int64())
+	assert.Equal(t, int64(0), calcNextBaseFee(new(big.Int), 30000000, 30000000).Int64())
+}
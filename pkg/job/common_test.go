@@ -11,6 +11,6 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	testRpc = rpcclient.NewRpcClient([]string{"wss://testnet.ankr.com/ws"})
+	testRpc = rpcclient.NewRpcClient([]string{"wss://testnet.ankr.com/ws"}, rpcclient.HeadSourceAuto, 0)
 	m.Run()
 }
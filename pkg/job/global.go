@@ -1,13 +1,30 @@
 package job
 
-import "github.com/uchainorg/uscan/pkg/workpool"
+import (
+	"context"
+	"time"
+
+	"github.com/uchainorg/uscan/pkg/workpool"
+)
 
 var (
 	DebugJobChan workpool.Dispathcher
 	TxJobChan    workpool.Dispathcher
+	Tracing      = TracingFull
 )
 
-func GlobalInit(work int) {
+func GlobalInit(work int, tracing string) {
 	DebugJobChan = workpool.NewDispathcher(work)
 	TxJobChan = workpool.NewDispathcher(work * 3)
+	Tracing = tracing
+}
+
+// retryWait waits before a failed rpc call is retried, false once ctx is canceled
+func retryWait(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
 }
@@ -7,14 +7,20 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/uchainorg/uscan/pkg/field"
 	"github.com/uchainorg/uscan/pkg/log"
 	"github.com/uchainorg/uscan/pkg/rpcclient"
 	"github.com/uchainorg/uscan/pkg/types"
 )
 
+// receipts fetched in one batch when the node has no eth_getBlockReceipts
+const receiptBatchSize = 100
+
 type SyncJob struct {
 	Completed            bool
 	Block                uint64
+	ctx                  context.Context
 	client               rpcclient.RpcClient
 	BlockData            *types.Block
 	TransactionDatas     []*types.Tx
@@ -23,31 +29,45 @@ type SyncJob struct {
 	InternalTxs          map[common.Hash][]*types.InternalTx // changed money internal tx
 	ContractOrMemberData map[common.Address]*types.Account   // miner , from , to, new contract(changed money)
 	ContractInfoMap      map[common.Address]*types.Contract  // new contract
-	ProxyContracts       map[common.Address]common.Address   // proxy contract => logic contract
+	Proxies              map[common.Address]*types.Proxy     // standard proxies read at the block
+
+	proxyCandidates map[common.Address]common.Hash // address => tx which created or upgraded it
 }
 
-func NewSyncJob(block uint64, client rpcclient.RpcClient) *SyncJob {
+// NewSyncJob creates the job of a block, its rpc calls are retried until ctx is canceled
+func NewSyncJob(ctx context.Context, block uint64, client rpcclient.RpcClient) *SyncJob {
 	return &SyncJob{
+		ctx:                  ctx,
 		Block:                block,
 		client:               client,
 		ContractOrMemberData: make(map[common.Address]*types.Account),
 		ContractInfoMap:      make(map[common.Address]*types.Contract),
-		ProxyContracts:       make(map[common.Address]common.Address),
+		Proxies:              make(map[common.Address]*types.Proxy),
+		proxyCandidates:      make(map[common.Address]common.Hash),
 	}
 }
 
 func (e *SyncJob) Execute() {
 	var (
-		err error
-		ctx = context.Background()
+		err    error
+		ctx    = e.ctx
+		number = (*hexutil.Big)(big.NewInt(int64(e.Block))).String()
+		caps   = e.client.Capabilities()
+		txs    []*types.Tx
 	)
 
 	// get block data
 	for {
-		e.BlockData, err = e.client.GetBlockByNumber(ctx, (*hexutil.Big)(big.NewInt(int64(e.Block))).String())
+		if caps.FullBlock {
+			e.BlockData, txs, err = e.client.GetFullBlockByNumber(ctx, number)
+		} else {
+			e.BlockData, err = e.client.GetBlockByNumber(ctx, number)
+		}
 		if err != nil {
 			log.Errorf("get block(%d) data failed: %v", e.Block, err)
-			time.Sleep(time.Second)
+			if !retryWait(ctx, time.Second) {
+				return
+			}
 		} else {
 			break
 		}
@@ -60,23 +80,58 @@ func (e *SyncJob) Execute() {
 		}
 	}
 
+	// get balance of withdrawal recipients
+	for _, w := range e.BlockData.Withdrawals {
+		e.ContractOrMemberData[w.Address] = &types.Account{
+			Owner: w.Address,
+		}
+	}
+
 	if len(e.BlockData.Transactions) != 0 {
 		e.TransactionDatas = make([]*types.Tx, 0, len(e.BlockData.Transactions))
 		e.ReceiptDatas = make([]*types.Rt, 0, len(e.BlockData.Transactions))
 		e.CallFrames = make(map[common.Hash]*types.CallFrame, len(e.BlockData.Transactions))
 		e.InternalTxs = make(map[common.Hash][]*types.InternalTx)
 
+		// whatever can not be fetched for the whole block is fetched per tx
+		rts := e.getReceipts(ctx, caps, number)
+		frames := e.getCallFrames(ctx, caps, number)
+		if ctx.Err() != nil {
+			return
+		}
+		// the block trace failed and the node can not trace single txs either
+		untraced := Tracing != TracingOff && frames == nil && !caps.TraceTx
+		if untraced {
+			log.Errorf("block(%d) can not be traced, its internal txs are left out", e.Block)
+		}
+
 		data := make([]*Jobs, len(e.BlockData.Transactions))
 		for i, tx := range e.BlockData.Transactions {
 			jobs := &Jobs{
-				txJob:     NewSyncTxJob(tx, e.client),
-				rtJob:     NewSyncRtJob(tx, e.client),
-				tracerJob: NewSyncTracerJob(e.Block, tx, e.client),
+				txJob:     NewSyncTxJob(ctx, tx, e.client),
+				rtJob:     NewSyncRtJob(ctx, tx, e.client),
+				tracerJob: NewSyncTracerJob(ctx, e.Block, tx, e.client),
 			}
 			data[i] = jobs
-			TxJobChan.AddJob(jobs.txJob)
-			TxJobChan.AddJob(jobs.rtJob)
-			TxJobChan.AddJob(jobs.tracerJob)
+			if txs != nil {
+				jobs.txJob.setTransaction(txs[i])
+			} else {
+				TxJobChan.AddJob(jobs.txJob)
+			}
+			if rts != nil {
+				jobs.rtJob.setReceipt(rts[i])
+			} else {
+				TxJobChan.AddJob(jobs.rtJob)
+			}
+			switch {
+			case Tracing == TracingOff, untraced:
+				// contracts are taken from the receipt below
+				jobs.tracerJob.Completed = true
+			case frames != nil:
+				jobs.tracerJob.setCallFrame(frames[i])
+			default:
+				TxJobChan.AddJob(jobs.tracerJob)
+			}
 		}
 
 		for _, v := range data {
@@ -89,19 +144,27 @@ func (e *SyncJob) Execute() {
 					v.rtJob.ReceiptData.ReturnErr = v.tracerJob.Error
 					e.TransactionDatas = append(e.TransactionDatas, v.txJob.TransactionData)
 					e.ReceiptDatas = append(e.ReceiptDatas, v.rtJob.ReceiptData)
-					e.CallFrames[v.tracerJob.tx] = v.tracerJob.CallFrame
-					e.InternalTxs[v.tracerJob.tx] = v.tracerJob.InternalTxs
+					if Tracing == TracingFull {
+						e.CallFrames[v.tracerJob.tx] = v.tracerJob.CallFrame
+					}
+					if Tracing == TracingOff || untraced {
+						e.receiptContract(ctx, v.txJob.TransactionData, v.rtJob.ReceiptData)
+					} else {
+						e.InternalTxs[v.tracerJob.tx] = v.tracerJob.InternalTxs
+					}
 					e.mergeContractOrMember(v.tracerJob.ContractOrMemberData)
 					e.mergeContractOrMember(v.txJob.ContractOrMemberData)
 					e.mergeContract(v.tracerJob.ContractInfoMap)
-					e.mergeProxyContract(v.tracerJob.ProxyContract)
+					e.mergeProxyCandidates(v.tracerJob.DelegateCallers)
 					break
-				} else {
-					time.Sleep(time.Millisecond * 500)
+				} else if !retryWait(ctx, time.Millisecond*500) {
+					return
 				}
 			}
 		}
 	}
+	e.detectProxies(ctx)
+
 	addresses := make([]common.Address, 0, len(e.ContractOrMemberData))
 	for k := range e.ContractOrMemberData {
 		if k == (common.Address{}) {
@@ -113,7 +176,9 @@ func (e *SyncJob) Execute() {
 
 	for {
 		if balanceMap, err := e.client.GetBalances(ctx, addresses, hexutil.EncodeUint64(e.Block)); err != nil {
-			time.Sleep(time.Second)
+			if !retryWait(ctx, time.Second) {
+				return
+			}
 		} else {
 			for k, v := range balanceMap {
 				e.ContractOrMemberData[k].Balance = *v
@@ -125,6 +190,141 @@ func (e *SyncJob) Execute() {
 	e.Completed = true
 }
 
+// getReceipts returns the receipts of the block in the order of its transactions,
+// with eth_getBlockReceipts when the node supports it or else in chunked batches
+func (e *SyncJob) getReceipts(ctx context.Context, caps rpcclient.Capabilities, number string) []*types.Rt {
+	var (
+		rts []*types.Rt
+		err error
+	)
+	if caps.BlockReceipts {
+		if rts, err = e.client.GetBlockReceipts(ctx, number); err != nil {
+			return nil
+		}
+	} else {
+		rts = make([]*types.Rt, 0, len(e.BlockData.Transactions))
+		for i := 0; i < len(e.BlockData.Transactions); i += receiptBatchSize {
+			end := i + receiptBatchSize
+			if end > len(e.BlockData.Transactions) {
+				end = len(e.BlockData.Transactions)
+			}
+			res, err := e.client.GetTransactionReceiptsByHash(ctx, e.BlockData.Transactions[i:end])
+			if err != nil {
+				return nil
+			}
+			rts = append(rts, res...)
+		}
+	}
+
+	if len(rts) != len(e.BlockData.Transactions) {
+		log.Errorf("block(%d) has %d txs but %d receipts", e.Block, len(e.BlockData.Transactions), len(rts))
+		return nil
+	}
+	for i, v := range rts {
+		if v == nil || v.TxHash != e.BlockData.Transactions[i] {
+			log.Errorf("block(%d) receipt %d does not match tx %s", e.Block, i, e.BlockData.Transactions[i].Hex())
+			return nil
+		}
+	}
+	return rts
+}
+
+// traceRetries bounds the retries of a block trace when there is no per tx tracing to fall back to
+const traceRetries = 5
+
+// getCallFrames returns the call traces of the block in the order of its transactions,
+// nil when the node can not trace the whole block
+func (e *SyncJob) getCallFrames(ctx context.Context, caps rpcclient.Capabilities, number string) []*types.CallFrame {
+	if Tracing == TracingOff || !caps.TraceBlock {
+		return nil
+	}
+	var (
+		res []*rpcclient.TxTraceResult
+		err error
+	)
+	for retry := 0; ; retry++ {
+		res, err = e.client.GetTracerCalls(ctx, number)
+		if err == nil {
+			break
+		}
+		// the txs are traced one by one instead
+		if caps.TraceTx {
+			return nil
+		}
+		if retry >= traceRetries {
+			log.Errorf("trace block(%d) failed: %v", e.Block, err)
+			return nil
+		}
+		if !retryWait(ctx, time.Second) {
+			return nil
+		}
+	}
+	if len(res) != len(e.BlockData.Transactions) {
+		log.Errorf("block(%d) has %d txs but %d traces", e.Block, len(e.BlockData.Transactions), len(res))
+		return nil
+	}
+	frames := make([]*types.CallFrame, len(res))
+	for i, v := range res {
+		// older nodes do not return the tx hash
+		if v.Result == nil || v.Error != "" || (v.TxHash != (common.Hash{}) && v.TxHash != e.BlockData.Transactions[i]) {
+			log.Errorf("block(%d) trace %d of tx %s failed: %s", e.Block, i, e.BlockData.Transactions[i].Hex(), v.Error)
+			return nil
+		}
+		frames[i] = v.Result
+	}
+	return frames
+}
+
+// receiptContract takes the contract created by tx from its receipt when tracing is off,
+// contracts created by other contracts can not be seen without traces
+func (e *SyncJob) receiptContract(ctx context.Context, tx *types.Tx, rt *types.Rt) {
+	if rt.ContractAddress == nil || *rt.ContractAddress == (common.Address{}) {
+		return
+	}
+	addr := *rt.ContractAddress
+	var (
+		code string
+		err  error
+	)
+	for {
+		code, err = e.client.GetCode(ctx, addr, hexutil.EncodeUint64(e.Block))
+		if err != nil {
+			log.Errorf("get code(%s) failed: %v", addr.Hex(), err)
+			if !retryWait(ctx, time.Second) {
+				return
+			}
+		} else {
+			break
+		}
+	}
+	deployed, err := hexutil.Decode(code)
+	if err != nil || len(deployed) == 0 {
+		// failed deployment
+		return
+	}
+
+	in, arg := []byte(tx.Data), []byte{}
+	if len(deployed) >= 32 {
+		in, arg = getByteCodeAndArg(addr, tx.Data, deployed)
+	}
+	e.mergeContractOrMember(map[common.Address]*types.Account{
+		addr: {
+			Owner:       addr,
+			BlockNumber: *field.NewInt(int64(e.Block)),
+			Creator:     tx.From,
+			TxHash:      tx.Hash,
+		},
+	})
+	e.mergeContract(map[common.Address]*types.Contract{
+		addr: {
+			ByteCodeHash:          crypto.Keccak256Hash(in),
+			ByteCode:              in,
+			ConstructorArguements: arg,
+			DeployedCode:          deployed,
+		},
+	})
+}
+
 func (e *SyncJob) mergeContractOrMember(data map[common.Address]*types.Account) {
 	for k, v := range data {
 		if _, ok := e.ContractOrMemberData[k]; ok {
@@ -148,9 +348,15 @@ func (e *SyncJob) mergeContract(data map[common.Address]*types.Contract) {
 	}
 }
 
-func (e *SyncJob) mergeProxyContract(data map[common.Address]common.Address) {
-	for k, v := range data {
-		e.ProxyContracts[k] = v
+// mergeProxyCandidates adds the delegatecallers which have not been read yet
+func (e *SyncJob) mergeProxyCandidates(data map[common.Address]struct{}) {
+	for k := range data {
+		if _, ok := proxyChecked.Get(k); ok {
+			continue
+		}
+		if _, ok := e.proxyCandidates[k]; !ok {
+			e.proxyCandidates[k] = common.Hash{}
+		}
 	}
 }
 
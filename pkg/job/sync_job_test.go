@@ -1,12 +1,13 @@
 package job
 
 import (
+	"context"
 	"testing"
 )
 
 func TestBlockJob(t *testing.T) {
-	GlobalInit(2)
-	sj := NewSyncJob(1600937, testRpc)
+	GlobalInit(2, TracingFull)
+	sj := NewSyncJob(context.Background(), 1600937, testRpc)
 	sj.Execute()
 
 	t.Log("blockData", sj.BlockData)
@@ -0,0 +1,125 @@
+package job
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/utils"
+)
+
+var (
+	// eip-1967 slots, keccak256("eip1967.proxy.<name>") - 1
+	implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	adminSlot          = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	beaconSlot         = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	// eip-1822 slot, keccak256("PROXIABLE")
+	proxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+
+	UpgradedEventTopic       = common.HexToHash("0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b")
+	BeaconUpgradedEventTopic = common.HexToHash("0x1cf3b03a6cf19fa2baba4df148e9dcabedea7f8a5c07840e207e5c089be95d3e")
+	AdminChangedEventTopic   = common.HexToHash("0x7e644d79422f17c01e4894b5f4f588d331ebfa28653d42ae832dc59e38c9798f")
+
+	// implementation() of a beacon
+	beaconImplementationSelector = hexutil.MustDecode("0x5c60da1b")
+)
+
+// warns once that the node is not an archive node
+var latestProxyWarning sync.Once
+
+// delegatecallers whose slots have been read, a proxy is read again when it emits an upgrade event
+var proxyChecked = utils.NewCache()
+
+// detectProxies reads the proxy slots at the block of the contracts it created, of the contracts
+// which emitted an upgrade event and of the delegatecallers which have not been read yet
+func (e *SyncJob) detectProxies(ctx context.Context) {
+	for addr := range e.ContractInfoMap {
+		var txHash common.Hash
+		if acc, ok := e.ContractOrMemberData[addr]; ok {
+			txHash = acc.TxHash
+		}
+		e.proxyCandidates[addr] = txHash
+	}
+	for _, rt := range e.ReceiptDatas {
+		for _, l := range rt.Logs {
+			if len(l.Topics) == 0 {
+				continue
+			}
+			switch l.Topics[0] {
+			case UpgradedEventTopic, BeaconUpgradedEventTopic, AdminChangedEventTopic:
+				e.proxyCandidates[l.Address] = rt.TxHash
+			}
+		}
+	}
+
+	number := hexutil.EncodeUint64(e.Block)
+	for addr, txHash := range e.proxyCandidates {
+		p, err := e.readProxy(ctx, addr, number)
+		if err != nil {
+			// the state of an old block is only kept by an archive node, the slots are read at the
+			// latest block instead, which may hold an implementation set after this block
+			latestProxyWarning.Do(func() {
+				log.Warnf("read proxy(%s) at block(%d) failed, proxies are read at the latest block when the node keeps no state of their block: %v", addr.Hex(), e.Block, err)
+			})
+			p, err = e.readProxy(ctx, addr, "latest")
+		}
+		if err != nil {
+			// the proxy is read again with its next upgrade or delegatecall
+			log.Errorf("read proxy(%s) failed: %v", addr.Hex(), err)
+			continue
+		}
+		proxyChecked.Add(addr, struct{}{})
+		if p == nil {
+			continue
+		}
+		p.BlockNumber = e.Block
+		p.TxHash = txHash
+		e.Proxies[addr] = p
+	}
+}
+
+// readProxy returns nil when addr is not an eip-1967, beacon or eip-1822 proxy
+func (e *SyncJob) readProxy(ctx context.Context, addr common.Address, number string) (*types.Proxy, error) {
+	slots, err := e.client.GetStorageAts(ctx, addr, []common.Hash{implementationSlot, beaconSlot, proxiableSlot, adminSlot}, number)
+	if err != nil {
+		return nil, err
+	}
+	p := &types.Proxy{}
+	p.Admin, _ = slotAddress(slots[3])
+	if impl, ok := slotAddress(slots[0]); ok {
+		p.Kind, p.Implementation = types.ProxyEIP1967, impl
+	} else if beacon, ok := slotAddress(slots[1]); ok {
+		res, err := e.client.Call(ctx, beacon, beaconImplementationSelector, number)
+		if err != nil {
+			return nil, err
+		}
+		if len(res) != common.HashLength {
+			return nil, nil
+		}
+		if impl, ok = slotAddress(common.BytesToHash(res)); !ok {
+			return nil, nil
+		}
+		p.Kind, p.Implementation, p.Beacon = types.ProxyBeacon, impl, beacon
+	} else if impl, ok := slotAddress(slots[2]); ok {
+		p.Kind, p.Implementation = types.ProxyEIP1822, impl
+	} else {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// slotAddress returns the address held by a storage slot, other values are not an address
+func slotAddress(v common.Hash) (common.Address, bool) {
+	if v == (common.Hash{}) {
+		return common.Address{}, false
+	}
+	for _, b := range v[:common.HashLength-common.AddressLength] {
+		if b != 0 {
+			return common.Address{}, false
+		}
+	}
+	return common.BytesToAddress(v.Bytes()), true
+}
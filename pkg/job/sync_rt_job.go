@@ -12,15 +12,18 @@ import (
 
 type SyncRtJob struct {
 	Completed   bool
+	ctx         context.Context
 	tx          common.Hash
 	client      rpcclient.RpcClient
 	ReceiptData *types.Rt
 }
 
-func NewSyncRtJob(tx common.Hash,
+func NewSyncRtJob(ctx context.Context,
+	tx common.Hash,
 	client rpcclient.RpcClient,
 ) *SyncRtJob {
 	return &SyncRtJob{
+		ctx:    ctx,
 		tx:     tx,
 		client: client,
 	}
@@ -29,14 +32,22 @@ func NewSyncRtJob(tx common.Hash,
 func (e *SyncRtJob) Execute() {
 	var err error
 	for {
-		e.ReceiptData, err = e.client.GetTransactionReceiptByHash(context.Background(), e.tx)
+		e.ReceiptData, err = e.client.GetTransactionReceiptByHash(e.ctx, e.tx)
 		if err != nil {
 			log.Errorf("get transaction(%s) data failed: %v", e.tx.Hex(), err)
-			time.Sleep(time.Second)
+			if !retryWait(e.ctx, time.Second) {
+				return
+			}
 		} else {
 			break
 		}
 	}
+	e.setReceipt(e.ReceiptData)
+}
+
+// setReceipt completes the job with a receipt fetched in bulk
+func (e *SyncRtJob) setReceipt(rt *types.Rt) {
+	e.ReceiptData = rt
 	if e.ReceiptData.ContractAddress == nil {
 		e.ReceiptData.ContractAddress = &common.Address{}
 	}
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -11,7 +12,7 @@ import (
 
 func TestRtJob(t *testing.T) {
 	tx := common.HexToHash("0x9aaa0c4a421d8cd3e52765475acccb23a6dd388d0be384b00bb73fc7e8db796d")
-	rtJob := NewSyncRtJob(tx, testRpc)
+	rtJob := NewSyncRtJob(context.Background(), tx, testRpc)
 	rtJob.Execute()
 
 	assert.Equal(t, tx, rtJob.ReceiptData.TxHash)
@@ -19,6 +19,7 @@ import (
 type SyncTracerJob struct {
 	Completed bool
 	Status    bool // tx exec result
+	ctx       context.Context
 	block     uint64
 	tx        common.Hash
 	client    rpcclient.RpcClient
@@ -29,35 +30,45 @@ type SyncTracerJob struct {
 	// address => map
 	ContractOrMemberData map[common.Address]*types.Account
 	ContractInfoMap      map[common.Address]*types.Contract
-	ProxyContract        map[common.Address]common.Address // proxy => logic
+	DelegateCallers      map[common.Address]struct{} // proxy candidates, libraries delegatecall too
 }
 
-func NewSyncTracerJob(block uint64,
+func NewSyncTracerJob(ctx context.Context,
+	block uint64,
 	tx common.Hash,
 	client rpcclient.RpcClient,
 ) *SyncTracerJob {
 	return &SyncTracerJob{
+		ctx:                  ctx,
 		block:                block,
 		tx:                   tx,
 		client:               client,
 		InternalTxs:          make([]*types.InternalTx, 0, 1),
 		ContractOrMemberData: make(map[common.Address]*types.Account),
 		ContractInfoMap:      make(map[common.Address]*types.Contract),
-		ProxyContract:        make(map[common.Address]common.Address),
+		DelegateCallers:      make(map[common.Address]struct{}),
 	}
 }
 
 func (e *SyncTracerJob) Execute() {
 	var err error
 	for {
-		e.CallFrame, err = e.client.GetTracerCall(context.Background(), e.tx)
+		e.CallFrame, err = e.client.GetTracerCall(e.ctx, e.tx)
 		if err != nil {
 			log.Errorf("get transaction(%s) data failed: %v", e.tx.Hex(), err)
-			time.Sleep(time.Second)
+			if !retryWait(e.ctx, time.Second) {
+				return
+			}
 		} else {
 			break
 		}
 	}
+	e.setCallFrame(e.CallFrame)
+}
+
+// setCallFrame completes the job with a call frame traced in bulk
+func (e *SyncTracerJob) setCallFrame(frame *types.CallFrame) {
+	e.CallFrame = frame
 	if e.CallFrame.Error == "" {
 		e.Status = true
 	} else {
@@ -97,7 +108,7 @@ func (e *SyncTracerJob) handleCall(prefix string, data *types.CallFrame) {
 	}
 
 	if data.Type == "DELEGATECALL" {
-		e.ProxyContract[data.From] = data.To
+		e.DelegateCallers[data.From] = struct{}{}
 	}
 
 	if data.Value.String() != "0x0" {
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -9,7 +10,7 @@ import (
 
 func TestTracerJob(t *testing.T) {
 	hash := common.HexToHash("0x077dac720003821e7c6ef716cce0b7c312ba72e0fe574ccb0edbf598e07d8ac7")
-	job := NewSyncTracerJob(1691329, hash, testRpc)
+	job := NewSyncTracerJob(context.Background(), 1691329, hash, testRpc)
 	job.Execute()
 
 	assert.True(t, job.Status)
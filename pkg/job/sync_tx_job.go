@@ -12,15 +12,18 @@ import (
 
 type SyncTxJob struct {
 	Completed            bool
+	ctx                  context.Context
 	tx                   common.Hash
 	client               rpcclient.RpcClient
 	TransactionData      *types.Tx
 	ContractOrMemberData map[common.Address]*types.Account
 }
 
-func NewSyncTxJob(tx common.Hash,
+func NewSyncTxJob(ctx context.Context,
+	tx common.Hash,
 	client rpcclient.RpcClient) *SyncTxJob {
 	return &SyncTxJob{
+		ctx:                  ctx,
 		tx:                   tx,
 		client:               client,
 		ContractOrMemberData: make(map[common.Address]*types.Account),
@@ -30,15 +33,22 @@ func NewSyncTxJob(tx common.Hash,
 func (e *SyncTxJob) Execute() {
 	var err error
 	for {
-		e.TransactionData, err = e.client.GetTransactionByHash(context.Background(), e.tx)
+		e.TransactionData, err = e.client.GetTransactionByHash(e.ctx, e.tx)
 		if err != nil {
 			log.Errorf("get transaction(%s) data failed: %v", e.tx.Hex(), err)
-			time.Sleep(time.Second)
+			if !retryWait(e.ctx, time.Second) {
+				return
+			}
 		} else {
 			break
 		}
 	}
+	e.setTransaction(e.TransactionData)
+}
 
+// setTransaction completes the job with a transaction fetched in bulk
+func (e *SyncTxJob) setTransaction(tx *types.Tx) {
+	e.TransactionData = tx
 	if len(e.TransactionData.Data) > 0 {
 		e.TransactionData.Method = e.TransactionData.Data[:4]
 	}
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 
 func TestTxJob(t *testing.T) {
 	hash := common.HexToHash("0x9aaa0c4a421d8cd3e52765475acccb23a6dd388d0be384b00bb73fc7e8db796d")
-	txJob := NewSyncTxJob(hash, testRpc)
+	txJob := NewSyncTxJob(context.Background(), hash, testRpc)
 	txJob.Execute()
 
 	to := common.HexToAddress("0x07861819f3d9773088f67e5572bd645b2e5c15ef")
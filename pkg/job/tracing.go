@@ -0,0 +1,32 @@
+package job
+
+import (
+	"fmt"
+
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/rpcclient"
+)
+
+// tracing modes of the sync
+const (
+	TracingFull      = "full"       // call traces for internal txs and contracts, the call tree is kept for tracetx2
+	TracingCallsOnly = "calls-only" // call traces for internal txs and contracts only
+	TracingOff       = "off"        // no debug_* calls, contracts are detected from receipts
+)
+
+// ResolveTracing checks the configured mode against what the node supports,
+// tracing is turned off when the node can trace neither blocks nor txs
+func ResolveTracing(mode string, caps rpcclient.Capabilities) (string, error) {
+	switch mode {
+	case TracingFull, TracingCallsOnly:
+		if !caps.TraceBlock && !caps.TraceTx {
+			log.Infof("tracing mode %s is not supported by the node, fall back to %s", mode, TracingOff)
+			return TracingOff, nil
+		}
+		return mode, nil
+	case TracingOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown tracing mode: %s", mode)
+	}
+}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/rpcclient"
+)
+
+func TestResolveTracing(t *testing.T) {
+	mode, err := ResolveTracing(TracingFull, rpcclient.Capabilities{TraceTx: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TracingFull, mode)
+
+	mode, err = ResolveTracing(TracingCallsOnly, rpcclient.Capabilities{TraceBlock: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TracingCallsOnly, mode)
+
+	mode, err = ResolveTracing(TracingFull, rpcclient.Capabilities{FullBlock: true, BlockReceipts: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TracingOff, mode)
+
+	mode, err = ResolveTracing(TracingOff, rpcclient.Capabilities{TraceTx: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TracingOff, mode)
+
+	_, err = ResolveTracing("all", rpcclient.Capabilities{})
+	assert.Error(t, err)
+}
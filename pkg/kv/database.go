@@ -51,8 +51,13 @@ type Transactioner interface {
 type Sorter interface {
 	SPut(ctx context.Context, key, val []byte, opts *WriteOption) error
 	SDel(ctx context.Context, key, val []byte, opts *WriteOption) error
+	SHas(ctx context.Context, key, val []byte, opts *ReadOption) (bool, error)
 	SCount(ctx context.Context, key []byte, opts *ReadOption) (uint64, error)
 	SGet(ctx context.Context, key []byte, offset, limit uint64, opts *ReadOption) ([][]byte, error)
+	// SFloor returns the greatest value of key which is not greater than val
+	SFloor(ctx context.Context, key, val []byte, opts *ReadOption) ([]byte, error)
+	// SRange returns at most limit values of key which are not less than from, in ascending order
+	SRange(ctx context.Context, key, from []byte, limit uint64, opts *ReadOption) ([][]byte, error)
 }
 
 type Database interface {
@@ -17,6 +17,7 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package mdbx
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/torquem-ch/mdbx-go/mdbx"
@@ -55,6 +56,33 @@ func (d *MdbxDB) SDel(ctx context.Context, key, val []byte, opts *kv.WriteOption
 	return
 }
 
+func (d *MdbxDB) SHas(ctx context.Context, key, val []byte, opts *kv.ReadOption) (rs bool, err error) {
+	has := func(txn *mdbx.Txn) error {
+		c, err := txn.OpenCursor(d.tables[opts.Table])
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		_, _, err = c.Get(key, val, mdbx.GetBoth)
+		if err != nil {
+			if mdbx.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		rs = true
+		return nil
+	}
+
+	out, ok := ctx.Value(txKey{}).(*mdbx.Txn)
+	if ok {
+		err = has(out)
+	} else {
+		err = d.env.View(has)
+	}
+	return
+}
+
 func (d *MdbxDB) SGet(ctx context.Context, key []byte, offset, limit uint64, opts *kv.ReadOption) (rs [][]byte, err error) {
 	d.env.View(func(txn *mdbx.Txn) error {
 		c, err := txn.OpenCursor(d.tables[opts.Table])
@@ -123,3 +151,68 @@ func (d *MdbxDB) SCount(ctx context.Context, key []byte, opts *kv.ReadOption) (c
 	})
 	return
 }
+
+func (d *MdbxDB) SFloor(ctx context.Context, key, val []byte, opts *kv.ReadOption) (rs []byte, err error) {
+	floor := func(txn *mdbx.Txn) error {
+		c, err := txn.OpenCursor(d.tables[opts.Table])
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		var v []byte
+		_, v, err = c.Get(key, val, mdbx.GetBothRange)
+		if err == nil {
+			if bytes.Compare(v, val) > 0 {
+				_, v, err = c.Get(nil, nil, mdbx.PrevDup)
+			}
+		} else if mdbx.IsNotFound(err) {
+			// every value of key is less than val, or key does not exist
+			if _, _, err = c.Get(key, nil, mdbx.Set); err == nil {
+				_, v, err = c.Get(nil, nil, mdbx.LastDup)
+			}
+		}
+		if err != nil {
+			if mdbx.IsNotFound(err) {
+				err = kv.NotFound
+			}
+			return err
+		}
+		rs = v
+		return nil
+	}
+
+	out, ok := ctx.Value(txKey{}).(*mdbx.Txn)
+	if ok {
+		err = floor(out)
+	} else {
+		err = d.env.View(floor)
+	}
+	return
+}
+
+func (d *MdbxDB) SRange(ctx context.Context, key, from []byte, limit uint64, opts *kv.ReadOption) (rs [][]byte, err error) {
+	scan := func(txn *mdbx.Txn) error {
+		c, err := txn.OpenCursor(d.tables[opts.Table])
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		rs = make([][]byte, 0)
+		var v []byte
+		for _, v, err = c.Get(key, from, mdbx.GetBothRange); err == nil && uint64(len(rs)) < limit; _, v, err = c.Get(nil, nil, mdbx.NextDup) {
+			rs = append(rs, v)
+		}
+		if err != nil && !mdbx.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	out, ok := ctx.Value(txKey{}).(*mdbx.Txn)
+	if ok {
+		err = scan(out)
+	} else {
+		err = d.env.View(scan)
+	}
+	return
+}
@@ -58,3 +58,67 @@ func TestMdbx(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, kv.NotFound.Error())
 }
+
+func TestSFloor(t *testing.T) {
+	var (
+		path = t.TempDir()
+		db   = NewMdbx(path, []string{}, []string{"sort"})
+		ctx  = context.Background()
+		key  = []byte("/key")
+		opts = &kv.WriteOption{Table: "sort"}
+	)
+	defer db.Close()
+
+	for _, v := range [][]byte{{0x2}, {0x4}, {0x6}} {
+		assert.NoError(t, db.SPut(ctx, key, v, opts))
+	}
+
+	res, err := db.SFloor(ctx, key, []byte{0x4}, &kv.ReadOption{Table: "sort"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x4}, res)
+
+	res, err = db.SFloor(ctx, key, []byte{0x5}, &kv.ReadOption{Table: "sort"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x4}, res)
+
+	res, err = db.SFloor(ctx, key, []byte{0x9}, &kv.ReadOption{Table: "sort"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x6}, res)
+
+	_, err = db.SFloor(ctx, key, []byte{0x1}, &kv.ReadOption{Table: "sort"})
+	assert.ErrorIs(t, err, kv.NotFound)
+
+	_, err = db.SFloor(ctx, []byte("/none"), []byte{0x9}, &kv.ReadOption{Table: "sort"})
+	assert.ErrorIs(t, err, kv.NotFound)
+}
+
+func TestSRange(t *testing.T) {
+	var (
+		path = t.TempDir()
+		db   = NewMdbx(path, []string{}, []string{"sort"})
+		ctx  = context.Background()
+		key  = []byte("/key")
+		opts = &kv.ReadOption{Table: "sort"}
+	)
+	defer db.Close()
+
+	for _, v := range [][]byte{{0x2}, {0x4}, {0x6}, {0x8}} {
+		assert.NoError(t, db.SPut(ctx, key, v, &kv.WriteOption{Table: "sort"}))
+	}
+
+	res, err := db.SRange(ctx, key, []byte{0x3}, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x4}, {0x6}}, res)
+
+	res, err = db.SRange(ctx, key, []byte{0x6}, 10, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x6}, {0x8}}, res)
+
+	res, err = db.SRange(ctx, key, []byte{0x9}, 10, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = db.SRange(ctx, []byte("/none"), []byte{0x1}, 10, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
@@ -11,13 +11,14 @@ var _ kv.Database = (*Database)(nil)
 
 type Database struct {
 	db     map[string]map[string][]byte
-	dbList map[string][][]byte
+	dbList map[string]map[string][][]byte // table => key => values
 	lock   sync.RWMutex
 }
 
 func NewMemoryDb() *Database {
 	return &Database{
-		db: make(map[string]map[string][]byte),
+		db:     make(map[string]map[string][]byte),
+		dbList: make(map[string]map[string][][]byte),
 	}
 }
 
@@ -3,43 +3,94 @@ package memorydb
 import (
 	"bytes"
 	"context"
+	"sort"
 
 	"github.com/uchainorg/uscan/pkg/kv"
 )
 
 var _ kv.Sorter = (*Database)(nil)
 
+// values returns the sorted values of key, ascending like the duplicates of mdbx
+func (db *Database) values(table string, key []byte) [][]byte {
+	return db.dbList[table][string(key)]
+}
+
 func (db *Database) SPut(ctx context.Context, key, val []byte, opts *kv.WriteOption) error {
 	_, ok := db.dbList[opts.Table]
 	if !ok {
-		db.dbList[opts.Table] = make([][]byte, 0, 1)
+		db.dbList[opts.Table] = make(map[string][][]byte)
+	}
+	vals := db.values(opts.Table, key)
+	i := sort.Search(len(vals), func(i int) bool {
+		return bytes.Compare(vals[i], val) >= 0
+	})
+	if i < len(vals) && bytes.Equal(vals[i], val) {
+		return nil
 	}
-	db.dbList[opts.Table] = append(db.dbList[opts.Table], val)
+	vals = append(vals, nil)
+	copy(vals[i+1:], vals[i:])
+	vals[i] = append([]byte{}, val...)
+	db.dbList[opts.Table][string(key)] = vals
 	return nil
 }
 
 func (db *Database) SDel(ctx context.Context, key, val []byte, opts *kv.WriteOption) error {
-	_, ok := db.dbList[opts.Table]
-	if ok {
-		var vals = make([][]byte, 0, len(db.dbList[opts.Table]))
-		for _, v := range db.dbList[opts.Table] {
-			if !bytes.Equal(v, val) {
-				vals = append(vals, v)
-			}
+	vals := db.values(opts.Table, key)
+	for i, v := range vals {
+		if bytes.Equal(v, val) {
+			db.dbList[opts.Table][string(key)] = append(vals[:i:i], vals[i+1:]...)
+			return nil
 		}
-		db.dbList[opts.Table] = vals
 	}
 	return nil
 }
 
-func (db *Database) SCount(ctx context.Context, key []byte, opts *kv.ReadOption) (uint64, error) {
-	_, ok := db.dbList[opts.Table]
-	if ok {
-		return uint64(len(db.dbList[opts.Table])), nil
+func (db *Database) SHas(ctx context.Context, key, val []byte, opts *kv.ReadOption) (bool, error) {
+	for _, v := range db.values(opts.Table, key) {
+		if bytes.Equal(v, val) {
+			return true, nil
+		}
 	}
-	return uint64(0), nil
+	return false, nil
 }
 
+func (db *Database) SCount(ctx context.Context, key []byte, opts *kv.ReadOption) (uint64, error) {
+	return uint64(len(db.values(opts.Table, key))), nil
+}
+
+// SGet returns the values of key from the largest one
 func (db *Database) SGet(ctx context.Context, key []byte, page, pageSize uint64, opts *kv.ReadOption) ([][]byte, error) {
-	return nil, nil
+	vals := db.values(opts.Table, key)
+	rs := make([][]byte, 0)
+	for i := len(vals) - 1 - int(page); i >= 0 && uint64(len(rs)) < pageSize; i-- {
+		rs = append(rs, vals[i])
+	}
+	return rs, nil
+}
+
+func (db *Database) SFloor(ctx context.Context, key, val []byte, opts *kv.ReadOption) ([]byte, error) {
+	var rs []byte
+	for _, v := range db.values(opts.Table, key) {
+		if bytes.Compare(v, val) > 0 {
+			break
+		}
+		rs = v
+	}
+	if rs == nil {
+		return nil, kv.NotFound
+	}
+	return rs, nil
+}
+
+func (db *Database) SRange(ctx context.Context, key, from []byte, limit uint64, opts *kv.ReadOption) ([][]byte, error) {
+	rs := make([][]byte, 0)
+	for _, v := range db.values(opts.Table, key) {
+		if uint64(len(rs)) == limit {
+			break
+		}
+		if bytes.Compare(v, from) >= 0 {
+			rs = append(rs, v)
+		}
+	}
+	return rs, nil
 }
@@ -44,3 +44,44 @@ func TestMemoryDb(t *testing.T) {
 	assert.False(t, exists)
 
 }
+
+func TestMemoryDbSort(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		db    = NewMemoryDb()
+		opts  = &kv.WriteOption{Table: "test"}
+		ropts = &kv.ReadOption{Table: "test"}
+	)
+	for _, v := range []string{"c", "a", "b", "a"} {
+		assert.NoError(t, db.SPut(ctx, []byte("key"), []byte(v), opts))
+	}
+	assert.NoError(t, db.SPut(ctx, []byte("other"), []byte("b"), opts))
+
+	count, err := db.SCount(ctx, []byte("key"), ropts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	// only the values of the key are ranged over
+	rs, err := db.SRange(ctx, []byte("key"), []byte("b"), 10, ropts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("b"), []byte("c")}, rs)
+	rs, err = db.SRange(ctx, []byte("other"), nil, 10, ropts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("b")}, rs)
+
+	rs, err = db.SGet(ctx, []byte("key"), 1, 10, ropts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("b"), []byte("a")}, rs)
+
+	floor, err := db.SFloor(ctx, []byte("key"), []byte("bb"), ropts)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("b"), floor)
+
+	assert.NoError(t, db.SDel(ctx, []byte("key"), []byte("b"), opts))
+	has, err := db.SHas(ctx, []byte("key"), []byte("b"), ropts)
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = db.SHas(ctx, []byte("other"), []byte("b"), ropts)
+	assert.NoError(t, err)
+	assert.True(t, has)
+}
@@ -23,6 +23,7 @@ import (
 
 var (
 	infoLogger  = log.New(os.Stdout, "[uscan] INF ", log.Ldate|log.Ltime|log.Lmsgprefix)
+	warnLogger  = log.New(os.Stdout, "[uscan] WRN ", log.Ldate|log.Ltime|log.Lmsgprefix)
 	errorLogger = log.New(os.Stdout, "[uscan] ERR ", log.Ldate|log.Ltime|log.Lmsgprefix)
 	fatalLogger = log.New(os.Stdout, "[uscan] FTL ", log.Ldate|log.Ltime|log.Lmsgprefix)
 )
@@ -34,6 +35,15 @@ func Info(msg ...any) {
 func Infof(format string, msg ...any) {
 	infoLogger.Printf(format, msg...)
 }
+
+func Warn(msg ...any) {
+	warnLogger.Println(msg...)
+}
+
+func Warnf(format string, msg ...any) {
+	warnLogger.Printf(format, msg...)
+}
+
 func Error(msg ...any) {
 	errorLogger.Println(msg...)
 }
@@ -19,6 +19,9 @@ package pkg
 import (
 	"context"
 	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/uchainorg/uscan/pkg/service"
 	"github.com/uchainorg/uscan/pkg/storage"
@@ -26,7 +29,14 @@ import (
 	"github.com/spf13/viper"
 	"github.com/uchainorg/uscan/pkg/contract"
 	"github.com/uchainorg/uscan/pkg/core"
+	"github.com/uchainorg/uscan/pkg/gasoracle"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/mempool"
+	"github.com/uchainorg/uscan/pkg/nft"
 	"github.com/uchainorg/uscan/pkg/rpcclient"
+	"github.com/uchainorg/uscan/pkg/stream"
+	"github.com/uchainorg/uscan/pkg/webhook"
 	"github.com/uchainorg/uscan/share"
 
 	"github.com/spf13/cobra"
@@ -35,17 +45,65 @@ import (
 )
 
 func MainRun(cmd *cobra.Command, args []string) {
-	os.RemoveAll(viper.GetString(share.MdbxPath) + "/fork")
 	storage := storage.NewStorage(viper.GetString(share.MdbxPath))
-	rpcMgr := rpcclient.NewRpcClient(viper.GetStringSlice(share.RpcUrls))
+	rpcMgr := rpcclient.NewRpcClient(viper.GetStringSlice(share.RpcUrls), viper.GetString(share.HeadSource), viper.GetDuration(share.PollInterval))
+	tracing, err := job.ResolveTracing(viper.GetString(share.TracingMode), rpcMgr.Capabilities())
+	if err != nil {
+		log.Fatalf("tracing mode: %v", err)
+	}
 
-	sync := core.NewSync(rpcMgr, contract.NewClient(rpcMgr), viper.GetInt64(share.ForkBlockNum), storage.FullDB, storage.ForkDB, viper.GetUint64(share.WorkChan))
-	go sync.Execute(context.Background())
+	contractClient := contract.NewClient(rpcMgr)
+	sync := core.NewSync(rpcMgr, contractClient, viper.GetInt64(share.ForkBlockNum), viper.GetUint64(share.ReorgDepth), viper.GetUint64(share.StartBlock), tracing, storage.FullDB, storage.ForkDB, viper.GetUint64(share.WorkChan))
+
+	webhooks, err := webhook.NewDispatcher(storage.FullDB, share.WebhookWorkers)
+	if err != nil {
+		log.Fatalf("load webhooks: %v", err)
+	}
+	sync.SetWebhooks(webhooks)
+	hub := stream.NewHub(viper.GetInt(share.StreamBuffer))
+	sync.SetStream(hub)
+	gasOracle := gasoracle.New(viper.GetInt(share.GasTrackerBlocks))
+	sync.SetGasOracle(gasOracle)
+	var pending *mempool.Pool
+	if source := viper.GetString(share.PendingSource); source != mempool.SourceOff {
+		pending = mempool.NewPool(viper.GetInt(share.PendingPoolSize))
+		sync.SetMempool(pending)
+	}
 
 	service.NewStore(storage)
+	service.SetWebhooks(webhooks)
+	service.SetStream(hub)
+	service.SetGasOracle(gasOracle)
+	service.SetMempool(pending)
+	service.SetTracing(tracing)
+	service.SetContractClient(contractClient)
+	service.SetMetadataResolver(nft.NewResolver(viper.GetString(share.IpfsGateway)))
 	service.StartHandleContractVerity()
 	apis.GetChainID(rpcMgr.ChainID(context.Background()))
 	_, svc := grace.New(context.Background())
+	var failed atomic.Bool
+	svc.RegisterService("sync service", func(ctx context.Context) error {
+		err := sync.Execute(ctx)
+		if err != nil && ctx.Err() == nil {
+			// grace ignores the error of a service, so stop the whole process
+			// rather than keep serving a chain which is no longer synced
+			log.Errorf("sync service exited: %v, shutting down", err)
+			failed.Store(true)
+			syscall.Kill(os.Getpid(), syscall.SIGTERM)
+			time.AfterFunc(time.Minute, func() { os.Exit(1) })
+		}
+		return err
+	})
 	svc.RegisterService("web service", apis.Apis)
+	if pending != nil {
+		watcher := mempool.NewWatcher(rpcMgr, pending, viper.GetString(share.PendingSource), viper.GetDuration(share.PollInterval))
+		svc.RegisterService("pending tx watcher", watcher.Run)
+	}
+	svc.Register(sync.Stop)
+	svc.Register(webhooks.Stop)
+	svc.Register(hub.Close)
 	svc.Wait()
+	if failed.Load() {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,125 @@
+package mempool
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// Entry is a pending tx and the time it was first seen
+type Entry struct {
+	Tx   *types.Tx
+	Seen uint64
+}
+
+// Pool keeps the latest pending txs in memory, the oldest are evicted once it is full
+type Pool struct {
+	mu    sync.RWMutex
+	size  int
+	order *list.List // oldest first
+	txs   map[common.Hash]*list.Element
+}
+
+func NewPool(size int) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	return &Pool{
+		size:  size,
+		order: list.New(),
+		txs:   make(map[common.Hash]*list.Element),
+	}
+}
+
+// Add adds a pending tx, it returns false when the tx is known already
+func (p *Pool) Add(tx *types.Tx) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.txs[tx.Hash]; ok {
+		return false
+	}
+	if p.order.Len() == p.size {
+		p.remove(p.order.Front())
+	}
+	p.txs[tx.Hash] = p.order.PushBack(&Entry{Tx: tx, Seen: uint64(time.Now().Unix())})
+	return true
+}
+
+// Remove drops the txs, they have been mined or dropped by the node
+func (p *Pool) Remove(hashes ...common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, hash := range hashes {
+		if e, ok := p.txs[hash]; ok {
+			p.remove(e)
+		}
+	}
+}
+
+// Retain drops the txs which are not in pending
+func (p *Pool) Retain(pending map[common.Hash]struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for hash, e := range p.txs {
+		if _, ok := pending[hash]; !ok {
+			p.remove(e)
+		}
+	}
+}
+
+// Hashes returns the hashes of the pooled txs, the oldest first
+func (p *Pool) Hashes() []common.Hash {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	res := make([]common.Hash, 0, p.order.Len())
+	for e := p.order.Front(); e != nil; e = e.Next() {
+		res = append(res, e.Value.(*Entry).Tx.Hash)
+	}
+	return res
+}
+
+func (p *Pool) Has(hash common.Hash) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.txs[hash]
+	return ok
+}
+
+func (p *Pool) Get(hash common.Hash) (*Entry, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.txs[hash]
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*Entry), true
+}
+
+// List returns the pending txs, the latest seen first
+func (p *Pool) List(offset, limit int) []*Entry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	res := make([]*Entry, 0, limit)
+	i := 0
+	for e := p.order.Back(); e != nil && len(res) < limit; e = e.Prev() {
+		if i >= offset {
+			res = append(res, e.Value.(*Entry))
+		}
+		i++
+	}
+	return res
+}
+
+func (p *Pool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.order.Len()
+}
+
+func (p *Pool) remove(e *list.Element) {
+	delete(p.txs, e.Value.(*Entry).Tx.Hash)
+	p.order.Remove(e)
+}
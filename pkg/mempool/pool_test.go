@@ -0,0 +1,40 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+func testTx(i int64) *types.Tx {
+	return &types.Tx{Hash: common.BigToHash(big.NewInt(i))}
+}
+
+func TestPool(t *testing.T) {
+	p := NewPool(3)
+	for i := int64(1); i <= 4; i++ {
+		assert.True(t, p.Add(testTx(i)))
+	}
+	assert.False(t, p.Add(testTx(4)))
+
+	// the oldest is evicted once the pool is full
+	assert.Equal(t, 3, p.Len())
+	assert.False(t, p.Has(testTx(1).Hash))
+
+	list := p.List(0, 10)
+	assert.Equal(t, testTx(4).Hash, list[0].Tx.Hash)
+	assert.Equal(t, testTx(2).Hash, list[2].Tx.Hash)
+	assert.Equal(t, 1, len(p.List(2, 10)))
+	assert.Equal(t, []common.Hash{testTx(2).Hash, testTx(3).Hash, testTx(4).Hash}, p.Hashes())
+
+	p.Remove(testTx(3).Hash)
+	assert.False(t, p.Has(testTx(3).Hash))
+
+	p.Retain(map[common.Hash]struct{}{testTx(2).Hash: {}})
+	assert.Equal(t, 1, p.Len())
+	_, ok := p.Get(testTx(2).Hash)
+	assert.True(t, ok)
+}
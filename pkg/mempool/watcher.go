@@ -0,0 +1,195 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/rpcclient"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// sources of the pending txs
+const (
+	SourceOff       = "off"
+	SourceAuto      = "auto"      // subscribe, poll when the node has no subscriptions
+	SourceSubscribe = "subscribe" // eth_subscribe newPendingTransactions
+	SourcePoll      = "poll"      // txpool_content every poll interval
+)
+
+const (
+	// hashes of the subscription fetched in one batch
+	fetchBatch    = 100
+	fetchInterval = 500 * time.Millisecond
+	// the pooled txs of a subscription are checked against the node every interval
+	recheckInterval = 30 * time.Second
+	// waits between two failed subscriptions, doubled up to the max
+	resubscribeBackoff    = time.Second
+	resubscribeMaxBackoff = time.Minute
+)
+
+// Watcher fills a pool with the pending txs of the node
+type Watcher struct {
+	client   rpcclient.RpcClient
+	pool     *Pool
+	source   string
+	interval time.Duration
+}
+
+func NewWatcher(client rpcclient.RpcClient, pool *Pool, source string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = 3 * time.Second
+	}
+	return &Watcher{
+		client:   client,
+		pool:     pool,
+		source:   source,
+		interval: interval,
+	}
+}
+
+// Run watches the pending txs until ctx is canceled
+func (w *Watcher) Run(ctx context.Context) error {
+	switch w.source {
+	case SourcePoll:
+		w.poll(ctx)
+		return nil
+	case SourceSubscribe, SourceAuto:
+		err := w.subscribe(ctx)
+		if w.source == SourceAuto && errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			log.Infof("node has no subscriptions, poll txpool_content every %s", w.interval)
+			w.poll(ctx)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (w *Watcher) subscribe(ctx context.Context) error {
+	hashes := make(chan common.Hash, fetchBatch*10)
+	sub, err := w.client.SubscribePendingTransactions(ctx, hashes)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(fetchInterval)
+	defer ticker.Stop()
+	recheck := time.NewTicker(recheckInterval)
+	defer recheck.Stop()
+
+	batch := make([]common.Hash, 0, fetchBatch)
+	for {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+			return nil
+		case err = <-sub.Err():
+			log.Errorf("subscribe pending txs: %v", err)
+			if sub = w.resubscribe(ctx, hashes); sub == nil {
+				return nil
+			}
+		case hash := <-hashes:
+			batch = append(batch, hash)
+			if len(batch) == fetchBatch {
+				w.fetch(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.fetch(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-recheck.C:
+			w.recheck(ctx)
+		}
+	}
+}
+
+// resubscribe subscribes again until it succeeds, it returns nil once ctx is canceled
+func (w *Watcher) resubscribe(ctx context.Context, hashes chan common.Hash) ethereum.Subscription {
+	backoff := resubscribeBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		sub, err := w.client.SubscribePendingTransactions(ctx, hashes)
+		if err == nil {
+			log.Infof("resubscribed pending txs")
+			return sub
+		}
+		log.Errorf("resubscribe pending txs: %v, retry in %s", err, backoff)
+		if backoff *= 2; backoff > resubscribeMaxBackoff {
+			backoff = resubscribeMaxBackoff
+		}
+	}
+}
+
+// recheck drops the pooled txs the node no longer has pending. A subscription only tells
+// about new txs, so the ones dropped or replaced by another tx of the same nonce are found here.
+func (w *Watcher) recheck(ctx context.Context) {
+	hashes := w.pool.Hashes()
+	for start := 0; start < len(hashes); start += fetchBatch {
+		end := start + fetchBatch
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		txs, err := w.client.GetTransactionsByHash(ctx, hashes[start:end])
+		if err != nil {
+			log.Errorf("recheck pending txs: %v", err)
+			return
+		}
+		for i, tx := range txs {
+			if !pending(tx) {
+				w.pool.Remove(hashes[start+i])
+			}
+		}
+	}
+}
+
+// fetch reads the txs of the hashes, the ones mined or dropped meanwhile are skipped
+func (w *Watcher) fetch(ctx context.Context, hashes []common.Hash) {
+	txs, err := w.client.GetTransactionsByHash(ctx, hashes)
+	if err != nil {
+		log.Errorf("get pending txs: %v", err)
+		return
+	}
+	for _, tx := range txs {
+		if pending(tx) {
+			w.pool.Add(tx)
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		txs, err := w.client.GetPendingTransactions(ctx)
+		if err != nil {
+			log.Errorf("get txpool content: %v", err)
+		} else {
+			current := make(map[common.Hash]struct{}, len(txs))
+			for _, tx := range txs {
+				current[tx.Hash] = struct{}{}
+				w.pool.Add(tx)
+			}
+			w.pool.Retain(current)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func pending(tx *types.Tx) bool {
+	return tx.Hash != (common.Hash{}) && tx.BlockNum.ToUint64() == 0
+}
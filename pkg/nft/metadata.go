@@ -0,0 +1,160 @@
+package nft
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/uchainorg/uscan/pkg/utils"
+)
+
+const (
+	// bytes of a metadata document read at most
+	maxMetadataSize = 1 << 20
+	fetchTimeout    = 10 * time.Second
+)
+
+var (
+	ErrUnsupportedURI = errors.New("unsupported uri")
+	ErrInvalidJSON    = errors.New("metadata is not a json object")
+)
+
+// Resolver fetches the metadata of the tokens, ipfs uris are read through a http gateway.
+// The uris are set by the token contracts, so they are only fetched from public addresses,
+// the configured gateway may be a local node.
+type Resolver struct {
+	gateway       string
+	gatewayClient *http.Client
+	client        *http.Client
+}
+
+func NewResolver(gateway string) *Resolver {
+	if !strings.HasSuffix(gateway, "/") {
+		gateway += "/"
+	}
+	return &Resolver{
+		gateway:       gateway,
+		gatewayClient: &http.Client{Timeout: fetchTimeout},
+		client:        utils.NewPublicClient(fetchTimeout),
+	}
+}
+
+// TokenURI replaces the {id} of an erc1155 uri with the token id, as 64 lowercase hex chars
+func TokenURI(uri string, tokenId *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", tokenId))
+}
+
+// URL returns the gateway url of an ipfs uri, other uris are returned as they are
+func (r *Resolver) URL(uri string) string {
+	if !strings.HasPrefix(uri, "ipfs://") {
+		return uri
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+	return r.gateway + path
+}
+
+// Fetch reads the document of a uri, a data uri is decoded in place
+func (r *Resolver) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	if strings.HasPrefix(uri, "data:") {
+		return decodeDataURI(uri)
+	}
+	u, err := url.Parse(r.URL(uri))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	client := r.client
+	if strings.HasPrefix(uri, "ipfs://") {
+		client = r.gatewayClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: %s", u, resp.Status)
+	}
+	bin, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bin) > maxMetadataSize {
+		return nil, fmt.Errorf("get %s: metadata is larger than %d bytes", u, maxMetadataSize)
+	}
+	return bin, nil
+}
+
+// decodeDataURI decodes data:[<mediatype>][;base64],<data>
+func decodeDataURI(uri string) ([]byte, error) {
+	comma := strings.IndexByte(uri, ',')
+	if comma < 0 {
+		return nil, fmt.Errorf("%w: data uri without data", ErrUnsupportedURI)
+	}
+	params, data := uri[len("data:"):comma], uri[comma+1:]
+	if strings.HasSuffix(params, ";base64") {
+		if bin, err := base64.StdEncoding.DecodeString(data); err == nil {
+			return bin, nil
+		}
+		return base64.RawStdEncoding.DecodeString(data)
+	}
+	text, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+// Metadata is the erc721 or erc1155 metadata of a token, the whole document is kept in Raw
+type Metadata struct {
+	Name        string
+	Description string
+	Image       string
+	Attributes  json.RawMessage
+	Raw         json.RawMessage
+}
+
+// ParseMetadata reads the well known fields of a metadata document, fields of another type are left empty
+func ParseMetadata(bin []byte) (*Metadata, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bin, &fields); err != nil {
+		return nil, ErrInvalidJSON
+	}
+	m := &Metadata{
+		Name:        stringField(fields, "name"),
+		Description: stringField(fields, "description"),
+		Image:       stringField(fields, "image"),
+		Attributes:  fields["attributes"],
+		Raw:         bin,
+	}
+	if m.Image == "" {
+		m.Image = stringField(fields, "image_url")
+	}
+	// erc1155 names the attributes properties
+	if m.Attributes == nil {
+		m.Attributes = fields["properties"]
+	}
+	return m, nil
+}
+
+func stringField(fields map[string]json.RawMessage, name string) string {
+	var s string
+	if raw, ok := fields[name]; ok {
+		_ = json.Unmarshal(raw, &s)
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package nft
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/utils"
+)
+
+func TestTokenURI(t *testing.T) {
+	assert.Equal(t, "https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json",
+		TokenURI("https://token-cdn-domain/{id}.json", big.NewInt(314592)))
+	assert.Equal(t, "ipfs://Qm/1", TokenURI("ipfs://Qm/1", big.NewInt(1)))
+}
+
+func TestURL(t *testing.T) {
+	r := NewResolver("https://ipfs.io/ipfs")
+	assert.Equal(t, "https://ipfs.io/ipfs/QmHash/1.json", r.URL("ipfs://QmHash/1.json"))
+	assert.Equal(t, "https://ipfs.io/ipfs/QmHash", r.URL("ipfs://ipfs/QmHash"))
+	assert.Equal(t, "https://example.com/1", r.URL("https://example.com/1"))
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ipfs/QmHash/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name":"one"}`))
+	}))
+	defer srv.Close()
+	r := NewResolver(srv.URL + "/ipfs/")
+
+	bin, err := r.Fetch(ctx, "ipfs://QmHash/1")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"one"}`, string(bin))
+	_, err = r.Fetch(ctx, "ipfs://missing")
+	assert.Error(t, err)
+	// the uris of the tokens are not fetched from local addresses
+	_, err = r.Fetch(ctx, srv.URL+"/ipfs/QmHash/1")
+	assert.ErrorIs(t, err, utils.ErrNonPublicAddress)
+
+	bin, err = r.Fetch(ctx, "data:application/json;base64,eyJuYW1lIjoidHdvIn0=")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"two"}`, string(bin))
+	bin, err = r.Fetch(ctx, `data:application/json,{"name":"three%20"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"three "}`, string(bin))
+
+	_, err = r.Fetch(ctx, "ftp://example.com/1")
+	assert.ErrorIs(t, err, ErrUnsupportedURI)
+}
+
+func TestParseMetadata(t *testing.T) {
+	m, err := ParseMetadata([]byte(`{"name":"one","description":1,"image_url":"ipfs://QmImage","attributes":[{"trait_type":"eyes","value":"blue"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "one", m.Name)
+	assert.Equal(t, "", m.Description)
+	assert.Equal(t, "ipfs://QmImage", m.Image)
+	assert.JSONEq(t, `[{"trait_type":"eyes","value":"blue"}]`, string(m.Attributes))
+
+	_, err = ParseMetadata([]byte(`["one"]`))
+	assert.ErrorIs(t, err, ErrInvalidJSON)
+}
@@ -18,6 +18,9 @@ const (
 	recordNotFindErr  = 10002
 	contractVerityErr = 10003
 	exportNumErr      = 10004
+	streamResumeErr   = 10005
+	notConfirmedErr   = 10006
+	unauthorizedErr   = 10007
 )
 
 func NewUnknownError(err error) *Error {
@@ -27,6 +30,13 @@ func NewUnknownError(err error) *Error {
 	}
 }
 
+func NewStreamResumeError(err error) *Error {
+	return &Error{
+		Code: streamResumeErr,
+		Msg:  err.Error(),
+	}
+}
+
 var (
 	ErrInvalidParameter = &Error{
 		Code: invalidParameter,
@@ -37,6 +47,16 @@ var (
 		Code: recordNotFindErr,
 		Msg:  "record not find",
 	}
+
+	ErrBlockNotConfirmed = &Error{
+		Code: notConfirmedErr,
+		Msg:  "block is still in the fork window",
+	}
+
+	ErrUnauthorized = &Error{
+		Code: unauthorizedErr,
+		Msg:  "unauthorized",
+	}
 )
 
 var (
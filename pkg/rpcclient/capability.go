@@ -0,0 +1,67 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/share"
+)
+
+// probeCapabilities calls every bulk method of client against the block number,
+// a method is supported when the node answers it without an error
+func probeCapabilities(ctx context.Context, client *rpcGroup, number uint64) Capabilities {
+	ctx, cancel := context.WithTimeout(ctx, share.HttpTimeout)
+	defer cancel()
+
+	var (
+		caps     Capabilities
+		block    = hexutil.EncodeUint64(number)
+		full     fullBlock
+		receipts []interface{}
+		traces   []*TxTraceResult
+	)
+	if err := client.rpcClient.CallContext(ctx, &full, "eth_getBlockByNumber", block, true); err == nil {
+		caps.FullBlock = true
+	}
+	if err := client.rpcClient.CallContext(ctx, &receipts, "eth_getBlockReceipts", block); err == nil {
+		caps.BlockReceipts = true
+	}
+	if err := client.rpcClient.CallContext(ctx, &traces, "debug_traceBlockByNumber", block, &TracerConfig{Tracer: "callTracer"}); err == nil {
+		caps.TraceBlock = true
+	}
+	// there is no tx to trace for sure, only a node without the method answers with method errors
+	var frame interface{}
+	caps.TraceTx = methodSupported(client.rpcClient.CallContext(ctx, &frame, "debug_traceTransaction", common.Hash{}, &TracerConfig{Tracer: "callTracer"}))
+	log.Infof("capabilities of %s: full block: %t, block receipts: %t, trace block: %t, trace tx: %t", client.wsuri, caps.FullBlock, caps.BlockReceipts, caps.TraceBlock, caps.TraceTx)
+	return caps
+}
+
+// methodSupported tells whether err is not about the method missing or being disabled on the node
+func methodSupported(err error) bool {
+	if err == nil {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(err.Error()), "method")
+}
+
+// probeAll keeps the methods supported by all the clients, the one in use changes with the head
+func probeAll(ctx context.Context, clients []*rpcGroup, number uint64) Capabilities {
+	caps := Capabilities{FullBlock: true, BlockReceipts: true, TraceBlock: true, TraceTx: true}
+	for _, v := range clients {
+		c := probeCapabilities(ctx, v, number)
+		caps.FullBlock = caps.FullBlock && c.FullBlock
+		caps.BlockReceipts = caps.BlockReceipts && c.BlockReceipts
+		caps.TraceBlock = caps.TraceBlock && c.TraceBlock
+		caps.TraceTx = caps.TraceTx && c.TraceTx
+	}
+	return caps
+}
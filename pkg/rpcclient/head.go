@@ -0,0 +1,85 @@
+package rpcclient
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/share"
+)
+
+// head sources of a node
+const (
+	HeadSourceAuto      = "auto"      // subscribe on ws/wss urls, poll on the others
+	HeadSourceSubscribe = "subscribe" // eth_subscribe newHeads
+	HeadSourcePoll      = "poll"      // eth_blockNumber every poll interval
+)
+
+const defaultPollInterval = 3 * time.Second
+
+func usePolling(headSource, uri string) bool {
+	switch headSource {
+	case HeadSourcePoll:
+		return true
+	case HeadSourceSubscribe:
+		return false
+	default:
+		uri = strings.ToLower(uri)
+		return !strings.HasPrefix(uri, "ws://") && !strings.HasPrefix(uri, "wss://")
+	}
+}
+
+func (m *manage) subscribeHead(ctx context.Context, client *rpcGroup, index int) {
+	headerChan := make(chan *ethTypes.Header, share.MaxChanSize)
+	go func() {
+		for {
+			sub, err := client.client.SubscribeNewHead(ctx, headerChan)
+			if err != nil {
+				log.Errorf("subscribe(%s) head failed: %+v", client.wsuri, err)
+				time.Sleep(time.Second * 3)
+				continue
+			}
+			for err = range sub.Err() {
+				log.Errorf("subscribe(%s) err: %+v", client.wsuri, err)
+				time.Sleep(time.Second * 10)
+				break
+			}
+		}
+	}()
+
+	for head := range headerChan {
+		m.updateHead(head.Number.Uint64(), index)
+	}
+}
+
+func (m *manage) pollHead(ctx context.Context, client *rpcGroup, index int) {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+	for {
+		number, err := client.client.BlockNumber(ctx)
+		if err != nil {
+			log.Errorf("poll(%s) head failed: %+v", client.wsuri, err)
+		} else {
+			m.updateHead(number, index)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateHead feeds latestChan when a node is ahead of the latest block seen so far
+func (m *manage) updateHead(number uint64, index int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if number > m.latestBlockNumber {
+		m.latestBlockNumber = number
+		m.latestChan <- m.latestBlockNumber
+		m.index = index
+	}
+}
@@ -3,6 +3,7 @@ package rpcclient
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/uchainorg/uscan/pkg/field"
@@ -14,8 +15,11 @@ type RpcClient interface {
 	GetLatestBlockNumber(ctx context.Context) <-chan uint64
 	GetClient() *ethclient.Client
 	Close()
+	Capabilities() Capabilities
 
 	GetBlockByNumber(ctx context.Context, blockNumber string) (*types.Block, error)
+	GetFullBlockByNumber(ctx context.Context, blockNumber string) (*types.Block, []*types.Tx, error)
+	GetBlockReceipts(ctx context.Context, blockNumber string) ([]*types.Rt, error)
 	GetTransactionsByHash(ctx context.Context, transactionHash []common.Hash) ([]*types.Tx, error)
 	GetTransactionReceiptsByHash(ctx context.Context, transactionHash []common.Hash) ([]*types.Rt, error)
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash) (*types.Tx, error)
@@ -23,6 +27,11 @@ type RpcClient interface {
 	GetCode(ctx context.Context, address common.Address, blockNumber string) (string, error)
 	GetBalance(ctx context.Context, address common.Address, blockNumber string) (*field.BigInt, error)
 	GetBalances(ctx context.Context, addresses []common.Address, blockNumber string) (map[common.Address]*field.BigInt, error)
+	GetStorageAts(ctx context.Context, address common.Address, slots []common.Hash, blockNumber string) ([]common.Hash, error)
+	Call(ctx context.Context, to common.Address, data []byte, blockNumber string) ([]byte, error)
 	GetTracerCall(ctx context.Context, txhash common.Hash) (*types.CallFrame, error)
+	GetTracerCalls(ctx context.Context, blockNumber string) ([]*TxTraceResult, error)
 	GetTracerLog(ctx context.Context, txHash common.Hash) (*types.ExecutionResult, error)
+	SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error)
+	GetPendingTransactions(ctx context.Context) ([]*types.Tx, error)
 }
@@ -0,0 +1,32 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// SubscribePendingTransactions sends the hashes of the txs entering the pool of the node,
+// it fails with rpc.ErrNotificationsUnsupported on http urls
+func (r *manage) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	return r.clients[r.index].rpcClient.EthSubscribe(ctx, ch, "newPendingTransactions")
+}
+
+// GetPendingTransactions returns the executable txs of the pool of the node, the queued ones are left out
+func (r *manage) GetPendingTransactions(ctx context.Context) ([]*types.Tx, error) {
+	var content struct {
+		Pending map[common.Address]map[string]*types.Tx `json:"pending"`
+	}
+	if err := r.clients[r.index].rpcClient.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, err
+	}
+	res := make([]*types.Tx, 0)
+	for _, txs := range content.Pending {
+		for _, tx := range txs {
+			res = append(res, tx)
+		}
+	}
+	return res, nil
+}
@@ -3,9 +3,11 @@ package rpcclient
 import (
 	"context"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -29,9 +31,15 @@ type manage struct {
 	clients           []*rpcGroup
 	latestBlockNumber uint64
 	latestChan        chan uint64
+	headSource        string
+	pollInterval      time.Duration
+	mu                sync.Mutex
+	capabilities      Capabilities
 }
 
-func NewRpcClient(ws []string) *manage {
+// NewRpcClient dials every url, the head of each node is tracked with headSource,
+// see HeadSourceAuto for how it is chosen
+func NewRpcClient(ws []string, headSource string, pollInterval time.Duration) *manage {
 	clients := make([]*rpcGroup, len(ws))
 	for i, v := range ws {
 		rpcClient, err := rpc.Dial(v)
@@ -80,7 +88,15 @@ func NewRpcClient(ws []string) *manage {
 		clients:           clients,
 		latestBlockNumber: lastNumber,
 		latestChan:        make(chan uint64, share.MaxChanSize),
+		headSource:        headSource,
+		pollInterval:      pollInterval,
+		capabilities:      probeAll(context.Background(), clients, lastNumber),
 	}
+	if r.pollInterval <= 0 {
+		r.pollInterval = defaultPollInterval
+	}
+	// sync can start before the next head comes
+	r.latestChan <- lastNumber
 
 	go r.syncerBlock(context.Background())
 	return r
@@ -88,35 +104,12 @@ func NewRpcClient(ws []string) *manage {
 
 func (m *manage) syncerBlock(ctx context.Context) {
 	for i, v := range m.clients {
-		go func(client *rpcGroup, index int) {
-			var (
-				ctx        = context.Background()
-				headerChan = make(chan *ethTypes.Header, share.MaxChanSize)
-			)
-			go func() {
-				for {
-					sub, err := client.client.SubscribeNewHead(ctx, headerChan)
-					if err != nil {
-						log.Errorf("subscribe(%s) head failed: %+v", client.wsuri, err)
-						time.Sleep(time.Second * 3)
-						continue
-					}
-					for err = range sub.Err() {
-						log.Errorf("subscribe(%s) err: %+v", client.wsuri, err)
-						time.Sleep(time.Second * 10)
-						break
-					}
-				}
-			}()
-
-			for head := range headerChan {
-				if head.Number.Uint64() > m.latestBlockNumber {
-					m.latestBlockNumber = head.Number.Uint64()
-					m.latestChan <- m.latestBlockNumber
-					m.index = index
-				}
-			}
-		}(v, i)
+		if usePolling(m.headSource, v.wsuri) {
+			log.Infof("poll head of %s every %s", v.wsuri, m.pollInterval)
+			go m.pollHead(ctx, v, i)
+		} else {
+			go m.subscribeHead(ctx, v, i)
+		}
 	}
 }
 
@@ -128,6 +121,10 @@ func (r *manage) GetLatestBlockNumber(ctx context.Context) <-chan uint64 {
 	return r.latestChan
 }
 
+func (r *manage) Capabilities() Capabilities {
+	return r.capabilities
+}
+
 func (r *manage) GetBlockByNumber(ctx context.Context, blockNumber string) (*types.Block, error) {
 	result := &types.Block{}
 	err := r.clients[r.index].rpcClient.CallContext(ctx, result, "eth_getBlockByNumber", blockNumber, false)
@@ -138,6 +135,33 @@ func (r *manage) GetBlockByNumber(ctx context.Context, blockNumber string) (*typ
 	return result, nil
 }
 
+// GetFullBlockByNumber returns the block with its transaction objects, Transactions of the block are filled with their hashes
+func (r *manage) GetFullBlockByNumber(ctx context.Context, blockNumber string) (*types.Block, []*types.Tx, error) {
+	result := &fullBlock{}
+	err := r.clients[r.index].rpcClient.CallContext(ctx, result, "eth_getBlockByNumber", blockNumber, true)
+	if err != nil {
+		log.Errorf("eth_getBlockByNumber err: %v; endpoint: %s", err, r.clients[r.index].wsuri)
+		return nil, nil, err
+	}
+	result.Block.Transactions = make([]common.Hash, len(result.Transactions))
+	for i, v := range result.Transactions {
+		result.Block.Transactions[i] = v.Hash
+	}
+	return &result.Block, result.Transactions, nil
+}
+
+func (r *manage) GetBlockReceipts(ctx context.Context, blockNumber string) ([]*types.Rt, error) {
+	var result []*types.Rt
+	newCtx, cancel := context.WithTimeout(ctx, share.HttpTimeout)
+	defer cancel()
+	err := r.clients[r.index].rpcClient.CallContext(newCtx, &result, "eth_getBlockReceipts", blockNumber)
+	if err != nil {
+		log.Errorf("eth_getBlockReceipts err: %v; endpoint: %s", err, r.clients[r.index].wsuri)
+		return nil, err
+	}
+	return result, nil
+}
+
 func (r *manage) GetTransactionsByHash(ctx context.Context, transactionHash []common.Hash) ([]*types.Tx, error) {
 	result := make([]*types.Tx, 0, len(transactionHash))
 	elem := make([]rpc.BatchElem, 0, len(transactionHash))
@@ -199,7 +223,7 @@ func (r *manage) GetTransactionReceiptsByHash(ctx context.Context, transactionHa
 
 func (r *manage) GetTransactionByHash(ctx context.Context, transactionHash common.Hash) (res *types.Tx, err error) {
 	res = &types.Tx{}
-	err = r.clients[r.index].rpcClient.Call(res, "eth_getTransactionByHash", transactionHash.Hex())
+	err = r.clients[r.index].rpcClient.CallContext(ctx, res, "eth_getTransactionByHash", transactionHash.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +232,7 @@ func (r *manage) GetTransactionByHash(ctx context.Context, transactionHash commo
 
 func (r *manage) GetTransactionReceiptByHash(ctx context.Context, transactionHash common.Hash) (res *types.Rt, err error) {
 	res = &types.Rt{}
-	err = r.clients[r.index].rpcClient.Call(res, "eth_getTransactionReceipt", transactionHash.Hex())
+	err = r.clients[r.index].rpcClient.CallContext(ctx, res, "eth_getTransactionReceipt", transactionHash.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +288,49 @@ func (r *manage) GetBalances(ctx context.Context, addresses []common.Address, bl
 	return result, nil
 }
 
+// GetStorageAts reads storage slots of an address at a block in one batch
+func (r *manage) GetStorageAts(ctx context.Context, address common.Address, slots []common.Hash, blockNumber string) ([]common.Hash, error) {
+	result := make([]common.Hash, len(slots))
+	elem := make([]rpc.BatchElem, 0, len(slots))
+	for i, v := range slots {
+		elem = append(elem, rpc.BatchElem{
+			Method: "eth_getStorageAt",
+			Args:   []interface{}{address, v, blockNumber},
+			Result: &result[i],
+		})
+	}
+	newCtx, cancel := context.WithTimeout(ctx, share.HttpTimeout)
+	defer cancel()
+	err := r.clients[r.index].rpcClient.BatchCallContext(newCtx, elem)
+	if err != nil {
+		log.Errorf("eth_getStorageAt err: %+v; endpoint: %s", err, r.clients[r.index].wsuri)
+		return nil, err
+	}
+
+	for _, v := range elem {
+		if v.Error != nil {
+			log.Errorf("eth_getStorageAt failed: %+v; args: %s", v.Error, v.Args)
+			return nil, v.Error
+		}
+	}
+
+	return result, nil
+}
+
+func (r *manage) Call(ctx context.Context, to common.Address, data []byte, blockNumber string) ([]byte, error) {
+	var res hexutil.Bytes
+	msg := map[string]interface{}{
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	}
+	err := r.clients[r.index].rpcClient.CallContext(ctx, &res, "eth_call", msg, blockNumber)
+	if err != nil {
+		log.Errorf("eth_call err: %+v; endpoint: %s", err, r.clients[r.index].wsuri)
+		return nil, err
+	}
+	return res, nil
+}
+
 func (r *manage) Close() {
 	for _, v := range r.clients {
 		v.client.Close()
@@ -284,6 +351,18 @@ func (r *manage) GetTracerCall(ctx context.Context, txhash common.Hash) (*types.
 	return &res, nil
 }
 
+func (r *manage) GetTracerCalls(ctx context.Context, blockNumber string) ([]*TxTraceResult, error) {
+	var result []*TxTraceResult
+	newCtx, cancel := context.WithTimeout(ctx, share.HttpTimeout)
+	defer cancel()
+	err := r.clients[r.index].rpcClient.CallContext(newCtx, &result, "debug_traceBlockByNumber", blockNumber, &TracerConfig{Tracer: "callTracer"})
+	if err != nil {
+		log.Errorf("debug_traceBlockByNumber err: %v; endpoint: %s", err, r.clients[r.index].wsuri)
+		return nil, err
+	}
+	return result, nil
+}
+
 func (r *manage) GetTracerLog(ctx context.Context, txHash common.Hash) (*types.ExecutionResult, error) {
 	var err error
 	var res = types.ExecutionResult{}
@@ -3,6 +3,7 @@ package rpcclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -17,7 +18,7 @@ var testClient RpcClient
 
 func TestMain(m *testing.M) {
 	// testClient = NewRpcClient("ws://103.23.44.29:28546")
-	testClient = NewRpcClient([]string{"wss://testnet.ankr.com/ws"})
+	testClient = NewRpcClient([]string{"wss://testnet.ankr.com/ws"}, HeadSourceAuto, 0)
 
 	m.Run()
 }
@@ -116,3 +117,28 @@ func TestGetTracerLog(t *testing.T) {
 	assert.NoError(t, err)
 	t.Log(cf.JsonToString())
 }
+
+func TestUsePolling(t *testing.T) {
+	assert.True(t, usePolling(HeadSourceAuto, "https://rpc.example.com"))
+	assert.True(t, usePolling(HeadSourceAuto, "http://127.0.0.1:8545"))
+	assert.False(t, usePolling(HeadSourceAuto, "wss://rpc.example.com/ws"))
+	assert.False(t, usePolling("", "WS://127.0.0.1:8546"))
+	assert.True(t, usePolling(HeadSourcePoll, "ws://127.0.0.1:8546"))
+	assert.False(t, usePolling(HeadSourceSubscribe, "https://rpc.example.com"))
+}
+
+func TestUnmarshalFullBlock(t *testing.T) {
+	bin := []byte(`{"number":"0x10","hash":"0x9aaa0c4a421d8cd3e52765475acccb23a6dd388d0be384b00bb73fc7e8db796d","transactions":[{"hash":"0x8c1420f491679d36cb09912774e6a47fa6bfbd8bb225a33e151d0e1522e7b5a2","blockNumber":"0x10","input":"0xa9059cbb"}]}`)
+	var full fullBlock
+	assert.NoError(t, json.Unmarshal(bin, &full))
+	assert.Equal(t, uint64(16), full.Number.ToUint64())
+	assert.Len(t, full.Transactions, 1)
+	assert.Equal(t, common.HexToHash("0x8c1420f491679d36cb09912774e6a47fa6bfbd8bb225a33e151d0e1522e7b5a2"), full.Transactions[0].Hash)
+	assert.Nil(t, full.Block.Transactions)
+}
+
+func TestMethodSupported(t *testing.T) {
+	assert.True(t, methodSupported(nil))
+	assert.True(t, methodSupported(errors.New("transaction 0x0000000000000000000000000000000000000000000000000000000000000000 not found")))
+	assert.False(t, methodSupported(errors.New("the method debug_traceTransaction does not exist/is not available")))
+}
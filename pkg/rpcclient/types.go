@@ -1,5 +1,10 @@
 package rpcclient
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
 // Config are the configuration options for structured logger the EVM
 type TracerConfig struct {
 	EnableMemory     bool   `json:"enableMemory"`     // enable memory capture
@@ -9,3 +14,23 @@ type TracerConfig struct {
 	Tracer           string `json:"tracer,omitempty"` //
 	Timeout          string `json:"timeout,omitempty"`
 }
+
+// Capabilities are the bulk methods supported by every node, they are probed at startup
+type Capabilities struct {
+	FullBlock     bool // eth_getBlockByNumber with full transaction objects
+	BlockReceipts bool // eth_getBlockReceipts
+	TraceBlock    bool // debug_traceBlockByNumber with callTracer
+	TraceTx       bool // debug_traceTransaction with callTracer
+}
+
+// TxTraceResult is an item of debug_traceBlockByNumber
+type TxTraceResult struct {
+	TxHash common.Hash      `json:"txHash"`
+	Result *types.CallFrame `json:"result,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+type fullBlock struct {
+	types.Block
+	Transactions []*types.Tx `json:"transactions"`
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	DecodedByABI       = "abi"       // the verified abi of the contract or of its implementation
+	DecodedBySignature = "signature" // a text signature of the signature database
+)
+
+// contractABI returns the abi of a verified contract, nil when it is not verified
+func contractABI(address common.Address) (*abi.ABI, error) {
+	contract, err := store.GetValidateContract(address)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if contract.ABI == "" {
+		return nil, nil
+	}
+	parsed, err := abi.JSON(strings.NewReader(contract.ABI))
+	if err != nil {
+		return nil, nil
+	}
+	return &parsed, nil
+}
+
+// contractABIs returns the verified abis of a contract and, when it is a proxy, of its implementation
+func contractABIs(address common.Address) ([]*abi.ABI, error) {
+	abis := make([]*abi.ABI, 0, 2)
+	own, err := contractABI(address)
+	if err != nil {
+		return nil, err
+	}
+	if own != nil {
+		abis = append(abis, own)
+	}
+	logic, err := store.GetProxyContract(address)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return abis, nil
+		}
+		return nil, err
+	}
+	if logic == (common.Address{}) || logic == address {
+		return abis, nil
+	}
+	impl, err := contractABI(logic)
+	if err != nil {
+		return nil, err
+	}
+	if impl != nil {
+		abis = append(abis, impl)
+	}
+	return abis, nil
+}
+
+// abiCache keeps the abis read while a response is built, contracts emit several logs
+type abiCache map[common.Address][]*abi.ABI
+
+func (c abiCache) get(address common.Address) ([]*abi.ABI, error) {
+	if abis, ok := c[address]; ok {
+		return abis, nil
+	}
+	abis, err := contractABIs(address)
+	if err != nil {
+		return nil, err
+	}
+	c[address] = abis
+	return abis, nil
+}
+
+// decodeInput decodes the input of a call with the methods of the abis, or else with the
+// text signatures of its method id
+func decodeInput(abis []*abi.ABI, input []byte) (*types.DecodedInputResp, error) {
+	if len(input) < 4 {
+		return nil, nil
+	}
+	for _, contractAbi := range abis {
+		method, err := contractAbi.MethodById(input[:4])
+		if err != nil {
+			continue
+		}
+		params, ok := decodeArguments(method.Inputs, input[4:])
+		if !ok {
+			continue
+		}
+		return &types.DecodedInputResp{
+			Name:      method.Name,
+			Signature: method.Sig,
+			Params:    params,
+			Source:    DecodedByABI,
+		}, nil
+	}
+
+	signatures, err := functionSignatures(input[:4])
+	if err != nil {
+		return nil, err
+	}
+	for _, signature := range signatures {
+		name, args, err := signatureArguments(signature)
+		if err != nil {
+			continue
+		}
+		params, ok := decodeArguments(args, input[4:])
+		if !ok {
+			continue
+		}
+		return &types.DecodedInputResp{
+			Name:      name,
+			Signature: signature,
+			Params:    params,
+			Source:    DecodedBySignature,
+		}, nil
+	}
+	return nil, nil
+}
+
+// functionSignatures returns the method name of the verified contracts first, then the imported
+// signatures of the selector
+func functionSignatures(selector []byte) ([]string, error) {
+	signatures := make([]string, 0)
+	name, err := store.GetMethodName(hex.EncodeToString(selector))
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	if name != "" {
+		signatures = append(signatures, name)
+	}
+	imported, err := store.GetFunctionSignatures(selector)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	for _, signature := range imported {
+		if signature != name {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures, nil
+}
+
+// decodeArguments unpacks data, it only matches when the values pack back into the same data
+// so that a signature of the same id with other types is not taken for it
+func decodeArguments(args abi.Arguments, data []byte) ([]*types.DecodedParamResp, bool) {
+	values, err := args.UnpackValues(data)
+	if err != nil {
+		return nil, false
+	}
+	packed, err := args.Pack(values...)
+	if err != nil || !bytes.Equal(packed, data) {
+		return nil, false
+	}
+	params := make([]*types.DecodedParamResp, 0, len(args))
+	for i, arg := range args {
+		params = append(params, &types.DecodedParamResp{
+			Name:  arg.Name,
+			Type:  arg.Type.String(),
+			Value: formatAbiValue(values[i]),
+		})
+	}
+	return params, true
+}
+
+// signatureArguments parses a text signature like transfer(address,uint256), tuples are not supported
+func signatureArguments(signature string) (string, abi.Arguments, error) {
+	open := strings.IndexByte(signature, '(')
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature: %s", signature)
+	}
+	inner := signature[open+1 : len(signature)-1]
+	if strings.ContainsAny(inner, "()") {
+		return "", nil, fmt.Errorf("unsupported signature: %s", signature)
+	}
+	args := make(abi.Arguments, 0)
+	if inner == "" {
+		return signature[:open], args, nil
+	}
+	for _, typ := range strings.Split(inner, ",") {
+		t, err := abi.NewType(strings.TrimSpace(typ), "", nil)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, abi.Argument{Type: t})
+	}
+	return signature[:open], args, nil
+}
+
+// decodeEvent decodes a log with the events of the abis, or else with the imported text
+// signatures of its topic, nil when none of them matches it
+func decodeEvent(abis []*abi.ABI, l *types.Log) (*types.DecodedEventResp, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	for _, contractAbi := range abis {
+		event, err := contractAbi.EventByID(l.Topics[0])
+		if err != nil {
+			continue
+		}
+		if params, ok := decodeEventArguments(event.Inputs, l); ok {
+			return &types.DecodedEventResp{
+				Name:      event.Name,
+				Signature: event.Sig,
+				Params:    params,
+				Source:    DecodedByABI,
+			}, nil
+		}
+	}
+
+	signatures, err := store.GetEventSignatures(l.Topics[0])
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, signature := range signatures {
+		name, args, err := signatureArguments(signature)
+		if err != nil || len(args) < len(l.Topics)-1 {
+			continue
+		}
+		// a text signature does not tell which arguments are indexed, they are taken to be the
+		// first ones as most events declare them
+		for i := 0; i < len(l.Topics)-1; i++ {
+			args[i].Indexed = true
+		}
+		if _, ok := decodeArguments(args.NonIndexed(), l.Data); !ok {
+			continue
+		}
+		if params, ok := decodeEventArguments(args, l); ok {
+			return &types.DecodedEventResp{
+				Name:      name,
+				Signature: signature,
+				Params:    params,
+				Source:    DecodedBySignature,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func decodeEventArguments(args abi.Arguments, l *types.Log) ([]*types.DecodedParamResp, bool) {
+	values, err := args.UnpackValues(l.Data)
+	if err != nil {
+		return nil, false
+	}
+	params := make([]*types.DecodedParamResp, 0, len(args))
+	topics := l.Topics[1:]
+	for _, arg := range args {
+		param := &types.DecodedParamResp{
+			Name:    arg.Name,
+			Type:    arg.Type.String(),
+			Indexed: arg.Indexed,
+		}
+		if arg.Indexed {
+			if len(topics) == 0 {
+				return nil, false
+			}
+			param.Value = decodeTopic(arg.Type, topics[0])
+			topics = topics[1:]
+		} else {
+			if len(values) == 0 {
+				return nil, false
+			}
+			param.Value = formatAbiValue(values[0])
+			values = values[1:]
+		}
+		params = append(params, param)
+	}
+	return params, len(topics) == 0
+}
+
+// decodeTopic decodes an indexed value, the topic of a dynamic type is the hash of the value
+func decodeTopic(typ abi.Type, topic common.Hash) string {
+	switch typ.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic.Hex()
+	}
+	values, err := abi.Arguments{{Type: typ}}.UnpackValues(topic.Bytes())
+	if err != nil || len(values) != 1 {
+		return topic.Hex()
+	}
+	return formatAbiValue(values[0])
+}
+
+// formatAbiValue renders an unpacked value, numbers in decimal and bytes in hex
+func formatAbiValue(v interface{}) string {
+	switch t := v.(type) {
+	case common.Address:
+		return t.Hex()
+	case *big.Int:
+		return t.String()
+	case []byte:
+		return hexutil.Encode(t)
+	case string:
+		return t
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	if bin, err := json.Marshal(v); err == nil {
+		return string(bin)
+	}
+	return fmt.Sprint(v)
+}
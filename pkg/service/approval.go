@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	ApprovalRoleOwner   = "owner"
+	ApprovalRoleSpender = "spender"
+)
+
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// ListAccountApprovals returns the active approvals given by an owner or to a spender, ordered by token.
+// Approvals are indexed once their block is out of the fork window, up to indexedBlock.
+func ListAccountApprovals(pager *types.Pager, req *types.ApprovalQuery, address common.Address) (map[string]interface{}, error) {
+	var (
+		total     uint64
+		approvals []*types.Approval
+	)
+	indexed, err := indexedBlock()
+	if err != nil {
+		return nil, err
+	}
+	switch req.Role {
+	case "", ApprovalRoleOwner:
+		if total, err = store.GetOwnerApprovalCount(address); err != nil && !errors.Is(err, kv.NotFound) {
+			return nil, err
+		}
+		approvals, err = store.ListOwnerApprovals(address, pager.Offset, pager.Limit)
+	case ApprovalRoleSpender:
+		if total, err = store.GetSpenderApprovalCount(address); err != nil && !errors.Is(err, kv.NotFound) {
+			return nil, err
+		}
+		approvals, err = store.ListSpenderApprovals(address, pager.Offset, pager.Limit)
+	default:
+		return nil, response.ErrInvalidParameter
+	}
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+
+	tokens := make(map[common.Address]*types.Account)
+	items := make([]*types.ApprovalResp, 0, len(approvals))
+	for _, v := range approvals {
+		token, ok := tokens[v.Token]
+		if !ok {
+			if token, err = store.GetAccount(v.Token); err != nil {
+				if !errors.Is(err, kv.NotFound) {
+					return nil, err
+				}
+				token = &types.Account{}
+			}
+			tokens[v.Token] = token
+		}
+		items = append(items, approvalResp(v, token))
+	}
+	return map[string]interface{}{
+		"items":        items,
+		"total":        total,
+		"indexedBlock": indexed,
+	}, nil
+}
+
+func approvalResp(v *types.Approval, token *types.Account) *types.ApprovalResp {
+	resp := &types.ApprovalResp{
+		Contract:         v.Token.Hex(),
+		ContractName:     token.Name,
+		ContractSymbol:   token.Symbol,
+		ContractDecimals: token.Decimals.ToUint64(),
+		Type:             "approval",
+		Owner:            v.Owner.Hex(),
+		Spender:          v.Spender.Hex(),
+		TransactionHash:  v.TxHash.Hex(),
+		BlockNumber:      v.BlockNumber,
+		TimeStamp:        v.TimeStamp,
+	}
+	switch {
+	case token.Erc20:
+		resp.TokenType = "erc20"
+	case token.Erc721:
+		resp.TokenType = "erc721"
+	case token.Erc1155:
+		resp.TokenType = "erc1155"
+	}
+	if v.ForAll {
+		resp.Type = "approvalForAll"
+	} else {
+		allowance := currentAllowance(v)
+		resp.Allowance = allowance.String()
+		resp.LastApproved = v.Amount.String()
+		resp.Unlimited = allowance.Cmp(maxUint256) == 0
+	}
+	return resp
+}
+
+// currentAllowance reads what is left of an approval, transferFrom spends it without an event.
+// It falls back to the approved amount when the token can not be called.
+func currentAllowance(v *types.Approval) *big.Int {
+	if contractClient != nil {
+		allowance, err := contractClient.GetErc20Allowance(v.Token, v.Owner, v.Spender)
+		if err == nil {
+			return allowance
+		}
+		log.Errorf("read allowance of %s, owner: %s, spender: %s: %v", v.Token.Hex(), v.Owner.Hex(), v.Spender.Hex(), err)
+	}
+	return (*big.Int)(&v.Amount)
+}
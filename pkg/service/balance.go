@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// GetAccountBalanceAt returns the balance of an account at a block, or at the last block
+// mined before a timestamp. Only the blocks out of the fork window have a balance history.
+func GetAccountBalanceAt(address common.Address, req *types.BalanceQuery) (*types.BalanceResp, error) {
+	blockNum, err := resolveBlock(req.Block, req.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	change, err := store.GetBalanceAt(address, blockNum)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, response.ErrRecordNotFind
+		}
+		return nil, err
+	}
+	return &types.BalanceResp{
+		Address:     address.Hex(),
+		BlockNumber: blockNum,
+		Balance:     change.Balance.String(),
+		ChangedAt:   change.BlockNumber,
+	}, nil
+}
+
+func ListAccountBalanceHistory(pager *types.Pager, address common.Address) (map[string]interface{}, error) {
+	total, err := store.GetBalanceChangeCount(address)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	changes, err := store.ListBalanceChanges(address, pager.Offset, pager.Limit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	items := make([]*types.BalanceChangeResp, 0, len(changes))
+	for _, v := range changes {
+		items = append(items, &types.BalanceChangeResp{
+			BlockNumber: v.BlockNumber,
+			TimeStamp:   v.TimeStamp,
+			Balance:     v.Balance.String(),
+		})
+	}
+	return map[string]interface{}{
+		"items": items,
+		"total": total,
+	}, nil
+}
+
+// resolveBlock returns the block at a number or at a time, the last confirmed block when both are 0
+func resolveBlock(blockNum, ts uint64) (uint64, error) {
+	confirmed, err := store.GetConfirmedBlock()
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return 0, response.ErrRecordNotFind
+		}
+		return 0, err
+	}
+	switch {
+	case ts > 0:
+		return blockAtTime(ts, confirmed.ToUint64())
+	case blockNum == 0:
+		return confirmed.ToUint64(), nil
+	case blockNum > confirmed.ToUint64():
+		return 0, response.ErrBlockNotConfirmed
+	}
+	return blockNum, nil
+}
+
+// blockAtTime searches the last block mined at or before ts, between the start block and last
+func blockAtTime(ts uint64, last uint64) (uint64, error) {
+	var first uint64
+	if start, err := store.GetStartBlock(); err == nil {
+		first = start.ToUint64()
+	}
+	timeOf := func(n uint64) (uint64, error) {
+		bk, err := store.GetBlock(field.NewInt(int64(n)))
+		if err != nil {
+			return 0, err
+		}
+		return bk.TimeStamp.ToUint64(), nil
+	}
+
+	t, err := timeOf(first)
+	if err != nil {
+		return 0, err
+	}
+	if t > ts {
+		return 0, response.ErrRecordNotFind
+	}
+	lo, hi := first, last
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if t, err = timeOf(mid); err != nil {
+			return 0, err
+		}
+		if t <= ts {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
@@ -77,9 +77,22 @@ func Home() (map[string]interface{}, error) {
 	resp["metrics"] = GetHomeMetrics(home, dateTxs, totalTxs, t)
 	resp["blocks"] = blocks
 	resp["txs"] = txs
+	resp["indexedRange"] = GetIndexedRange()
 	return resp, nil
 }
 
+// GetIndexedRange returns the blocks which have been indexed, history before `from` is not available
+func GetIndexedRange() map[string]string {
+	indexed := map[string]string{"from": "0x0", "to": "0x0"}
+	if start, err := store.GetStartBlock(); err == nil {
+		indexed["from"] = start.String()
+	}
+	if end, err := store.GetBlockTotal(); err == nil {
+		indexed["to"] = end.String()
+	}
+	return indexed
+}
+
 func GetHomeMetrics(home *types.Home, dateTxs []map[string]string, totalTxs, t uint64) map[string]interface{} {
 	metrics := make(map[string]interface{})
 	metrics["address"] = home.AddressTotal.String()
@@ -161,7 +174,7 @@ func Search(f *types.SearchFilter) (map[string]interface{}, error) {
 		if err != nil && err != kv.NotFound {
 			return nil, err
 		}
-		if transaction != nil {
+		if transaction != nil || (pool != nil && pool.Has(common.HexToHash(f.Keyword))) {
 			resp["type"] = searchTxnHash
 			return resp, nil
 		}
@@ -236,6 +249,11 @@ func GetBlock(blockNum string) (*types.BlockResp, error) {
 		Transactions:      txs,
 		TransactionsTotal: block.TransactionTotal.ToUint64(),
 		//TransactionsRoot:  block,
+		Withdrawals: blockWithdrawals(block),
+	}
+	if block.WithdrawalsRoot != (common.Hash{}) {
+		root := block.WithdrawalsRoot.Hex()
+		resp.WithdrawalsRoot = &root
 	}
 	return resp, nil
 }
@@ -7,15 +7,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sirupsen/logrus"
 	"github.com/uchainorg/uscan/pkg/kv"
 	"github.com/uchainorg/uscan/pkg/log"
 	"github.com/uchainorg/uscan/pkg/response"
 	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/verify"
 	"github.com/xiaobaiskill/solc-go"
 )
 
@@ -103,6 +103,11 @@ func ValidateContract(req *types.ValidateContractReq) (map[string]string, error)
 }
 
 func validateContract(param *types.ContractVerityTmp) error {
+	switch param.CompilerType {
+	case types.VyperSingleFile, types.VyperJson:
+		return validateVyperContract(param)
+	}
+
 	input := &solc.Input{}
 	switch param.CompilerType {
 	case types.SoliditySingleFile:
@@ -142,12 +147,17 @@ func validateContract(param *types.ContractVerityTmp) error {
 		if err := json.Unmarshal([]byte(param.SourceCode), &input); err != nil {
 			return err
 		}
+		// the bytecode outputs carry the link and immutable references
+		input.Settings.OutputSelection = map[string]map[string][]string{
+			"*": {
+				"*": {"abi", "evm.deployedBytecode", "evm.bytecode", "evm.methodIdentifiers", "metadata"},
+			},
+		}
 	}
 
 	filePath := getSolcFile(param.CompilerFileName)
 	log.Infof("getSolcFilePath:%s\n", filePath)
-	newSolc := solc.NewSolc(filePath)
-	out, err := newSolc.Compile(input)
+	out, refs, err := compileSolc(filePath, input)
 	if err != nil {
 		return errors.New(fmt.Sprintf("contract verification failure. error: %s", err))
 	}
@@ -181,68 +191,73 @@ func validateContract(param *types.ContractVerityTmp) error {
 		return err
 	}
 
-	codeHash := ""
-
 	switch param.CompilerType {
 	case types.SoliditySingleFile:
 		metadata[key] = param.SourceCode
-		accountBC := hexutil.Encode(account.ByteCode)
-		log.Infof("solidity-single-file, hexutil.Encode(account.ByteCode): %+v\n", accountBC)
-		decodeObject, err := hexutil.Decode(accountBC[:len(accountBC)-86])
-		if err != nil {
-			return err
-		}
-
-		objectHash := "0x" + object
-		log.Infof("solidity-single-file, objectHash: %+v\n", objectHash)
-		decodeObject2, err := hexutil.Decode(objectHash[:len(objectHash)-86])
-		if err != nil {
-			return err
-		}
-		hashCode := crypto.Keccak256Hash(decodeObject)
-		hashCode2 := crypto.Keccak256Hash(decodeObject2)
-		if hashCode.Hex() == hashCode2.Hex() {
-			codeHash = account.ByteCodeHash.Hex()
-		}
 	case types.SolidityStandardJsonInput:
-		inputTmp := &solc.Input{}
-		if err := json.Unmarshal([]byte(param.SourceCode), &inputTmp); err != nil {
-			return err
-		}
-
 		var inputMetadata solc.Input
 		if err := json.Unmarshal([]byte(v.Metadata), &inputMetadata); err != nil {
 			return err
 		}
-		for k, _ := range inputMetadata.Sources {
-			metadata[k] = inputTmp.Sources[k].Content
-		}
-
-		bytecodeObject, err := hexutil.Decode("0x" + v.EVM.Bytecode.Object)
-		if err != nil {
-			return err
-		}
-
-		deployedBytecodeObject, err := hexutil.Decode("0x" + v.EVM.DeployedBytecode.Object)
-		if err != nil {
-			return err
-		}
-
-		splitOp := deployedBytecodeObject[len(deployedBytecodeObject)-32:]
-		var objectByte []byte
-		res := bytes.Split(bytecodeObject, splitOp)
-		if len(res) == 2 {
-			objectByte = append(res[0], splitOp...)
-		}
-		if crypto.Keccak256Hash(objectByte).Hex() == account.ByteCodeHash.Hex() {
-			codeHash = hexutil.Encode(account.ByteCode)
+		for k := range inputMetadata.Sources {
+			metadata[k] = input.Sources[k].Content
 		}
 		param.Optimization = 0
-		if inputTmp.Settings.Optimizer.Enabled {
+		if input.Settings.Optimizer.Enabled {
 			param.Optimization = 1
 		}
 		param.Runs = uint64(input.Settings.Optimizer.Runs)
 	}
+
+	compiled, err := verify.NewCompiled(v.EVM.Bytecode.Object, v.EVM.DeployedBytecode.Object, refs, key, param.ContractName)
+	if err != nil {
+		return err
+	}
+	codeHash := ""
+	match, err := verify.Verify(compiled, deployedContract(account))
+	if err == nil {
+		codeHash = account.ByteCodeHash.Hex()
+	} else if !errors.Is(err, verify.ErrMismatch) {
+		return err
+	}
+	return writeValidateContract(param, abi, metadata, object, codeHash, match, v.EVM.MethodIdentifiers)
+}
+
+// compileSolc runs solc in standard json mode, its output is also read for the references to be masked
+func compileSolc(bin string, input *solc.Input) (*solc.Output, *verify.Output, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed marshal input: %v", err)
+	}
+	command := exec.Command(bin, "--standard-json")
+	command.Stdin = bytes.NewReader(b)
+	output, err := command.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed output: %v", err)
+	}
+	out, refs := &solc.Output{}, &verify.Output{}
+	if err = json.Unmarshal(output, out); err != nil {
+		return nil, nil, fmt.Errorf("failed unmarshal output: %v", err)
+	}
+	if err = json.Unmarshal(output, refs); err != nil {
+		return nil, nil, fmt.Errorf("failed unmarshal output: %v", err)
+	}
+	return out, refs, nil
+}
+
+// deployedContract is the code of a contract as synced, the creation input is its bytecode followed by the constructor arguments
+func deployedContract(account *types.Contract) *verify.Deployed {
+	creation := make([]byte, 0, len(account.ByteCode)+len(account.ConstructorArguements))
+	creation = append(append(creation, account.ByteCode...), account.ConstructorArguements...)
+	return &verify.Deployed{
+		Creation:             creation,
+		Runtime:              account.DeployedCode,
+		ConstructorArguments: account.ConstructorArguements,
+	}
+}
+
+// writeValidateContract stores a contract whose bytecode matched as a full or partial match, codeHash is empty when it did not
+func writeValidateContract(param *types.ContractVerityTmp, abi []json.RawMessage, metadata map[string]string, object, codeHash, match string, methodIdentifiers map[string]string) error {
 	metadataMarshal, err := json.Marshal(metadata)
 	if err != nil {
 		return err
@@ -254,7 +269,7 @@ func validateContract(param *types.ContractVerityTmp) error {
 	}
 
 	if codeHash != "" {
-		for k, v := range v.EVM.MethodIdentifiers {
+		for k, v := range methodIdentifiers {
 			err := store.WriteMethodName(v, k)
 			if err != nil {
 				return err
@@ -271,6 +286,7 @@ func validateContract(param *types.ContractVerityTmp) error {
 			Metadata:        string(metadataMarshal),
 			Object:          object,
 			CodeHash:        codeHash,
+			Match:           match,
 		}); err != nil {
 			return err
 		}
@@ -348,6 +364,7 @@ func GetValidateContract(address common.Address) (*types.ContractVerityInfoResp,
 			ABI:             contract.ABI,
 			Metadata:        metadata,
 			Object:          contract.Object,
+			Match:           contract.Match,
 		}
 		proxyContractAddress, err := store.GetProxyContract(address)
 		if err != nil && err != kv.NotFound {
@@ -377,9 +394,13 @@ func GetValidateContract(address common.Address) (*types.ContractVerityInfoResp,
 				ABI:             proxyContract.ABI,
 				Metadata:        metadata,
 				Object:          proxyContract.Object,
+				Match:           proxyContract.Match,
 			}
 		}
 	}
+	if resp.Proxy, err = getProxy(address); err != nil {
+		return nil, err
+	}
 
 	return resp, nil
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/verify"
+	"github.com/uchainorg/uscan/pkg/vyper"
+)
+
+// validateVyperContract compiles a vyper-single-file or vyper-json source and verifies it like solidity,
+// the immutables vyper appends to the runtime code, as sized by the code layout, are left out of the comparison
+func validateVyperContract(param *types.ContractVerityTmp) error {
+	input := &vyper.Input{}
+	switch param.CompilerType {
+	case types.VyperSingleFile:
+		// vyper names a contract after its file
+		input.Language = "Vyper"
+		input.Sources = map[string]vyper.Source{
+			param.ContractName + ".vy": {Content: param.SourceCode},
+		}
+		input.Settings.Optimize = vyperOptimize(param.CompilerVersion, param.Optimization == 1)
+	case types.VyperJson:
+		if err := json.Unmarshal([]byte(param.SourceCode), input); err != nil {
+			return err
+		}
+		param.Optimization = 1
+		if v, ok := input.Settings.Optimize.(bool); (ok && !v) || input.Settings.Optimize == "none" {
+			param.Optimization = 0
+		}
+		param.Runs = 0
+	}
+	if param.EVMVersion != "" && param.EVMVersion != "default" {
+		input.Settings.EVMVersion = param.EVMVersion
+	}
+	input.Settings.OutputSelection = map[string][]string{
+		"*": {"abi", "evm.bytecode", "evm.deployedBytecode", "evm.methodIdentifiers", "layout"},
+	}
+
+	bin, err := getVyperFile(param)
+	if err != nil {
+		return err
+	}
+	log.Infof("getVyperFilePath:%s\n", bin)
+	out, err := vyper.NewVyper(bin).Compile(input)
+	if err != nil {
+		return fmt.Errorf("contract verification failure. error: %s", err)
+	}
+	if err = out.Err(); err != nil {
+		return fmt.Errorf("contract verification failure. error: %s", err)
+	}
+
+	v := vyper.Contract{}
+	key := ""
+	for k, contract := range out.Contracts {
+		cc, ok := contract[param.ContractName]
+		if !ok {
+			continue
+		}
+		v = cc
+		key = k
+	}
+	if key == "" {
+		return fmt.Errorf("contract name error. contract name:【%s】", param.ContractName)
+	}
+
+	account, err := store.GetContract(common.HexToAddress(param.Address))
+	if err != nil {
+		return err
+	}
+	compiled, err := verify.NewCompiled(v.EVM.Bytecode.Object, v.EVM.DeployedBytecode.Object, nil, key, param.ContractName)
+	if err != nil {
+		return err
+	}
+	deployed := deployedContract(account)
+	// only the immutables of the code layout may follow the compiled runtime code,
+	// any other length is left to the comparison to report as a mismatch
+	if len(deployed.Runtime) == len(compiled.Runtime)+v.ImmutablesSize() {
+		deployed.Runtime = deployed.Runtime[:len(compiled.Runtime)]
+	}
+	codeHash := ""
+	match, err := verify.Verify(compiled, deployed)
+	if err == nil {
+		codeHash = account.ByteCodeHash.Hex()
+	} else if !errors.Is(err, verify.ErrMismatch) {
+		return err
+	}
+
+	metadata := make(map[string]string, len(input.Sources))
+	for k, source := range input.Sources {
+		metadata[k] = source.Content
+	}
+	// method identifiers are stored without 0x like the ones of solc
+	methodIdentifiers := make(map[string]string, len(v.EVM.MethodIdentifiers))
+	for k, id := range v.EVM.MethodIdentifiers {
+		methodIdentifiers[k] = strings.TrimPrefix(id, "0x")
+	}
+	return writeValidateContract(param, v.ABI, metadata, strings.TrimPrefix(v.EVM.Bytecode.Object, "0x"), codeHash, match, methodIdentifiers)
+}
+
+// getVyperFile returns the vyper binary of the compiler version, it has to be in the compiler list
+func getVyperFile(param *types.ContractVerityTmp) (string, error) {
+	metadata, err := store.GetValidateContractMetadata()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range metadata.CompilerVersions {
+		if v.Name != param.CompilerVersion || v.FileName == "" {
+			continue
+		}
+		if param.CompilerFileName != "" && param.CompilerFileName != v.FileName {
+			continue
+		}
+		return getSolcFile(v.FileName), nil
+	}
+	return "", fmt.Errorf("vyper compiler %s is not in the compiler list", param.CompilerVersion)
+}
+
+// vyperOptimize returns the optimize setting of the version, nil keeps the default of the compiler.
+// Optimizations are turned off with false before vyper 0.3.10 and with "none" since.
+func vyperOptimize(version string, enabled bool) interface{} {
+	if enabled {
+		return nil
+	}
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "+-"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	current := [3]int{}
+	for i := 0; i < len(parts) && i < 3; i++ {
+		current[i], _ = strconv.Atoi(parts[i])
+	}
+	for i, n := range [3]int{0, 3, 10} {
+		if current[i] != n {
+			if current[i] > n {
+				return "none"
+			}
+			return false
+		}
+	}
+	return "none"
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// GetLogs returns the event logs matching an eth_getLogs style filter in block order.
+// Logs are indexed once their block is out of the fork window, indexedBlock is the last
+// block they have been indexed up to.
+func GetLogs(req *types.LogQuery, pager *types.Pager) (map[string]interface{}, error) {
+	filter, err := parseLogFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := indexedBlock()
+	if err != nil {
+		return nil, err
+	}
+	entries, total, err := store.FilterLogs(filter, pager.Offset, pager.Limit)
+	if err != nil {
+		return nil, err
+	}
+	items, err := eventLogs(entries)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"items":        items,
+		"total":        total,
+		"indexedBlock": indexed,
+	}, nil
+}
+
+// ListAccountEvents returns the event logs emitted by a contract, the latest first,
+// up to indexedBlock like GetLogs
+func ListAccountEvents(pager *types.Pager, address common.Address) (map[string]interface{}, error) {
+	indexed, err := indexedBlock()
+	if err != nil {
+		return nil, err
+	}
+	total, err := store.GetAccountLogCount(address)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	entries, err := store.ListAccountLogs(address, pager.Offset, pager.Limit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	items, err := eventLogs(entries)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"items":        items,
+		"total":        total,
+		"indexedBlock": indexed,
+	}, nil
+}
+
+// indexedBlock returns the last block out of the fork window, the blocks of the fork window
+// are left out of the indexes which are only written for the full db
+func indexedBlock() (uint64, error) {
+	confirmed, err := store.GetConfirmedBlock()
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return confirmed.ToUint64(), nil
+}
+
+// eventLogs reads the logs of the entries from their receipts, decoded with the abi of
+// the contract when it has been verified
+func eventLogs(entries []*types.LogEntry) ([]*types.EventLogResp, error) {
+	var (
+		items = make([]*types.EventLogResp, 0, len(entries))
+		rts   = make(map[common.Hash]*types.Rt)
+		abis  = make(abiCache)
+	)
+	for _, e := range entries {
+		rt, ok := rts[e.TxHash]
+		if !ok {
+			var err error
+			if rt, err = store.GetRt(e.TxHash); err != nil {
+				return nil, err
+			}
+			rts[e.TxHash] = rt
+		}
+		for _, l := range rt.Logs {
+			if l.LogIndex.ToUint64() != e.LogIndex {
+				continue
+			}
+			contractAbis, err := abis.get(l.Address)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := decodeEvent(contractAbis, l)
+			if err != nil {
+				return nil, err
+			}
+			topics := make([]string, 0, len(l.Topics))
+			for _, topic := range l.Topics {
+				topics = append(topics, topic.Hex())
+			}
+			items = append(items, &types.EventLogResp{
+				Address:     l.Address.Hex(),
+				Topics:      topics,
+				Data:        l.Data.String(),
+				BlockNumber: e.BlockNumber,
+				TxHash:      e.TxHash.Hex(),
+				LogIndex:    e.LogIndex,
+				Decoded:     decoded,
+			})
+			break
+		}
+	}
+	return items, nil
+}
+
+// parseLogFilter checks the query, an address or a topic is required
+func parseLogFilter(req *types.LogQuery) (*types.LogFilter, error) {
+	filter := &types.LogFilter{
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+	}
+	if filter.ToBlock == 0 {
+		filter.ToBlock = math.MaxUint64
+	}
+	if filter.FromBlock > filter.ToBlock {
+		return nil, response.ErrInvalidParameter
+	}
+	if req.Address != "" {
+		if !common.IsHexAddress(req.Address) {
+			return nil, response.ErrInvalidParameter
+		}
+		address := common.HexToAddress(req.Address)
+		filter.Address = &address
+	}
+	for i, topic := range []string{req.Topic0, req.Topic1, req.Topic2, req.Topic3} {
+		if topic == "" {
+			continue
+		}
+		bin, err := hexutil.Decode(topic)
+		if err != nil || len(bin) != common.HashLength {
+			return nil, response.ErrInvalidParameter
+		}
+		hash := common.BytesToHash(bin)
+		filter.Topics[i] = &hash
+	}
+	if filter.Address == nil && filter.Topics == [4]*common.Hash{} {
+		return nil, response.ErrInvalidParameter
+	}
+	return filter, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/gasoracle"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+var gasOracle *gasoracle.Oracle
+
+// SetGasOracle sets the oracle the gas tracker is served from
+func SetGasOracle(o *gasoracle.Oracle) {
+	gasOracle = o
+}
+
+func GasTracker() (*types.GasTrackerResp, error) {
+	est, err := gasOracle.Estimate()
+	if err != nil {
+		if errors.Is(err, gasoracle.ErrNoBlocks) {
+			return nil, response.ErrRecordNotFind
+		}
+		return nil, err
+	}
+	resp := &types.GasTrackerResp{
+		BlockNumber: est.BlockNumber,
+		BaseFee:     bigString(est.BaseFee),
+		NextBaseFee: bigString(est.NextBaseFee),
+		Slow:        toGasEstimateResp(est.NextBaseFee, est.Slow),
+		Standard:    toGasEstimateResp(est.NextBaseFee, est.Standard),
+		Fast:        toGasEstimateResp(est.NextBaseFee, est.Fast),
+		History:     make([]*types.GasHistoryResp, 0),
+	}
+	for _, v := range gasOracle.History() {
+		resp.History = append(resp.History, &types.GasHistoryResp{
+			BlockNumber:  v.Number,
+			Timestamp:    v.Timestamp,
+			BaseFee:      bigString(v.BaseFee),
+			GasUsedRatio: v.GasUsedRatio,
+			TxCount:      v.TxCount,
+			Slow:         bigString(v.Slow),
+			Standard:     bigString(v.Standard),
+			Fast:         bigString(v.Fast),
+		})
+	}
+	return resp, nil
+}
+
+// toGasEstimateResp is the gas price of a legacy tx and the priority fee of a eip-1559 tx
+func toGasEstimateResp(baseFee, priorityFee *big.Int) *types.GasEstimateResp {
+	return &types.GasEstimateResp{
+		GasPrice:    bigString(new(big.Int).Add(baseFee, priorityFee)),
+		PriorityFee: bigString(priorityFee),
+	}
+}
+
+func bigString(v *big.Int) string {
+	return (*field.BigInt)(v).String()
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/nft"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	// erc1155 holders read for a page of the owners of a token, ownerCount has all of them
+	nftOwnersLimit = 100
+	// a metadata which could not be fetched is fetched again after
+	nftRetryInterval = time.Hour
+	// a refresh within this time of the last fetch returns the cached metadata
+	nftRefreshInterval = time.Minute
+	nftFetchTimeout    = 20 * time.Second
+)
+
+var (
+	contractClient   contract.Contractor
+	metadataResolver *nft.Resolver
+)
+
+// SetContractClient sets the client the token uris are read with
+func SetContractClient(c contract.Contractor) {
+	contractClient = c
+}
+
+// SetMetadataResolver sets the resolver the token metadata is fetched with
+func SetMetadataResolver(r *nft.Resolver) {
+	metadataResolver = r
+}
+
+// GetNft returns the owners, the transfers and the metadata of an erc721 or erc1155 token id
+func GetNft(address common.Address, tokenID string, pager *types.Pager, req *types.NftQuery) (*types.NftResp, error) {
+	tokenId, err := parseNftTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	var ownersAfter *common.Address
+	if req.OwnersAfter != "" {
+		if !common.IsHexAddress(req.OwnersAfter) {
+			return nil, response.ErrInvalidParameter
+		}
+		addr := common.HexToAddress(req.OwnersAfter)
+		ownersAfter = &addr
+	}
+	account, err := nftAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.NftResp{
+		Contract:       address.Hex(),
+		ContractName:   account.Name,
+		ContractSymbol: account.Symbol,
+		TokenID:        (*big.Int)(tokenId).String(),
+		Owners:         make([]*types.HolderResp, 0),
+		Transfers:      make([]*types.NftTransferResp, 0),
+	}
+	if account.Erc721 {
+		resp.TokenType = "erc721"
+		err = erc721Nft(resp, address, tokenId, pager)
+	} else {
+		resp.TokenType = "erc1155"
+		err = erc1155Nft(resp, address, tokenId, pager, ownersAfter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := nftMetadata(address, tokenId, account.Erc1155 && !account.Erc721, false)
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil {
+		resp.Metadata = nftMetadataResp(metadata)
+	}
+	return resp, nil
+}
+
+// RefreshNftMetadata fetches the metadata of a token id again, the token id must have been transferred
+func RefreshNftMetadata(address common.Address, tokenID string) (*types.NftMetadataResp, error) {
+	tokenId, err := parseNftTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := nftAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := nftMetadata(address, tokenId, account.Erc1155 && !account.Erc721, true)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, response.ErrRecordNotFind
+	}
+	return nftMetadataResp(metadata), nil
+}
+
+func parseNftTokenID(tokenID string) (*field.BigInt, error) {
+	id, ok := new(big.Int).SetString(tokenID, 0)
+	if !ok || id.Sign() < 0 || id.BitLen() > 256 {
+		return nil, response.ErrInvalidParameter
+	}
+	return (*field.BigInt)(id), nil
+}
+
+func nftAccount(address common.Address) (*types.Account, error) {
+	account, err := store.GetAccount(address)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, response.ErrRecordNotFind
+		}
+		return nil, err
+	}
+	if !account.Erc721 && !account.Erc1155 {
+		return nil, response.ErrRecordNotFind
+	}
+	return account, nil
+}
+
+func erc721Nft(resp *types.NftResp, address common.Address, tokenId *field.BigInt, pager *types.Pager) error {
+	owner, err := store.GetErc721Owner(address, tokenId)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if err == nil {
+		resp.Owners = append(resp.Owners, &types.HolderResp{Address: owner.Hex(), Quantity: field.NewInt(1).String()})
+		resp.OwnerCount = 1
+	}
+
+	if resp.TransferCount, err = store.GetErc721TokenTransferCount(address, tokenId); err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	txs, err := store.ListErc721TokenTransfers(address, tokenId, pager.Offset, pager.Limit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	for _, tx := range txs {
+		resp.Transfers = append(resp.Transfers, &types.NftTransferResp{
+			TransactionHash: tx.TransactionHash.Hex(),
+			BlockNumber:     tx.BlockNumber.ToUint64(),
+			Method:          hexutil.Bytes(tx.Method).String(),
+			From:            tx.From.Hex(),
+			To:              tx.To.Hex(),
+			Quantity:        field.NewInt(1).String(),
+			TimeStamp:       tx.TimeStamp.ToUint64(),
+		})
+	}
+	return nil
+}
+
+func erc1155Nft(resp *types.NftResp, address common.Address, tokenId *field.BigInt, pager *types.Pager, ownersAfter *common.Address) error {
+	count, err := store.GetErc1155BalanceHolderCount(address, tokenId)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	resp.OwnerCount = count
+
+	// a page of the holders in address order with their current quantities, the balance
+	// histories are only read for snapshots
+	addrs, err := balanceHoldersAfter("erc1155", address, tokenId, ownersAfter, nftOwnersLimit)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		quantity, err := store.GetErc1155HolderQuantity(address, addr, tokenId)
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				continue
+			}
+			return err
+		}
+		if quantity.Cmp(field.NewInt(0)) > 0 {
+			resp.Owners = append(resp.Owners, &types.HolderResp{
+				Address:  addr.Hex(),
+				Quantity: quantity.String(),
+			})
+		}
+	}
+	if len(addrs) == nftOwnersLimit {
+		next := addrs[len(addrs)-1].Hex()
+		resp.OwnersNext = &next
+	}
+
+	if resp.TransferCount, err = store.GetErc1155TokenTransferCount(address, tokenId); err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	txs, err := store.ListErc1155TokenTransfers(address, tokenId, pager.Offset, pager.Limit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	for _, tx := range txs {
+		resp.Transfers = append(resp.Transfers, &types.NftTransferResp{
+			TransactionHash: tx.TransactionHash.Hex(),
+			BlockNumber:     tx.BlockNumber.ToUint64(),
+			Method:          hexutil.Bytes(tx.Method).String(),
+			From:            tx.From.Hex(),
+			To:              tx.To.Hex(),
+			Quantity:        tx.Quantity.String(),
+			TimeStamp:       tx.TimeStamp.ToUint64(),
+		})
+	}
+	return nil
+}
+
+// nftMetadata returns the cached metadata of a token id, it is fetched when it is not cached,
+// when the last fetch failed a while ago or when refresh is set. It is nil for a token id without transfers.
+func nftMetadata(address common.Address, tokenId *field.BigInt, erc1155, refresh bool) (*types.NftMetadata, error) {
+	now := time.Now()
+	cached, err := store.GetNftMetadata(address, tokenId)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		age := now.Sub(time.Unix(int64(cached.UpdatedAt), 0))
+		switch {
+		case refresh && age < nftRefreshInterval:
+			return cached, nil
+		case !refresh && (cached.Error == "" || age < nftRetryInterval):
+			return cached, nil
+		}
+	}
+
+	if cached == nil {
+		// any token id can be asked for, only those which have been transferred are cached
+		indexed, err := nftIndexed(address, tokenId, erc1155)
+		if err != nil || !indexed {
+			return nil, err
+		}
+	}
+
+	metadata := fetchNftMetadata(address, tokenId, erc1155)
+	metadata.UpdatedAt = uint64(now.Unix())
+	if err = store.WriteNftMetadata(address, tokenId, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func nftIndexed(address common.Address, tokenId *field.BigInt, erc1155 bool) (bool, error) {
+	var (
+		total uint64
+		err   error
+	)
+	if erc1155 {
+		total, err = store.GetErc1155TokenTransferCount(address, tokenId)
+	} else {
+		total, err = store.GetErc721TokenTransferCount(address, tokenId)
+	}
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func fetchNftMetadata(address common.Address, tokenId *field.BigInt, erc1155 bool) *types.NftMetadata {
+	var (
+		metadata = &types.NftMetadata{}
+		uri      string
+		err      error
+	)
+	if erc1155 {
+		uri, err = contractClient.GetEIP1155Meta(address.Hex(), hexutil.EncodeBig((*big.Int)(tokenId)))
+		uri = nft.TokenURI(uri, (*big.Int)(tokenId))
+	} else {
+		uri, err = contractClient.GetEIP721Meta(address.Hex(), hexutil.EncodeBig((*big.Int)(tokenId)))
+	}
+	if err != nil {
+		metadata.Error = "read token uri: " + err.Error()
+		return metadata
+	}
+	metadata.TokenURI = uri
+
+	ctx, cancel := context.WithTimeout(context.Background(), nftFetchTimeout)
+	defer cancel()
+	data, err := metadataResolver.Fetch(ctx, uri)
+	if err == nil {
+		_, err = nft.ParseMetadata(data)
+	}
+	if err != nil {
+		metadata.Error = err.Error()
+		return metadata
+	}
+	metadata.Data = data
+	return metadata
+}
+
+func nftMetadataResp(metadata *types.NftMetadata) *types.NftMetadataResp {
+	resp := &types.NftMetadataResp{
+		TokenURI:  metadata.TokenURI,
+		Error:     metadata.Error,
+		UpdatedAt: metadata.UpdatedAt,
+	}
+	if len(metadata.Data) == 0 {
+		return resp
+	}
+	m, err := nft.ParseMetadata(metadata.Data)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.Name = m.Name
+	resp.Description = m.Description
+	resp.Image = m.Image
+	resp.ImageURL = metadataResolver.URL(m.Image)
+	resp.Attributes = m.Attributes
+	resp.Raw = m.Raw
+	return resp
+}
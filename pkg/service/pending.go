@@ -0,0 +1,79 @@
+package service
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/mempool"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// pool is nil when the pending txs are not watched
+var pool *mempool.Pool
+
+// SetMempool sets the pool the pending txs are served from
+func SetMempool(p *mempool.Pool) {
+	pool = p
+}
+
+func ListPendingTxs(pager *types.Pager) map[string]interface{} {
+	items := make([]*types.PendingTxResp, 0)
+	if pool == nil {
+		return map[string]interface{}{"items": items, "total": 0}
+	}
+	for _, e := range pool.List(int(pager.Offset), int(pager.Limit)) {
+		tx := e.Tx
+		resp := &types.PendingTxResp{
+			Hash:                 tx.Hash.Hex(),
+			Method:               tx.Method.String(),
+			From:                 tx.From.Hex(),
+			Gas:                  tx.Gas.String(),
+			GasPrice:             tx.GasPrice.String(),
+			MaxFeePerGas:         tx.GasFeeCap.StringPointer(),
+			MaxPriorityFeePerGas: tx.GasTipCap.StringPointer(),
+			Value:                tx.Value.String(),
+			Nonce:                tx.Nonce.String(),
+			SeenTime:             e.Seen,
+		}
+		if tx.To != nil {
+			to := tx.To.Hex()
+			resp.To = &to
+		}
+		items = append(items, resp)
+	}
+	return map[string]interface{}{"items": items, "total": pool.Len()}
+}
+
+// getPendingTx returns a tx of the pending pool, it has no block nor receipt yet
+func getPendingTx(txHash common.Hash) (*types.TxResp, bool) {
+	if pool == nil {
+		return nil, false
+	}
+	e, ok := pool.Get(txHash)
+	if !ok {
+		return nil, false
+	}
+	tx := e.Tx
+	resp := &types.TxResp{
+		Hash:                 tx.Hash.String(),
+		Method:               tx.Method.String(),
+		From:                 tx.From.Hex(),
+		Gas:                  tx.Gas.String(),
+		GasPrice:             tx.GasPrice.String(),
+		Value:                tx.Value.String(),
+		MaxFeePerGas:         tx.GasFeeCap.StringPointer(),
+		MaxPriorityFeePerGas: tx.GasTipCap.StringPointer(),
+		Input:                tx.Data.String(),
+		Nonce:                tx.Nonce.String(),
+		V:                    tx.V.String(),
+		R:                    tx.R.String(),
+		S:                    tx.S.String(),
+		CreatedTime:          e.Seen,
+		Pending:              true,
+		Logs:                 make([]*types.RtLogResp, 0),
+		TokensTransferred:    make([]*types.EventTransferData, 0),
+	}
+	if tx.To != nil {
+		resp.To = tx.To.Hex()
+	}
+	resp.Status = 3
+	return resp, true
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// implementations returned with a proxy, the latest first
+const proxyHistoryLimit = 100
+
+// getProxy returns the standard proxy read at address with its implementation history,
+// nil when address has not been detected as a proxy
+func getProxy(address common.Address) (*types.ProxyResp, error) {
+	proxy, err := store.GetProxy(address)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resp := &types.ProxyResp{
+		Type:                  proxy.Kind,
+		Implementation:        proxy.Implementation.Hex(),
+		BlockNumber:           proxy.BlockNumber,
+		ImplementationHistory: make([]*types.ProxyUpgradeResp, 0),
+	}
+	if proxy.Admin != (common.Address{}) {
+		admin := proxy.Admin.Hex()
+		resp.Admin = &admin
+	}
+	if proxy.Beacon != (common.Address{}) {
+		beacon := proxy.Beacon.Hex()
+		resp.Beacon = &beacon
+	}
+
+	upgrades, err := store.ListProxyUpgrades(address, 0, proxyHistoryLimit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	for _, u := range upgrades {
+		item := &types.ProxyUpgradeResp{
+			Implementation: u.Implementation.Hex(),
+			BlockNumber:    u.BlockNumber,
+		}
+		if u.TxHash != (common.Hash{}) {
+			txHash := u.TxHash.Hex()
+			item.TransactionHash = &txHash
+		}
+		resp.ImplementationHistory = append(resp.ImplementationHistory, item)
+	}
+	return resp, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"os"
+
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/signature"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// ImportSignatures imports a function or event signature list from a local file
+func ImportSignatures(req *types.SignatureImportReq) (*types.SignatureImportResp, error) {
+	if (req.Type != signature.KindFunction && req.Type != signature.KindEvent) || req.Path == "" {
+		return nil, response.ErrInvalidParameter
+	}
+	f, err := os.Open(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	count, err := store.ImportSignatures(req.Type, f)
+	if err != nil {
+		return nil, err
+	}
+	return &types.SignatureImportResp{
+		Type:  req.Type,
+		Path:  req.Path,
+		Count: count,
+	}, nil
+}
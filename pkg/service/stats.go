@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	dateLayout = "20060102"
+
+	defaultStatsDays = 14
+	maxStatsDays     = 366
+)
+
+var dailyMetrics = map[string]func(*types.DailyStatsResp) interface{}{
+	"blockCount":       func(r *types.DailyStatsResp) interface{} { return r.BlockCount },
+	"txCount":          func(r *types.DailyStatsResp) interface{} { return r.TxCount },
+	"gasUsed":          func(r *types.DailyStatsResp) interface{} { return r.GasUsed },
+	"avgGasPrice":      func(r *types.DailyStatsResp) interface{} { return r.AvgGasPrice },
+	"baseFeeBurned":    func(r *types.DailyStatsResp) interface{} { return r.BaseFeeBurned },
+	"activeAddresses":  func(r *types.DailyStatsResp) interface{} { return r.ActiveAddresses },
+	"newAddresses":     func(r *types.DailyStatsResp) interface{} { return r.NewAddresses },
+	"newContracts":     func(r *types.DailyStatsResp) interface{} { return r.NewContracts },
+	"erc20Transfers":   func(r *types.DailyStatsResp) interface{} { return r.Erc20Transfers },
+	"erc721Transfers":  func(r *types.DailyStatsResp) interface{} { return r.Erc721Transfers },
+	"erc1155Transfers": func(r *types.DailyStatsResp) interface{} { return r.Erc1155Transfers },
+	"avgBlockTime":     func(r *types.DailyStatsResp) interface{} { return r.AvgBlockTime },
+}
+
+// ListDailyStats returns the stats of every day from req.From to req.To, the last two weeks by default.
+// Days without blocks are returned with zero values so that charts have no gaps.
+func ListDailyStats(req *types.DailyStatsQuery) ([]interface{}, error) {
+	metric, ok := dailyMetrics[req.Metric]
+	if req.Metric != "" && !ok {
+		return nil, response.ErrInvalidParameter
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.To != "" {
+		t, err := time.Parse(dateLayout, req.To)
+		if err != nil {
+			return nil, response.ErrInvalidParameter
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, 1-defaultStatsDays)
+	if req.From != "" {
+		t, err := time.Parse(dateLayout, req.From)
+		if err != nil {
+			return nil, response.ErrInvalidParameter
+		}
+		from = t
+	}
+	if from.After(to) || to.Sub(from) >= maxStatsDays*24*time.Hour {
+		return nil, response.ErrInvalidParameter
+	}
+
+	items := make([]interface{}, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		stats, err := store.GetDailyStats(date)
+		if err != nil {
+			if !errors.Is(err, kv.NotFound) {
+				return nil, err
+			}
+			stats = &types.DailyStats{Date: date}
+		}
+		resp := toDailyStatsResp(stats)
+		if metric != nil {
+			items = append(items, &types.DailyMetricResp{Date: date, Value: metric(resp)})
+		} else {
+			items = append(items, resp)
+		}
+	}
+	return items, nil
+}
+
+func toDailyStatsResp(stats *types.DailyStats) *types.DailyStatsResp {
+	return &types.DailyStatsResp{
+		Date:             stats.Date,
+		BlockCount:       stats.BlockCount,
+		TxCount:          stats.TxCount,
+		GasUsed:          stats.GasUsed.String(),
+		AvgGasPrice:      stats.AvgGasPrice().String(),
+		BaseFeeBurned:    stats.BaseFeeBurned.String(),
+		ActiveAddresses:  stats.ActiveAddresses,
+		NewAddresses:     stats.NewAddresses,
+		NewContracts:     stats.NewContracts,
+		Erc20Transfers:   stats.Erc20Transfers,
+		Erc721Transfers:  stats.Erc721Transfers,
+		Erc1155Transfers: stats.Erc1155Transfers,
+		AvgBlockTime:     stats.AvgBlockTime(),
+	}
+}
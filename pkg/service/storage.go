@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/uchainorg/uscan/pkg/field"
@@ -30,6 +31,9 @@ type Storage interface {
 	ListBlockTxs(total, blockNum *field.BigInt, offset, limit int64) ([]*types.Tx, error)
 	ListBlocks(total *field.BigInt, offset, limit int64) ([]*types.Block, error)
 	GetBlockTotal() (bk *field.BigInt, err error)
+	GetStartBlock() (bk *field.BigInt, err error)
+	GetConfirmedBlock() (bk *field.BigInt, err error)
+	GetDailyStats(date string) (*types.DailyStats, error)
 
 	GetAccount(address common.Address) (acc *types.Account, err error)
 	GetContract(address common.Address) (*types.Contract, error)
@@ -38,6 +42,31 @@ type Storage interface {
 	GetAccountErc721Total(address common.Address) (total *field.BigInt, err error)
 	GetAccountErc1155Total(address common.Address) (total *field.BigInt, err error)
 	GetAccountITxTotal(address common.Address) (total *field.BigInt, err error)
+	GetBalanceAt(address common.Address, blockNum uint64) (*types.BalanceChange, error)
+	ListBalanceChanges(address common.Address, offset, limit int64) ([]*types.BalanceChange, error)
+	GetBalanceChangeCount(address common.Address) (uint64, error)
+	GetErc20BalanceAt(contract, address common.Address, blockNum uint64) (*types.BalanceChange, error)
+	GetErc1155BalanceAt(contract common.Address, tokenId *field.BigInt, address common.Address, blockNum uint64) (*types.BalanceChange, error)
+	ListErc20BalanceHolders(contract, from common.Address, limit int64) ([]common.Address, error)
+	ListErc1155BalanceHolders(contract common.Address, tokenId *field.BigInt, from common.Address, limit int64) ([]common.Address, error)
+	GetErc1155BalanceHolderCount(contract common.Address, tokenId *field.BigInt) (uint64, error)
+	GetErc1155HolderQuantity(contract, address common.Address, tokenId *field.BigInt) (*field.BigInt, error)
+	ListAccountWithdrawals(address common.Address, offset, limit int64) ([]*types.AccountWithdrawal, error)
+	GetAccountWithdrawalCount(address common.Address) (uint64, error)
+	FilterLogs(filter *types.LogFilter, offset, limit int64) ([]*types.LogEntry, uint64, error)
+	ListAccountLogs(address common.Address, offset, limit int64) ([]*types.LogEntry, error)
+	GetAccountLogCount(address common.Address) (uint64, error)
+	ListErc721TokenTransfers(contract common.Address, tokenId *field.BigInt, offset, limit int64) ([]*types.Erc721Transfer, error)
+	GetErc721TokenTransferCount(contract common.Address, tokenId *field.BigInt) (uint64, error)
+	ListErc1155TokenTransfers(contract common.Address, tokenId *field.BigInt, offset, limit int64) ([]*types.Erc1155Transfer, error)
+	GetErc1155TokenTransferCount(contract common.Address, tokenId *field.BigInt) (uint64, error)
+	GetErc721Owner(contract common.Address, tokenId *field.BigInt) (common.Address, error)
+	GetNftMetadata(contract common.Address, tokenId *field.BigInt) (*types.NftMetadata, error)
+	WriteNftMetadata(contract common.Address, tokenId *field.BigInt, metadata *types.NftMetadata) error
+	ListOwnerApprovals(address common.Address, offset, limit int64) ([]*types.Approval, error)
+	GetOwnerApprovalCount(address common.Address) (uint64, error)
+	ListSpenderApprovals(address common.Address, offset, limit int64) ([]*types.Approval, error)
+	GetSpenderApprovalCount(address common.Address) (uint64, error)
 
 	ListAccountTxs(address common.Address, total *field.BigInt, offset, limit int64) ([]*types.Tx, error)
 	ListAccountITxs(address common.Address, total *field.BigInt, offset, limit int64) ([]*types.InternalTx, error)
@@ -67,6 +96,9 @@ type Storage interface {
 	GetErc1155HolderCount(address common.Address) (count uint64, err error)
 
 	GetMethodName(methodID string) (string, error)
+	GetFunctionSignatures(selector []byte) ([]string, error)
+	GetEventSignatures(topic common.Hash) ([]string, error)
+	ImportSignatures(kind string, r io.Reader) (int, error)
 
 	ListErc721Inventories(address common.Address, offset, limit int64) ([]*types.Inventory, error)
 	GetErc721InventoryCount(address common.Address) (count uint64, err error)
@@ -81,6 +113,8 @@ type Storage interface {
 	WriteMethodName(id, name string) error
 	WriteValidateContract(address common.Address, data *types.ContractVerity) error
 	GetProxyContract(address common.Address) (logic common.Address, err error)
+	GetProxy(address common.Address) (*types.Proxy, error)
+	ListProxyUpgrades(address common.Address, offset, limit int64) ([]*types.ProxyUpgrade, error)
 
 	GetErc20ContractTransfer(contract common.Address, offset, limit int64) (data []*types.Erc20Transfer, total *field.BigInt, err error)
 	GetErc721ContractTransfer(contract common.Address, offset, limit int64) (data []*types.Erc721Transfer, total *field.BigInt, err error)
@@ -143,6 +177,142 @@ func (s *Store) GetBlockTotal() (bk *field.BigInt, err error) {
 	return s.St.ReadSyncingBlock(s.ctx)
 }
 
+func (s *Store) GetStartBlock() (bk *field.BigInt, err error) {
+	return s.St.ReadStartBlock(s.ctx)
+}
+
+func (s *Store) GetConfirmedBlock() (bk *field.BigInt, err error) {
+	return s.St.ReadConfirmedBlock(s.ctx)
+}
+
+func (s *Store) GetDailyStats(date string) (*types.DailyStats, error) {
+	return s.St.ReadDailyStats(s.ctx, date)
+}
+
+func (s *Store) GetBalanceAt(address common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return s.St.ReadBalanceAt(s.ctx, address, blockNum)
+}
+
+func (s *Store) ListBalanceChanges(address common.Address, offset, limit int64) ([]*types.BalanceChange, error) {
+	return s.St.ListBalanceChanges(s.ctx, address, uint64(offset), uint64(limit))
+}
+
+func (s *Store) GetBalanceChangeCount(address common.Address) (uint64, error) {
+	return s.St.ReadBalanceChangeCount(s.ctx, address)
+}
+
+func (s *Store) GetErc20BalanceAt(contract, address common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return s.St.ReadErc20BalanceAt(s.ctx, contract, address, blockNum)
+}
+
+func (s *Store) GetErc1155BalanceAt(contract common.Address, tokenId *field.BigInt, address common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return s.St.ReadErc1155BalanceAt(s.ctx, contract, tokenId, address, blockNum)
+}
+
+func (s *Store) ListErc20BalanceHolders(contract, from common.Address, limit int64) ([]common.Address, error) {
+	return s.St.GetErc20BalanceHolders(s.ctx, contract, from, uint64(limit))
+}
+
+func (s *Store) ListErc1155BalanceHolders(contract common.Address, tokenId *field.BigInt, from common.Address, limit int64) ([]common.Address, error) {
+	return s.St.GetErc1155BalanceHolders(s.ctx, contract, tokenId, from, uint64(limit))
+}
+
+func (s *Store) GetErc1155BalanceHolderCount(contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return s.St.GetErc1155BalanceHolderCount(s.ctx, contract, tokenId)
+}
+
+func (s *Store) GetErc1155HolderQuantity(contract, address common.Address, tokenId *field.BigInt) (*field.BigInt, error) {
+	return s.St.ReadErc1155HolderTokenIdQuantity(s.ctx, contract, address, tokenId)
+}
+
+func (s *Store) ListAccountWithdrawals(address common.Address, offset, limit int64) ([]*types.AccountWithdrawal, error) {
+	return s.St.ListAccountWithdrawals(s.ctx, address, uint64(offset), uint64(limit))
+}
+
+func (s *Store) GetAccountWithdrawalCount(address common.Address) (uint64, error) {
+	return s.St.ReadAccountWithdrawalCount(s.ctx, address)
+}
+
+func (s *Store) FilterLogs(filter *types.LogFilter, offset, limit int64) ([]*types.LogEntry, uint64, error) {
+	return s.St.FilterLogs(s.ctx, filter, uint64(offset), uint64(limit))
+}
+
+func (s *Store) ListAccountLogs(address common.Address, offset, limit int64) ([]*types.LogEntry, error) {
+	return s.St.ListAccountLogs(s.ctx, address, uint64(offset), uint64(limit))
+}
+
+func (s *Store) GetAccountLogCount(address common.Address) (uint64, error) {
+	return s.St.ReadAccountLogCount(s.ctx, address)
+}
+
+func (s *Store) ListErc721TokenTransfers(contract common.Address, tokenId *field.BigInt, offset, limit int64) ([]*types.Erc721Transfer, error) {
+	indexes, err := s.St.ListErc721TokenTransfers(s.ctx, contract, tokenId, uint64(offset), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*types.Erc721Transfer, 0, len(indexes))
+	for _, index := range indexes {
+		tx, err := s.St.ReadErc721Transfer(s.ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (s *Store) GetErc721TokenTransferCount(contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return s.St.ReadErc721TokenTransferCount(s.ctx, contract, tokenId)
+}
+
+func (s *Store) ListErc1155TokenTransfers(contract common.Address, tokenId *field.BigInt, offset, limit int64) ([]*types.Erc1155Transfer, error) {
+	indexes, err := s.St.ListErc1155TokenTransfers(s.ctx, contract, tokenId, uint64(offset), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*types.Erc1155Transfer, 0, len(indexes))
+	for _, index := range indexes {
+		tx, err := s.St.ReadErc1155Transfer(s.ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (s *Store) GetErc1155TokenTransferCount(contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return s.St.ReadErc1155TokenTransferCount(s.ctx, contract, tokenId)
+}
+
+func (s *Store) GetErc721Owner(contract common.Address, tokenId *field.BigInt) (common.Address, error) {
+	return s.St.ReadErc721Owner(s.ctx, contract, tokenId)
+}
+
+func (s *Store) GetNftMetadata(contract common.Address, tokenId *field.BigInt) (*types.NftMetadata, error) {
+	return s.St.ReadNftMetadata(s.ctx, contract, tokenId)
+}
+
+func (s *Store) WriteNftMetadata(contract common.Address, tokenId *field.BigInt, metadata *types.NftMetadata) error {
+	return s.St.WriteNftMetadata(s.ctx, contract, tokenId, metadata)
+}
+
+func (s *Store) ListOwnerApprovals(address common.Address, offset, limit int64) ([]*types.Approval, error) {
+	return s.St.ListOwnerApprovals(s.ctx, address, uint64(offset), uint64(limit))
+}
+
+func (s *Store) GetOwnerApprovalCount(address common.Address) (uint64, error) {
+	return s.St.ReadOwnerApprovalCount(s.ctx, address)
+}
+
+func (s *Store) ListSpenderApprovals(address common.Address, offset, limit int64) ([]*types.Approval, error) {
+	return s.St.ListSpenderApprovals(s.ctx, address, uint64(offset), uint64(limit))
+}
+
+func (s *Store) GetSpenderApprovalCount(address common.Address) (uint64, error) {
+	return s.St.ReadSpenderApprovalCount(s.ctx, address)
+}
+
 func (s *Store) GetAccount(address common.Address) (*types.Account, error) {
 	return s.St.ReadAccount(s.ctx, address)
 }
@@ -413,6 +583,18 @@ func (s *Store) GetMethodName(methodID string) (string, error) {
 	return s.St.ReadMethodName(s.ctx, methodID, "")
 }
 
+func (s *Store) GetFunctionSignatures(selector []byte) ([]string, error) {
+	return s.St.ReadFunctionSignatures(s.ctx, selector)
+}
+
+func (s *Store) GetEventSignatures(topic common.Hash) ([]string, error) {
+	return s.St.ReadEventSignatures(s.ctx, topic)
+}
+
+func (s *Store) ImportSignatures(kind string, r io.Reader) (int, error) {
+	return s.St.ImportSignatures(s.ctx, kind, r)
+}
+
 func (s *Store) ListErc721Inventories(address common.Address, offset, limit int64) ([]*types.Inventory, error) {
 	return s.St.GetErc721Inventory(s.ctx, address, uint64(offset), uint64(limit))
 }
@@ -461,6 +643,14 @@ func (s *Store) GetProxyContract(address common.Address) (logic common.Address,
 	return s.St.ReadProxyContract(s.ctx, address)
 }
 
+func (s *Store) GetProxy(address common.Address) (*types.Proxy, error) {
+	return s.St.ReadProxy(s.ctx, address)
+}
+
+func (s *Store) ListProxyUpgrades(address common.Address, offset, limit int64) ([]*types.ProxyUpgrade, error) {
+	return s.St.ListProxyUpgrades(s.ctx, address, uint64(offset), uint64(limit))
+}
+
 func (s *Store) GetErc20ContractTransfer(contract common.Address, offset, limit int64) (data []*types.Erc20Transfer, total *field.BigInt, err error) {
 	return s.St.GetErc20ContractTransfer(s.ctx, contract, offset, limit)
 }
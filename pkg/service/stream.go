@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/stream"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+var hub *stream.Hub
+
+// SetStream sets the hub the committed blocks are pushed with
+func SetStream(h *stream.Hub) {
+	hub = h
+}
+
+func SubscribeStream(f *types.StreamFilter) (*stream.Subscriber, error) {
+	filter := &stream.Filter{}
+	if f.Address != "" {
+		if !common.IsHexAddress(f.Address) {
+			return nil, response.ErrInvalidParameter
+		}
+		filter.Address = common.HexToAddress(f.Address)
+	}
+	if f.Contract != "" {
+		if !common.IsHexAddress(f.Contract) {
+			return nil, response.ErrInvalidParameter
+		}
+		filter.Contract = common.HexToAddress(f.Contract)
+	}
+	sub, err := hub.Subscribe(f.From, filter)
+	if err != nil {
+		if errors.Is(err, stream.ErrResume) {
+			return nil, response.NewStreamResumeError(err)
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+func UnsubscribeStream(sub *stream.Subscriber) {
+	hub.Unsubscribe(sub)
+}
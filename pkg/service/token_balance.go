@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	// addresses read at once when holders are walked
+	snapshotBatch = 1000
+	// addresses looked at by a page of the snapshot at most, it ends early when they hold nothing
+	snapshotScanLimit = 10 * snapshotBatch
+)
+
+// GetTokenBalanceAt returns the erc20 amount, or the erc1155 quantity of a token id, of a holder at a block
+func GetTokenBalanceAt(contract, holder common.Address, req *types.TokenBalanceQuery) (*types.TokenBalanceResp, error) {
+	tokenId, err := parseTokenID(req)
+	if err != nil {
+		return nil, err
+	}
+	blockNum, err := resolveBlock(req.Block, req.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := readTokenBalanceAt(req.Type, contract, tokenId, holder, blockNum)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, response.ErrRecordNotFind
+		}
+		return nil, err
+	}
+	return &types.TokenBalanceResp{
+		Contract:    contract.Hex(),
+		Holder:      holder.Hex(),
+		TokenID:     tokenId.StringPointer(),
+		BlockNumber: blockNum,
+		Balance:     change.Balance.String(),
+		ChangedAt:   change.BlockNumber,
+	}, nil
+}
+
+// ListTokenHoldersAt returns the holders of a token at a block in address order, a page starts
+// after the address req.After and next is where the following page starts
+func ListTokenHoldersAt(contract common.Address, req *types.TokenBalanceQuery, pager *types.Pager) (map[string]interface{}, error) {
+	tokenId, err := parseTokenID(req)
+	if err != nil {
+		return nil, err
+	}
+	blockNum, err := resolveBlock(req.Block, req.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	var after *common.Address
+	if req.After != "" {
+		if !common.IsHexAddress(req.After) {
+			return nil, response.ErrInvalidParameter
+		}
+		addr := common.HexToAddress(req.After)
+		after = &addr
+	}
+
+	var (
+		items   = make([]*types.HolderResp, 0)
+		scanned int
+		done    bool
+	)
+	for !done && int64(len(items)) < pager.Limit && scanned < snapshotScanLimit {
+		var addrs []common.Address
+		if addrs, err = balanceHoldersAfter(req.Type, contract, tokenId, after, snapshotBatch); err != nil {
+			return nil, err
+		}
+		done = len(addrs) < snapshotBatch
+		for i := range addrs {
+			after = &addrs[i]
+			scanned++
+			change, err := readTokenBalanceAt(req.Type, contract, tokenId, addrs[i], blockNum)
+			if err != nil {
+				if errors.Is(err, kv.NotFound) {
+					continue
+				}
+				return nil, err
+			}
+			if change.Balance.Cmp(field.NewInt(0)) > 0 {
+				items = append(items, &types.HolderResp{
+					Address:  addrs[i].Hex(),
+					Quantity: change.Balance.String(),
+				})
+			}
+			if int64(len(items)) == pager.Limit {
+				done = i == len(addrs)-1 && done
+				break
+			}
+		}
+	}
+
+	var next *string
+	if !done && after != nil {
+		cursor := after.Hex()
+		next = &cursor
+	}
+	return map[string]interface{}{
+		"blockNumber": blockNum,
+		"items":       items,
+		"next":        next,
+	}, nil
+}
+
+// ExportTokenHoldersAt returns the holders of a token at a block as csv, balances are decimal
+func ExportTokenHoldersAt(contract common.Address, req *types.TokenBalanceQuery) (uint64, []byte, error) {
+	blockNum, holders, err := tokenHoldersAt(contract, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err = w.Write([]string{"address", "balance"}); err != nil {
+		return 0, nil, err
+	}
+	for _, h := range holders {
+		if err = w.Write([]string{h.Addr.Hex(), (*big.Int)(&h.Quantity).String()}); err != nil {
+			return 0, nil, err
+		}
+	}
+	w.Flush()
+	return blockNum, buf.Bytes(), w.Error()
+}
+
+// tokenHoldersAt reads the balance at the block of every address which has held the token,
+// the largest balance first. It walks all of them, so it is only used by the export.
+func tokenHoldersAt(contract common.Address, req *types.TokenBalanceQuery) (uint64, []*types.Holder, error) {
+	tokenId, err := parseTokenID(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	blockNum, err := resolveBlock(req.Block, req.Timestamp)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	holders := make([]*types.Holder, 0)
+	err = walkBalanceHolders(req.Type, contract, tokenId, func(addr common.Address) error {
+		change, err := readTokenBalanceAt(req.Type, contract, tokenId, addr, blockNum)
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				return nil
+			}
+			return err
+		}
+		if change.Balance.Cmp(field.NewInt(0)) > 0 {
+			holders = append(holders, &types.Holder{Addr: addr, Quantity: change.Balance})
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	sort.SliceStable(holders, func(i, j int) bool {
+		return holders[i].Quantity.Cmp(&holders[j].Quantity) > 0
+	})
+	return blockNum, holders, nil
+}
+
+// walkBalanceHolders calls fn with every address which has held the token, in address order
+func walkBalanceHolders(typ string, contract common.Address, tokenId *field.BigInt, fn func(common.Address) error) error {
+	var after *common.Address
+	for {
+		addrs, err := balanceHoldersAfter(typ, contract, tokenId, after, snapshotBatch)
+		if err != nil {
+			return err
+		}
+		for i := range addrs {
+			if err = fn(addrs[i]); err != nil {
+				return err
+			}
+			after = &addrs[i]
+		}
+		if len(addrs) < snapshotBatch {
+			return nil
+		}
+	}
+}
+
+// balanceHoldersAfter returns up to limit addresses which have held the token, the first one
+// follows after, or is the lowest address when after is nil
+func balanceHoldersAfter(typ string, contract common.Address, tokenId *field.BigInt, after *common.Address, limit int64) ([]common.Address, error) {
+	var (
+		from  common.Address
+		addrs []common.Address
+		err   error
+	)
+	if after != nil {
+		// the range starts at after, it is read once more and dropped
+		from = *after
+		limit++
+	}
+	if typ == "erc1155" {
+		addrs, err = store.ListErc1155BalanceHolders(contract, tokenId, from, limit)
+	} else {
+		addrs, err = store.ListErc20BalanceHolders(contract, from, limit)
+	}
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	if after != nil && len(addrs) > 0 && addrs[0] == *after {
+		addrs = addrs[1:]
+	} else if after != nil && int64(len(addrs)) == limit {
+		addrs = addrs[:limit-1]
+	}
+	return addrs, nil
+}
+
+func readTokenBalanceAt(typ string, contract common.Address, tokenId *field.BigInt, holder common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	if typ == "erc1155" {
+		return store.GetErc1155BalanceAt(contract, tokenId, holder, blockNum)
+	}
+	return store.GetErc20BalanceAt(contract, holder, blockNum)
+}
+
+// parseTokenID checks the type of the query, erc1155 requires a decimal or hex token id
+func parseTokenID(req *types.TokenBalanceQuery) (*field.BigInt, error) {
+	switch req.Type {
+	case "", "erc20":
+		return nil, nil
+	case "erc1155":
+		id, ok := new(big.Int).SetString(req.TokenID, 0)
+		if !ok || id.Sign() < 0 {
+			return nil, response.ErrInvalidParameter
+		}
+		return (*field.BigInt)(id), nil
+	}
+	return nil, response.ErrInvalidParameter
+}
@@ -0,0 +1,20 @@
+package service
+
+import "github.com/uchainorg/uscan/pkg/job"
+
+var tracing = job.TracingFull
+
+// SetTracing sets the tracing mode the sync is running with
+func SetTracing(mode string) {
+	tracing = mode
+}
+
+// InternalTxUnavailable tells that internal txs are not indexed without tracing
+func InternalTxUnavailable() bool {
+	return tracing == job.TracingOff
+}
+
+// TraceTx2Unavailable tells that call trees are only kept with full tracing
+func TraceTx2Unavailable() bool {
+	return tracing != job.TracingFull
+}
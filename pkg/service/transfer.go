@@ -308,6 +308,10 @@ func GetTraceTx(hash common.Hash) (*types.TraceTxResp, error) {
 
 func GetTraceTx2(hash common.Hash) (*types.TraceTx2Resp, error) {
 	resp := &types.TraceTx2Resp{}
+	if TraceTx2Unavailable() {
+		resp.Unavailable = true
+		return resp, nil
+	}
 	t, err := store.ReadTraceTx2(hash)
 	if err != nil {
 		if err == kv.NotFound {
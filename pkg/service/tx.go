@@ -164,6 +164,9 @@ func GetTx(tx string) (*types.TxResp, error) {
 	txHash := common.HexToHash(tx)
 	txData, err := store.GetTx(txHash)
 	if err != nil {
+		if resp, ok := getPendingTx(txHash); ok {
+			return resp, nil
+		}
 		if err != nil {
 			return nil, response.ErrRecordNotFind
 		}
@@ -266,6 +269,17 @@ func GetTx(tx string) (*types.TxResp, error) {
 			resp.ContractAddressSymbol = ca.Symbol
 		}
 	}
+	abis := make(abiCache)
+	if txData.To != nil {
+		contractAbis, err := abis.get(*txData.To)
+		if err != nil {
+			return nil, err
+		}
+		if resp.DecodedInput, err = decodeInput(contractAbis, txData.Data); err != nil {
+			return nil, err
+		}
+	}
+
 	// event log
 	resp.TotalLogs = 0
 	resp.Logs = make([]*types.RtLogResp, 0)
@@ -280,11 +294,20 @@ func GetTx(tx string) (*types.TxResp, error) {
 			for _, topic := range log.Topics {
 				topics = append(topics, topic.Hex())
 			}
+			contractAbis, err := abis.get(log.Address)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := decodeEvent(contractAbis, log)
+			if err != nil {
+				return nil, err
+			}
 			resp.Logs[i] = &types.RtLogResp{
 				Address:  log.Address.Hex(),
 				Topics:   topics,
 				Data:     log.Data.String(),
 				LogIndex: log.LogIndex.ToUint64(),
+				Decoded:  decoded,
 			}
 
 			//for _, topic := range TokenTopics {
@@ -341,6 +364,9 @@ func GetTx(tx string) (*types.TxResp, error) {
 			resp.MethodName = methodName
 		}
 	}
+	if resp.MethodName == "" && resp.DecodedInput != nil {
+		resp.MethodName = resp.DecodedInput.Signature
+	}
 
 	return resp, nil
 }
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/response"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/webhook"
+)
+
+var webhooks *webhook.Dispatcher
+
+// SetWebhooks sets the dispatcher the webhooks are managed with
+func SetWebhooks(d *webhook.Dispatcher) {
+	webhooks = d
+}
+
+func CreateWebhook(req *types.WebhookReq) (*types.WebhookResp, error) {
+	hook := &types.Webhook{
+		URL:    req.URL,
+		Secret: req.Secret,
+	}
+	if req.Address != "" {
+		if !common.IsHexAddress(req.Address) {
+			return nil, response.ErrInvalidParameter
+		}
+		hook.Address = common.HexToAddress(req.Address)
+	}
+	if req.Contract != "" {
+		if !common.IsHexAddress(req.Contract) {
+			return nil, response.ErrInvalidParameter
+		}
+		hook.Contract = common.HexToAddress(req.Contract)
+	}
+	if req.Topic != "" {
+		topic, err := hexutil.Decode(req.Topic)
+		if err != nil || len(topic) != common.HashLength {
+			return nil, response.ErrInvalidParameter
+		}
+		hook.Topic = common.BytesToHash(topic)
+	}
+	if req.MinValue != "" {
+		value, ok := new(big.Int).SetString(req.MinValue, 10)
+		if !ok || value.Sign() < 0 {
+			return nil, response.ErrInvalidParameter
+		}
+		hook.MinValue = field.BigInt(*value)
+	}
+
+	hook, err := webhooks.Create(hook)
+	if err != nil {
+		if errors.Is(err, webhook.ErrInvalidWebhook) {
+			return nil, response.ErrInvalidParameter
+		}
+		return nil, err
+	}
+	resp := toWebhookResp(hook)
+	resp.Secret = &hook.Secret
+	return resp, nil
+}
+
+func ListWebhooks() []*types.WebhookResp {
+	hooks := webhooks.List()
+	resp := make([]*types.WebhookResp, 0, len(hooks))
+	for _, hook := range hooks {
+		resp = append(resp, toWebhookResp(hook))
+	}
+	return resp
+}
+
+func GetWebhook(id uint64) (*types.WebhookResp, error) {
+	hook, err := webhooks.Get(id)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil, response.ErrRecordNotFind
+		}
+		return nil, err
+	}
+	return toWebhookResp(hook), nil
+}
+
+func DeleteWebhook(id uint64) error {
+	if _, err := GetWebhook(id); err != nil {
+		return err
+	}
+	return webhooks.Delete(id)
+}
+
+func ListWebhookDeliveries(id uint64, pager *types.Pager) (map[string]interface{}, error) {
+	data, total, err := webhooks.Deliveries(id, pager.Offset, pager.Limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*types.WebhookDeliveryResp, 0, len(data))
+	for _, v := range data {
+		items = append(items, &types.WebhookDeliveryResp{
+			BlockNumber: v.BlockNumber.ToUint64(),
+			BlockHash:   v.BlockHash.Hex(),
+			Confirmed:   v.Confirmed,
+			Removed:     v.Removed,
+			Attempts:    v.Attempts,
+			StatusCode:  v.StatusCode,
+			Error:       v.Error,
+			Payload:     v.Payload,
+			CreatedTime: v.TimeStamp.ToUint64(),
+		})
+	}
+	return map[string]interface{}{
+		"items": items,
+		"total": total,
+	}, nil
+}
+
+func toWebhookResp(hook *types.Webhook) *types.WebhookResp {
+	resp := &types.WebhookResp{
+		ID:          hook.ID.ToUint64(),
+		URL:         hook.URL,
+		MinValue:    (*big.Int)(&hook.MinValue).String(),
+		CreatedTime: hook.Created.ToUint64(),
+	}
+	if hook.Address != (common.Address{}) {
+		address := hook.Address.Hex()
+		resp.Address = &address
+	}
+	if hook.Contract != (common.Address{}) {
+		contract := hook.Contract.Hex()
+		resp.Contract = &contract
+	}
+	if hook.Topic != (common.Hash{}) {
+		topic := hook.Topic.Hex()
+		resp.Topic = &topic
+	}
+	return resp
+}
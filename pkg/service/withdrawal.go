@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+// ListAccountWithdrawals returns the beacon-chain withdrawals to an address, the latest first
+func ListAccountWithdrawals(pager *types.Pager, address common.Address) (map[string]interface{}, error) {
+	total, err := store.GetAccountWithdrawalCount(address)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	withdrawals, err := store.ListAccountWithdrawals(address, pager.Offset, pager.Limit)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return nil, err
+	}
+	items := make([]*types.WithdrawalResp, 0, len(withdrawals))
+	for _, v := range withdrawals {
+		items = append(items, &types.WithdrawalResp{
+			Index:          v.Index,
+			ValidatorIndex: v.Validator,
+			Address:        address.Hex(),
+			Amount:         gweiToWei(v.Amount),
+			BlockNumber:    v.BlockNumber,
+			TimeStamp:      v.TimeStamp,
+		})
+	}
+	return map[string]interface{}{
+		"items": items,
+		"total": total,
+	}, nil
+}
+
+func blockWithdrawals(block *types.Block) []*types.WithdrawalResp {
+	items := make([]*types.WithdrawalResp, 0, len(block.Withdrawals))
+	for _, v := range block.Withdrawals {
+		items = append(items, &types.WithdrawalResp{
+			Index:          uint64(v.Index),
+			ValidatorIndex: uint64(v.Validator),
+			Address:        v.Address.Hex(),
+			Amount:         gweiToWei(uint64(v.Amount)),
+			BlockNumber:    block.Number.ToUint64(),
+			TimeStamp:      block.TimeStamp.ToUint64(),
+		})
+	}
+	return items
+}
+
+// gweiToWei renders a withdrawal amount like the other values, in hex wei
+func gweiToWei(amount uint64) string {
+	wei := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(params.GWei))
+	return (*field.BigInt)(wei).String()
+}
@@ -0,0 +1,60 @@
+package signature
+
+import (
+	"context"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+)
+
+// signatures written in one tx, the sync waits for the tx of an import
+const importBatch = 10000
+
+// Import writes the signatures of a list into db and returns how many have been read, see Parse for
+// the formats. Signatures which are there already are left as they are, the batches written before
+// an error are kept.
+func Import(ctx context.Context, db kv.Database, kind string, r io.Reader) (count int, err error) {
+	var write func(ctx context.Context, text string) error
+	switch kind {
+	case KindFunction:
+		write = func(ctx context.Context, text string) error {
+			return fulldb.WriteFunctionSignature(ctx, db, Selector(kind, text), text)
+		}
+	case KindEvent:
+		write = func(ctx context.Context, text string) error {
+			return fulldb.WriteEventSignature(ctx, db, common.BytesToHash(Selector(kind, text)), text)
+		}
+	default:
+		return 0, ErrInvalidKind
+	}
+
+	txCtx, err := db.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	err = Parse(r, func(text string) error {
+		if err := write(txCtx, text); err != nil {
+			return err
+		}
+		if count++; count%importBatch == 0 {
+			db.Commit(txCtx)
+			var err error
+			if txCtx, err = db.BeginTx(ctx); err != nil {
+				txCtx = nil
+				return err
+			}
+		}
+		return nil
+	})
+	if txCtx == nil {
+		return count, err
+	}
+	if err != nil {
+		db.RollBack(txCtx)
+		return count, err
+	}
+	db.Commit(txCtx)
+	return count, nil
+}
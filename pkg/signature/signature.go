@@ -0,0 +1,127 @@
+package signature
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	KindFunction = "function"
+	KindEvent    = "event"
+
+	// longer values do not fit in a sorted table
+	maxSignatureLength = 1024
+)
+
+var (
+	ErrInvalidKind = errors.New("signature kind must be function or event")
+
+	// a selector or topic in front of the signature of a line, as in "0xa9059cbb transfer(address,uint256)"
+	linePrefix = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{8,64}[\s,:;=|]+`)
+	textFormat = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*\(.*\)$`)
+)
+
+// Normalize removes the spaces of a text signature, it returns false when text is not one
+func Normalize(text string) (string, bool) {
+	text = strings.Join(strings.Fields(text), "")
+	if len(text) > maxSignatureLength || !textFormat.MatchString(text) {
+		return "", false
+	}
+	depth := 0
+	for _, c := range text {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth < 0 {
+				return "", false
+			}
+		}
+	}
+	return text, depth == 0
+}
+
+// Selector is the 4 bytes id of a function or the topic of an event
+func Selector(kind, text string) []byte {
+	hash := crypto.Keccak256([]byte(text))
+	if kind == KindFunction {
+		return hash[:4]
+	}
+	return hash
+}
+
+// Parse reads the text signatures of a list. A list is either plain text with a signature per line,
+// optionally behind its selector, or a json dump in which every string which is a signature counts,
+// whatever its layout. Duplicates are not removed.
+func Parse(r io.Reader, fn func(text string) error) error {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if b[0] == '[' || b[0] == '{' {
+			return parseJSON(br, fn)
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			break
+		}
+		br.ReadByte()
+	}
+	return parseText(br, fn)
+}
+
+func parseText(r io.Reader, fn func(text string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = linePrefix.ReplaceAllString(line, "")
+		if text, ok := Normalize(line); ok {
+			if err := fn(text); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func parseJSON(r io.Reader, fn func(text string) error) error {
+	var dump interface{}
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return err
+	}
+	return walk(dump, fn)
+}
+
+func walk(v interface{}, fn func(text string) error) error {
+	switch t := v.(type) {
+	case string:
+		if text, ok := Normalize(t); ok {
+			return fn(text)
+		}
+	case []interface{}:
+		for _, item := range t {
+			if err := walk(item, fn); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, item := range t {
+			if err := walk(item, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package signature
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/share"
+)
+
+func parse(t *testing.T, list string) []string {
+	res := make([]string, 0)
+	assert.NoError(t, Parse(strings.NewReader(list), func(text string) error {
+		res = append(res, text)
+		return nil
+	}))
+	return res
+}
+
+func TestParseText(t *testing.T) {
+	list := `transfer(address,uint256)
+0x095ea7b3 approve(address, uint256)
+a9059cbb,transfer(address,uint256)
+
+not a signature
+swap((address,uint256)[],bytes)
+broken(uint256`
+	assert.Equal(t, []string{
+		"transfer(address,uint256)",
+		"approve(address,uint256)",
+		"transfer(address,uint256)",
+		"swap((address,uint256)[],bytes)",
+	}, parse(t, list))
+}
+
+func TestParseJSON(t *testing.T) {
+	// 4byte.directory dump
+	list := ` [{"id": 1, "text_signature": "transfer(address,uint256)", "hex_signature": "0xa9059cbb"}]`
+	assert.Equal(t, []string{"transfer(address,uint256)"}, parse(t, list))
+
+	// selector => candidates
+	list = `{"0xa9059cbb": ["transfer(address,uint256)", "many_msg_babbage(bytes1)"]}`
+	assert.Equal(t, []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)"}, parse(t, list))
+}
+
+func TestSelector(t *testing.T) {
+	assert.Equal(t, "0xa9059cbb", hexutil.Encode(Selector(KindFunction, "transfer(address,uint256)")))
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hexutil.Encode(Selector(KindEvent, "Transfer(address,address,uint256)")))
+}
+
+func TestImport(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = mdbx.NewMdbx(t.TempDir(), []string{}, []string{share.SignatureSortTabl})
+	)
+	count, err := Import(ctx, db, KindFunction, strings.NewReader("transfer(address,uint256)\nmany_msg_babbage(bytes1)\ntransfer(address,uint256)\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// colliding signatures are all kept
+	res, err := fulldb.ReadFunctionSignatures(ctx, db, hexutil.MustDecode("0xa9059cbb"))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)"}, res)
+
+	_, err = Import(ctx, db, KindEvent, strings.NewReader(`["Transfer(address,address,uint256)"]`))
+	assert.NoError(t, err)
+	res, err = fulldb.ReadEventSignatures(ctx, db, common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, res)
+
+	_, err = Import(ctx, db, "method", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidKind)
+}
@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/signature"
+	"github.com/uchainorg/uscan/pkg/storage"
+	"github.com/uchainorg/uscan/share"
+)
+
+func SignaturesRun(cmd *cobra.Command, args []string) {
+	functions, _ := cmd.Flags().GetStringSlice("functions")
+	events, _ := cmd.Flags().GetStringSlice("events")
+	if len(functions) == 0 && len(events) == 0 {
+		log.Fatal("nothing to import, use --functions or --events")
+	}
+
+	storage := storage.NewStorage(viper.GetString(share.MdbxPath))
+	importSignatureFiles(storage, signature.KindFunction, functions)
+	importSignatureFiles(storage, signature.KindEvent, events)
+}
+
+func importSignatureFiles(st *storage.StorageImpl, kind string, paths []string) {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("open %s: %v", path, err)
+		}
+		count, err := st.ImportSignatures(context.Background(), kind, f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("import %s signatures of %s: %v, %d imported", kind, path, err, count)
+		}
+		log.Infof("imported %d %s signatures of %s", count, kind, path)
+	}
+}
@@ -0,0 +1,44 @@
+package forkdb
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	journalKey = []byte("/fork/journal/")
+)
+
+/*
+table: journal
+
+/fork/journal/<block num> => undo entries of the block
+*/
+
+func ReadJournal(ctx context.Context, db kv.Reader, blockNum *field.BigInt) (j *types.Journal, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, append(journalKey, blockNum.Bytes()...), &kv.ReadOption{Table: share.ForkJournalTbl})
+	if err != nil {
+		return
+	}
+	j = &types.Journal{}
+	err = j.Unmarshal(bytesRes)
+	return
+}
+
+func WriteJournal(ctx context.Context, db kv.Writer, blockNum *field.BigInt, j *types.Journal) (err error) {
+	var bytesRes []byte
+	bytesRes, err = j.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, append(journalKey, blockNum.Bytes()...), bytesRes, &kv.WriteOption{Table: share.ForkJournalTbl})
+}
+
+func DeleteJournal(ctx context.Context, db kv.Writer, blockNum *field.BigInt) (err error) {
+	return db.Del(ctx, append(journalKey, blockNum.Bytes()...), &kv.WriteOption{Table: share.ForkJournalTbl})
+}
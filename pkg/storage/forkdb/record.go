@@ -0,0 +1,44 @@
+package forkdb
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	recordKey = []byte("/fork/record/")
+)
+
+/*
+table: fork_record
+
+/fork/record/<block num> => cleanup of the fork block
+*/
+
+func ReadForkRecord(ctx context.Context, db kv.Reader, blockNum *field.BigInt) (r *types.ForkRecord, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, append(recordKey, blockNum.Bytes()...), &kv.ReadOption{Table: share.ForkRecordTbl})
+	if err != nil {
+		return
+	}
+	r = &types.ForkRecord{}
+	err = r.Unmarshal(bytesRes)
+	return
+}
+
+func WriteForkRecord(ctx context.Context, db kv.Writer, blockNum *field.BigInt, r *types.ForkRecord) (err error) {
+	var bytesRes []byte
+	bytesRes, err = r.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, append(recordKey, blockNum.Bytes()...), bytesRes, &kv.WriteOption{Table: share.ForkRecordTbl})
+}
+
+func DeleteForkRecord(ctx context.Context, db kv.Writer, blockNum *field.BigInt) (err error) {
+	return db.Del(ctx, append(recordKey, blockNum.Bytes()...), &kv.WriteOption{Table: share.ForkRecordTbl})
+}
@@ -0,0 +1,143 @@
+package fulldb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	approvalPrefix        = []byte("/approval/")
+	approvalOwnerPrefix   = []byte("/approval/owner/")
+	approvalSpenderPrefix = []byte("/approval/spender/")
+)
+
+/*
+	// key = value
+	/approval/<token><owner><spender> => latest approval
+
+	// key = > sort, active approvals only
+	/approval/owner/<owner> => token + spender
+	/approval/spender/<spender> => token + owner
+*/
+
+func getApprovalKey(token, owner, spender common.Address) []byte {
+	key := make([]byte, 0, len(approvalPrefix)+3*common.AddressLength)
+	key = append(key, approvalPrefix...)
+	key = append(key, token.Bytes()...)
+	key = append(key, owner.Bytes()...)
+	return append(key, spender.Bytes()...)
+}
+
+func getApprovalIndexKey(prefix []byte, addr common.Address) []byte {
+	key := make([]byte, 0, len(prefix)+common.AddressLength)
+	key = append(key, prefix...)
+	return append(key, addr.Bytes()...)
+}
+
+func getApprovalIndexValue(token, addr common.Address) []byte {
+	return append(append(make([]byte, 0, 2*common.AddressLength), token.Bytes()...), addr.Bytes()...)
+}
+
+func ReadApproval(ctx context.Context, db kv.Reader, token, owner, spender common.Address) (approval *types.Approval, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, getApprovalKey(token, owner, spender), &kv.ReadOption{Table: share.ApprovalTbl})
+	if err != nil {
+		return
+	}
+	approval = &types.Approval{}
+	err = approval.Unmarshal(bytesRes)
+	return
+}
+
+// WriteApproval replaces the approval of the spender unless a later one has been written,
+// revoked approvals are removed from the indexes of the owner and the spender
+func WriteApproval(ctx context.Context, db kv.Database, approval *types.Approval) (err error) {
+	var last *types.Approval
+	last, err = ReadApproval(ctx, db, approval.Token, approval.Owner, approval.Spender)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if last != nil && approval.Before(last) {
+		return nil
+	}
+
+	var bytesRes []byte
+	if bytesRes, err = approval.Marshal(); err != nil {
+		return err
+	}
+	if err = db.Put(ctx, getApprovalKey(approval.Token, approval.Owner, approval.Spender), bytesRes, &kv.WriteOption{Table: share.ApprovalTbl}); err != nil {
+		return err
+	}
+
+	var (
+		ownerKey     = getApprovalIndexKey(approvalOwnerPrefix, approval.Owner)
+		ownerValue   = getApprovalIndexValue(approval.Token, approval.Spender)
+		spenderKey   = getApprovalIndexKey(approvalSpenderPrefix, approval.Spender)
+		spenderValue = getApprovalIndexValue(approval.Token, approval.Owner)
+		opts         = &kv.WriteOption{Table: share.ApprovalSortTabl}
+	)
+	if approval.Active() {
+		if err = db.SPut(ctx, ownerKey, ownerValue, opts); err != nil {
+			return err
+		}
+		return db.SPut(ctx, spenderKey, spenderValue, opts)
+	}
+	if last == nil || !last.Active() {
+		return nil
+	}
+	if err = db.SDel(ctx, ownerKey, ownerValue, opts); err != nil {
+		return err
+	}
+	return db.SDel(ctx, spenderKey, spenderValue, opts)
+}
+
+// ListOwnerApprovals returns the active approvals given by owner, ordered by token
+func ListOwnerApprovals(ctx context.Context, db kv.Database, owner common.Address, offset, limit uint64) ([]*types.Approval, error) {
+	return listApprovals(ctx, db, owner, true, offset, limit)
+}
+
+func GetOwnerApprovalCount(ctx context.Context, db kv.Sorter, owner common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getApprovalIndexKey(approvalOwnerPrefix, owner), &kv.ReadOption{Table: share.ApprovalSortTabl})
+}
+
+// ListSpenderApprovals returns the active approvals given to spender, ordered by token
+func ListSpenderApprovals(ctx context.Context, db kv.Database, spender common.Address, offset, limit uint64) ([]*types.Approval, error) {
+	return listApprovals(ctx, db, spender, false, offset, limit)
+}
+
+func GetSpenderApprovalCount(ctx context.Context, db kv.Sorter, spender common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getApprovalIndexKey(approvalSpenderPrefix, spender), &kv.ReadOption{Table: share.ApprovalSortTabl})
+}
+
+func listApprovals(ctx context.Context, db kv.Database, addr common.Address, owner bool, offset, limit uint64) (approvals []*types.Approval, err error) {
+	prefix := approvalSpenderPrefix
+	if owner {
+		prefix = approvalOwnerPrefix
+	}
+	var res [][]byte
+	res, err = db.SGet(ctx, getApprovalIndexKey(prefix, addr), offset, limit, &kv.ReadOption{Table: share.ApprovalSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	approvals = make([]*types.Approval, len(res))
+	for i, v := range res {
+		if len(v) != 2*common.AddressLength {
+			return nil, types.ErrorInvalidByte
+		}
+		token, other := common.BytesToAddress(v[:common.AddressLength]), common.BytesToAddress(v[common.AddressLength:])
+		if owner {
+			approvals[i], err = ReadApproval(ctx, db, token, addr, other)
+		} else {
+			approvals[i], err = ReadApproval(ctx, db, token, other, addr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
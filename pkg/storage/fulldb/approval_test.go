@@ -0,0 +1,52 @@
+package fulldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestApprovals(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{share.ApprovalTbl}, []string{share.ApprovalSortTabl})
+		owner    = common.HexToAddress("0x1")
+		spender  = common.HexToAddress("0x2")
+		operator = common.HexToAddress("0x3")
+		token    = common.HexToAddress("0x10")
+		nft      = common.HexToAddress("0x20")
+	)
+	assert.NoError(t, WriteApproval(ctx, db, &types.Approval{Token: token, Owner: owner, Spender: spender, Amount: *field.NewInt(100), BlockNumber: 10}))
+	assert.NoError(t, WriteApproval(ctx, db, &types.Approval{Token: nft, Owner: owner, Spender: operator, ForAll: true, Approved: true, BlockNumber: 11}))
+
+	count, err := GetOwnerApprovalCount(ctx, db, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	approvals, err := ListSpenderApprovals(ctx, db, spender, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(approvals))
+	assert.Equal(t, uint64(100), approvals[0].Amount.ToUint64())
+
+	// the latest allowance is kept, a backfilled earlier approval does not replace it
+	assert.NoError(t, WriteApproval(ctx, db, &types.Approval{Token: token, Owner: owner, Spender: spender, Amount: *field.NewInt(50), BlockNumber: 12}))
+	assert.NoError(t, WriteApproval(ctx, db, &types.Approval{Token: token, Owner: owner, Spender: spender, Amount: *field.NewInt(100), BlockNumber: 10}))
+	approval, err := ReadApproval(ctx, db, token, owner, spender)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(50), approval.Amount.ToUint64())
+
+	// revoked approvals leave the indexes
+	assert.NoError(t, WriteApproval(ctx, db, &types.Approval{Token: nft, Owner: owner, Spender: operator, ForAll: true, Approved: false, BlockNumber: 13}))
+	approvals, err = ListOwnerApprovals(ctx, db, owner, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(approvals))
+	assert.Equal(t, spender, approvals[0].Spender)
+	count, err = GetSpenderApprovalCount(ctx, db, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+}
@@ -0,0 +1,73 @@
+package fulldb
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	balancePrefix = []byte("/balance/")
+)
+
+/*
+	// key = > sort
+	/balance/<address> => block number + timestamp + balance
+*/
+
+func getBalanceKey(addr common.Address) []byte {
+	key := make([]byte, 0, len(balancePrefix)+common.AddressLength)
+	key = append(key, balancePrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+func WriteBalanceChange(ctx context.Context, db kv.Sorter, addr common.Address, change *types.BalanceChange) (err error) {
+	return db.SPut(ctx, getBalanceKey(addr), change.ToBytes(), &kv.WriteOption{Table: share.BalanceSortTabl})
+}
+
+// ReadBalanceAt returns the last balance change of addr up to the block
+func ReadBalanceAt(ctx context.Context, db kv.Sorter, addr common.Address, blockNum uint64) (change *types.BalanceChange, err error) {
+	return readBalanceChangeAt(ctx, db, getBalanceKey(addr), blockNum, share.BalanceSortTabl)
+}
+
+func readBalanceChangeAt(ctx context.Context, db kv.Sorter, key []byte, blockNum uint64, table string) (change *types.BalanceChange, err error) {
+	// every change of the block sorts before the highest timestamp and balance
+	val := make([]byte, 48)
+	binary.BigEndian.PutUint64(val[:8], blockNum)
+	binary.BigEndian.PutUint64(val[8:16], math.MaxUint64)
+	for i := 16; i < len(val); i++ {
+		val[i] = 0xff
+	}
+	var res []byte
+	res, err = db.SFloor(ctx, key, val, &kv.ReadOption{Table: table})
+	if err != nil {
+		return nil, err
+	}
+	return types.ByteToBalanceChange(res)
+}
+
+// ListBalanceChanges returns the balance changes of addr, the latest first
+func ListBalanceChanges(ctx context.Context, db kv.Sorter, addr common.Address, offset, limit uint64) (changes []*types.BalanceChange, err error) {
+	var res [][]byte
+	res, err = db.SGet(ctx, getBalanceKey(addr), offset, limit, &kv.ReadOption{Table: share.BalanceSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	changes = make([]*types.BalanceChange, len(res))
+	for i, v := range res {
+		changes[i], err = types.ByteToBalanceChange(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func GetBalanceChangeCount(ctx context.Context, db kv.Sorter, addr common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getBalanceKey(addr), &kv.ReadOption{Table: share.BalanceSortTabl})
+}
@@ -12,6 +12,7 @@ import (
 var (
 	homeKey    = []byte("/home")
 	syncingKey = []byte("/syncing")
+	startKey   = []byte("/start")
 )
 
 /*
@@ -19,6 +20,7 @@ table: home
 
 /home => home
 /syncing => block number
+/start => first indexed block number
 */
 
 func ReadHome(ctx context.Context, db kv.Reader) (home *types.Home, err error) {
@@ -56,3 +58,18 @@ func ReadSyncingBlock(ctx context.Context, db kv.Reader) (bk *field.BigInt, err
 func WriteSyncingBlock(ctx context.Context, db kv.Writer, bk *field.BigInt) (err error) {
 	return db.Put(ctx, syncingKey, bk.Bytes(), &kv.WriteOption{Table: share.HomeTbl})
 }
+
+func ReadStartBlock(ctx context.Context, db kv.Reader) (bk *field.BigInt, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, startKey, &kv.ReadOption{Table: share.HomeTbl})
+	if err != nil {
+		return
+	}
+	bk = &field.BigInt{}
+	bk.SetBytes(bytesRes)
+	return
+}
+
+func WriteStartBlock(ctx context.Context, db kv.Writer, bk *field.BigInt) (err error) {
+	return db.Put(ctx, startKey, bk.Bytes(), &kv.WriteOption{Table: share.HomeTbl})
+}
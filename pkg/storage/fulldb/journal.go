@@ -0,0 +1,44 @@
+package fulldb
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	journalKey = []byte("/journal/")
+)
+
+/*
+table: journal
+
+/journal/<block num> => undo entries of the block
+*/
+
+func ReadJournal(ctx context.Context, db kv.Reader, blockNum *field.BigInt) (j *types.Journal, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, append(journalKey, blockNum.Bytes()...), &kv.ReadOption{Table: share.JournalTbl})
+	if err != nil {
+		return
+	}
+	j = &types.Journal{}
+	err = j.Unmarshal(bytesRes)
+	return
+}
+
+func WriteJournal(ctx context.Context, db kv.Writer, blockNum *field.BigInt, j *types.Journal) (err error) {
+	var bytesRes []byte
+	bytesRes, err = j.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, append(journalKey, blockNum.Bytes()...), bytesRes, &kv.WriteOption{Table: share.JournalTbl})
+}
+
+func DeleteJournal(ctx context.Context, db kv.Writer, blockNum *field.BigInt) (err error) {
+	return db.Del(ctx, append(journalKey, blockNum.Bytes()...), &kv.WriteOption{Table: share.JournalTbl})
+}
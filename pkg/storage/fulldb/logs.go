@@ -0,0 +1,178 @@
+package fulldb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	logAddrPrefix  = []byte("/log/addr/")
+	logTopicPrefix = []byte("/log/topic/")
+)
+
+const (
+	// matches counted by a filter, the total of a larger result is capped
+	maxFilterLogs = 10000
+	// entries read at once while a filter is scanned
+	filterLogBatch = 1000
+)
+
+/*
+	// key = > sort
+	/log/addr/<address> => block number + log index + tx hash
+	/log/addr/<address>/<topic0> => block number + log index + tx hash
+	/log/topic/<position><topic> => block number + log index + tx hash
+*/
+
+func getLogAddrKey(addr common.Address) []byte {
+	key := make([]byte, 0, len(logAddrPrefix)+common.AddressLength+common.HashLength+1)
+	key = append(key, logAddrPrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+func getLogAddrTopicKey(addr common.Address, topic common.Hash) []byte {
+	return append(append(getLogAddrKey(addr), '/'), topic.Bytes()...)
+}
+
+func getLogTopicKey(position int, topic common.Hash) []byte {
+	key := make([]byte, 0, len(logTopicPrefix)+common.HashLength+1)
+	key = append(key, logTopicPrefix...)
+	key = append(key, byte(position))
+	return append(key, topic.Bytes()...)
+}
+
+// WriteLog indexes a log by its address and topics, writing it again is a no-op
+func WriteLog(ctx context.Context, db kv.Sorter, l *types.Log, entry *types.LogEntry) (err error) {
+	keys := [][]byte{getLogAddrKey(l.Address)}
+	for i, topic := range l.Topics {
+		if i == 0 {
+			keys = append(keys, getLogAddrTopicKey(l.Address, topic))
+		}
+		if i < 4 {
+			keys = append(keys, getLogTopicKey(i, topic))
+		}
+	}
+	val := entry.ToBytes()
+	for _, key := range keys {
+		if err = db.SPut(ctx, key, val, &kv.WriteOption{Table: share.LogSortTabl}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAccountLogs returns the logs emitted by addr, the latest first
+func ListAccountLogs(ctx context.Context, db kv.Sorter, addr common.Address, offset, limit uint64) (entries []*types.LogEntry, err error) {
+	var res [][]byte
+	res, err = db.SGet(ctx, getLogAddrKey(addr), offset, limit, &kv.ReadOption{Table: share.LogSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	return byteToLogEntries(res)
+}
+
+func GetAccountLogCount(ctx context.Context, db kv.Sorter, addr common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getLogAddrKey(addr), &kv.ReadOption{Table: share.LogSortTabl})
+}
+
+// FilterLogs returns the logs matching f in block order. The index of the filter with the
+// fewest logs is scanned and the others are checked for every entry of it. The total is
+// capped at maxFilterLogs.
+func FilterLogs(ctx context.Context, db kv.Sorter, f *types.LogFilter, offset, limit uint64) (entries []*types.LogEntry, total uint64, err error) {
+	keys := make([][]byte, 0)
+	switch {
+	case f.Address != nil && f.Topics[0] != nil:
+		keys = append(keys, getLogAddrTopicKey(*f.Address, *f.Topics[0]))
+	case f.Address != nil:
+		keys = append(keys, getLogAddrKey(*f.Address))
+	}
+	for i, topic := range f.Topics {
+		if topic != nil && (i > 0 || f.Address == nil) {
+			keys = append(keys, getLogTopicKey(i, *topic))
+		}
+	}
+	entries = make([]*types.LogEntry, 0)
+	if len(keys) == 0 {
+		return entries, 0, nil
+	}
+
+	var (
+		opts = &kv.ReadOption{Table: share.LogSortTabl}
+		scan int
+		min  uint64
+	)
+	for i, key := range keys {
+		count, err := db.SCount(ctx, key, opts)
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				return entries, 0, nil
+			}
+			return nil, 0, err
+		}
+		if i == 0 || count < min {
+			scan, min = i, count
+		}
+	}
+
+	from := make([]byte, 8)
+	binary.BigEndian.PutUint64(from, f.FromBlock)
+	for total < maxFilterLogs {
+		res, err := db.SRange(ctx, keys[scan], from, filterLogBatch, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, v := range res {
+			entry, err := types.ByteToLogEntry(v)
+			if err != nil {
+				return nil, 0, err
+			}
+			if entry.BlockNumber > f.ToBlock {
+				return entries, total, nil
+			}
+			match := true
+			for i, key := range keys {
+				if i == scan {
+					continue
+				}
+				if match, err = db.SHas(ctx, key, v, opts); err != nil {
+					return nil, 0, err
+				}
+				if !match {
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			if total >= offset && total < offset+limit {
+				entries = append(entries, entry)
+			}
+			if total++; total == maxFilterLogs {
+				break
+			}
+		}
+		if len(res) < filterLogBatch {
+			break
+		}
+		// the next batch starts right after the last entry
+		from = append(append([]byte{}, res[len(res)-1]...), 0)
+	}
+	return entries, total, nil
+}
+
+func byteToLogEntries(res [][]byte) (entries []*types.LogEntry, err error) {
+	entries = make([]*types.LogEntry, len(res))
+	for i, v := range res {
+		entries[i], err = types.ByteToLogEntry(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
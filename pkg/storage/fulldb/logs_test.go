@@ -0,0 +1,69 @@
+package fulldb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestFilterLogs(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{}, []string{share.LogSortTabl})
+		token    = common.HexToAddress("0x1")
+		other    = common.HexToAddress("0x2")
+		transfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+		approval = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+		alice    = common.HexToHash("0xa")
+		bob      = common.HexToHash("0xb")
+	)
+	write := func(block, index uint64, addr common.Address, topics ...common.Hash) {
+		l := &types.Log{Address: addr, Topics: topics}
+		assert.NoError(t, WriteLog(ctx, db, l, &types.LogEntry{BlockNumber: block, LogIndex: index}))
+	}
+	write(10, 0, token, transfer, alice, bob)
+	write(10, 1, token, approval, alice, bob)
+	write(11, 0, other, transfer, bob, alice)
+	write(12, 3, token, transfer, bob, alice)
+	write(12, 4, token, transfer, alice, alice)
+
+	filter := func(f *types.LogFilter, offset, limit uint64) ([]*types.LogEntry, uint64) {
+		if f.ToBlock == 0 {
+			f.ToBlock = math.MaxUint64
+		}
+		entries, total, err := FilterLogs(ctx, db, f, offset, limit)
+		assert.NoError(t, err)
+		return entries, total
+	}
+
+	entries, total := filter(&types.LogFilter{Address: &token}, 0, 10)
+	assert.Equal(t, uint64(4), total)
+	assert.Equal(t, uint64(10), entries[0].BlockNumber)
+	assert.Equal(t, uint64(4), entries[3].LogIndex)
+
+	entries, total = filter(&types.LogFilter{Address: &token, Topics: [4]*common.Hash{&transfer}}, 1, 1)
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, uint64(12), entries[0].BlockNumber)
+
+	_, total = filter(&types.LogFilter{Topics: [4]*common.Hash{&transfer, nil, &alice}}, 0, 10)
+	assert.Equal(t, uint64(3), total)
+
+	_, total = filter(&types.LogFilter{Topics: [4]*common.Hash{&transfer}, FromBlock: 11, ToBlock: 11}, 0, 10)
+	assert.Equal(t, uint64(1), total)
+
+	missing := common.HexToHash("0xc")
+	_, total = filter(&types.LogFilter{Address: &token, Topics: [4]*common.Hash{nil, &missing}}, 0, 10)
+	assert.Equal(t, uint64(0), total)
+
+	entries, err := ListAccountLogs(ctx, db, token, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), entries[0].BlockNumber)
+	assert.Equal(t, uint64(4), entries[0].LogIndex)
+}
@@ -0,0 +1,127 @@
+package fulldb
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	nftErc721TransferPrefix  = []byte("/nft/erc721/")
+	nftErc1155TransferPrefix = []byte("/nft/erc1155/")
+	nftMetadataPrefix        = []byte("/nft/metadata/")
+)
+
+/*
+	// key = value
+	/nft/metadata/<contract><tokenId> => cached metadata
+
+	// key = > sort
+	/nft/erc721/<contract><tokenId> => erc721 transfer index
+	/nft/erc1155/<contract><tokenId> => erc1155 transfer index
+*/
+
+func getNftKey(prefix []byte, contract common.Address, tokenId *field.BigInt) []byte {
+	key := make([]byte, 0, len(prefix)+common.AddressLength+common.HashLength)
+	key = append(key, prefix...)
+	key = append(key, contract.Bytes()...)
+	return append(key, common.BytesToHash(tokenId.Bytes()).Bytes()...)
+}
+
+// WriteErc721TokenTransfer indexes an erc721 transfer, by its index in the erc721 transfers, for its token id
+func WriteErc721TokenTransfer(ctx context.Context, db kv.Sorter, contract common.Address, tokenId, index *field.BigInt) error {
+	return writeNftTransfer(ctx, db, getNftKey(nftErc721TransferPrefix, contract, tokenId), index)
+}
+
+// ListErc721TokenTransfers returns the indexes of the transfers of an erc721 token id, the latest first
+func ListErc721TokenTransfers(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt, offset, limit uint64) ([]*field.BigInt, error) {
+	return listNftTransfers(ctx, db, getNftKey(nftErc721TransferPrefix, contract, tokenId), offset, limit)
+}
+
+func GetErc721TokenTransferCount(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return db.SCount(ctx, getNftKey(nftErc721TransferPrefix, contract, tokenId), &kv.ReadOption{Table: share.NftSortTabl})
+}
+
+// WriteErc1155TokenTransfer indexes an erc1155 transfer, by its index in the erc1155 transfers, for its token id
+func WriteErc1155TokenTransfer(ctx context.Context, db kv.Sorter, contract common.Address, tokenId, index *field.BigInt) error {
+	return writeNftTransfer(ctx, db, getNftKey(nftErc1155TransferPrefix, contract, tokenId), index)
+}
+
+// ListErc1155TokenTransfers returns the indexes of the transfers of an erc1155 token id, the latest first
+func ListErc1155TokenTransfers(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt, offset, limit uint64) ([]*field.BigInt, error) {
+	return listNftTransfers(ctx, db, getNftKey(nftErc1155TransferPrefix, contract, tokenId), offset, limit)
+}
+
+func GetErc1155TokenTransferCount(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return db.SCount(ctx, getNftKey(nftErc1155TransferPrefix, contract, tokenId), &kv.ReadOption{Table: share.NftSortTabl})
+}
+
+func writeNftTransfer(ctx context.Context, db kv.Sorter, key []byte, index *field.BigInt) error {
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, index.ToUint64())
+	return db.SPut(ctx, key, val, &kv.WriteOption{Table: share.NftSortTabl})
+}
+
+func listNftTransfers(ctx context.Context, db kv.Sorter, key []byte, offset, limit uint64) (indexes []*field.BigInt, err error) {
+	var res [][]byte
+	res, err = db.SGet(ctx, key, offset, limit, &kv.ReadOption{Table: share.NftSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	indexes = make([]*field.BigInt, len(res))
+	for i, v := range res {
+		if len(v) != 8 {
+			return nil, types.ErrorInvalidByte
+		}
+		indexes[i] = field.NewInt(int64(binary.BigEndian.Uint64(v)))
+	}
+	return
+}
+
+// ReadErc721Owner returns the holder of an erc721 token id from the inventory of the contract
+func ReadErc721Owner(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt) (owner common.Address, err error) {
+	var (
+		key  = append(append(append([]byte{}, erc721HolderPrefix...), contract.Bytes()...), []byte("/tokenId")...)
+		from = common.BytesToHash(tokenId.Bytes()).Bytes()
+		res  [][]byte
+	)
+	res, err = db.SRange(ctx, key, from, 1, &kv.ReadOption{Table: share.InventorySortTabl})
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		return owner, kv.NotFound
+	}
+	inventory, err := types.ByteToInventory(res[0])
+	if err != nil {
+		return
+	}
+	if inventory.TokenID.Cmp(tokenId) != 0 {
+		return owner, kv.NotFound
+	}
+	return inventory.Addr, nil
+}
+
+func ReadNftMetadata(ctx context.Context, db kv.Reader, contract common.Address, tokenId *field.BigInt) (metadata *types.NftMetadata, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, getNftKey(nftMetadataPrefix, contract, tokenId), &kv.ReadOption{Table: share.NftTbl})
+	if err != nil {
+		return
+	}
+	metadata = &types.NftMetadata{}
+	err = metadata.Unmarshal(bytesRes)
+	return
+}
+
+func WriteNftMetadata(ctx context.Context, db kv.Writer, contract common.Address, tokenId *field.BigInt, metadata *types.NftMetadata) error {
+	bytesRes, err := metadata.Marshal()
+	if err != nil {
+		return err
+	}
+	return db.Put(ctx, getNftKey(nftMetadataPrefix, contract, tokenId), bytesRes, &kv.WriteOption{Table: share.NftTbl})
+}
@@ -0,0 +1,71 @@
+package fulldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestNftTransfers(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{}, []string{share.NftSortTabl})
+		contract = common.HexToAddress("0x10")
+	)
+	for _, index := range []int64{3, 7, 300} {
+		assert.NoError(t, WriteErc721TokenTransfer(ctx, db, contract, field.NewInt(1), field.NewInt(index)))
+	}
+	assert.NoError(t, WriteErc721TokenTransfer(ctx, db, contract, field.NewInt(2), field.NewInt(5)))
+
+	count, err := GetErc721TokenTransferCount(ctx, db, contract, field.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+	indexes, err := ListErc721TokenTransfers(ctx, db, contract, field.NewInt(1), 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{300, 7}, []uint64{indexes[0].ToUint64(), indexes[1].ToUint64()})
+
+	count, _ = GetErc1155TokenTransferCount(ctx, db, contract, field.NewInt(1))
+	assert.Equal(t, uint64(0), count)
+}
+
+func TestErc721Owner(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{share.HolderTbl}, []string{share.InventorySortTabl})
+		contract = common.HexToAddress("0x10")
+		owner    = common.HexToAddress("0x1")
+	)
+	assert.NoError(t, WriteErc721HolderTokenIdQuantity(ctx, db, contract, owner, field.NewInt(2), field.NewInt(1)))
+	assert.NoError(t, WriteErc721HolderTokenIdQuantity(ctx, db, contract, owner, field.NewInt(5), field.NewInt(1)))
+
+	res, err := ReadErc721Owner(ctx, db, contract, field.NewInt(5))
+	assert.NoError(t, err)
+	assert.Equal(t, owner, res)
+	_, err = ReadErc721Owner(ctx, db, contract, field.NewInt(3))
+	assert.ErrorIs(t, err, kv.NotFound)
+	_, err = ReadErc721Owner(ctx, db, contract, field.NewInt(6))
+	assert.ErrorIs(t, err, kv.NotFound)
+}
+
+func TestNftMetadata(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{share.NftTbl}, []string{})
+		contract = common.HexToAddress("0x10")
+	)
+	_, err := ReadNftMetadata(ctx, db, contract, field.NewInt(1))
+	assert.ErrorIs(t, err, kv.NotFound)
+
+	assert.NoError(t, WriteNftMetadata(ctx, db, contract, field.NewInt(1), &types.NftMetadata{TokenURI: "ipfs://Qm/1", Data: []byte(`{"name":"one"}`), UpdatedAt: 100}))
+	metadata, err := ReadNftMetadata(ctx, db, contract, field.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "ipfs://Qm/1", metadata.TokenURI)
+	assert.Equal(t, `{"name":"one"}`, string(metadata.Data))
+}
@@ -0,0 +1,136 @@
+package fulldb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	proxyInfoPrefix    = []byte("/proxy/info/")
+	proxyHistoryPrefix = []byte("/proxy/history/")
+)
+
+/*
+	// key = value
+	/proxy/info/<proxy> => latest standard proxy read
+	/proxy/<proxy> => implementation, see WriteProxyContract
+
+	// key = > sort
+	/proxy/history/<proxy> => block number + implementation + tx hash
+*/
+
+func getProxyInfoKey(proxy common.Address) []byte {
+	return append(append(make([]byte, 0, len(proxyInfoPrefix)+common.AddressLength), proxyInfoPrefix...), proxy.Bytes()...)
+}
+
+func getProxyHistoryKey(proxy common.Address) []byte {
+	return append(append(make([]byte, 0, len(proxyHistoryPrefix)+common.AddressLength), proxyHistoryPrefix...), proxy.Bytes()...)
+}
+
+func ReadProxy(ctx context.Context, db kv.Reader, proxy common.Address) (p *types.Proxy, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, getProxyInfoKey(proxy), &kv.ReadOption{Table: share.AccountsTbl})
+	if err != nil {
+		return
+	}
+	p = &types.Proxy{}
+	err = p.Unmarshal(bytesRes)
+	return
+}
+
+// WriteProxy records the implementation of a proxy read at a block. An upgrade is added to the
+// history when the implementation differs from the one before the block, and the proxy
+// contract is only replaced when no later read has been written, so backfills keep the latest.
+func WriteProxy(ctx context.Context, db kv.Database, proxy common.Address, p *types.Proxy) (err error) {
+	if err = writeProxyUpgrade(ctx, db, proxy, &types.ProxyUpgrade{
+		BlockNumber:    p.BlockNumber,
+		Implementation: p.Implementation,
+		TxHash:         p.TxHash,
+	}); err != nil {
+		return err
+	}
+
+	var last *types.Proxy
+	last, err = ReadProxy(ctx, db, proxy)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if last != nil && last.BlockNumber > p.BlockNumber {
+		return nil
+	}
+	var bytesRes []byte
+	if bytesRes, err = p.Marshal(); err != nil {
+		return err
+	}
+	if err = db.Put(ctx, getProxyInfoKey(proxy), bytesRes, &kv.WriteOption{Table: share.AccountsTbl}); err != nil {
+		return err
+	}
+	return WriteProxyContract(ctx, db, proxy, p.Implementation)
+}
+
+func writeProxyUpgrade(ctx context.Context, db kv.Database, proxy common.Address, upgrade *types.ProxyUpgrade) (err error) {
+	key := getProxyHistoryKey(proxy)
+	floor := (&types.ProxyUpgrade{BlockNumber: upgrade.BlockNumber + 1}).ToBytes()
+
+	var prev []byte
+	prev, err = db.SFloor(ctx, key, floor, &kv.ReadOption{Table: share.ProxySortTabl})
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if prev != nil {
+		last, err := types.ByteToProxyUpgrade(prev)
+		if err != nil {
+			return err
+		}
+		if last.Implementation == upgrade.Implementation {
+			return nil
+		}
+	}
+	if err = db.SPut(ctx, key, upgrade.ToBytes(), &kv.WriteOption{Table: share.ProxySortTabl}); err != nil {
+		return err
+	}
+
+	// a backfilled upgrade makes a later entry of the same implementation redundant
+	var next [][]byte
+	next, err = db.SRange(ctx, key, floor, 1, &kv.ReadOption{Table: share.ProxySortTabl})
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if len(next) > 0 {
+		later, err := types.ByteToProxyUpgrade(next[0])
+		if err != nil {
+			return err
+		}
+		if later.Implementation == upgrade.Implementation {
+			return db.SDel(ctx, key, next[0], &kv.WriteOption{Table: share.ProxySortTabl})
+		}
+	}
+	return nil
+}
+
+// ListProxyUpgrades returns the implementation history of a proxy, the latest first
+func ListProxyUpgrades(ctx context.Context, db kv.Sorter, proxy common.Address, offset, limit uint64) (upgrades []*types.ProxyUpgrade, err error) {
+	var res [][]byte
+	res, err = db.SGet(ctx, getProxyHistoryKey(proxy), offset, limit, &kv.ReadOption{Table: share.ProxySortTabl})
+	if err != nil {
+		return nil, err
+	}
+	upgrades = make([]*types.ProxyUpgrade, 0, len(res))
+	for _, v := range res {
+		u, err := types.ByteToProxyUpgrade(v)
+		if err != nil {
+			return nil, err
+		}
+		upgrades = append(upgrades, u)
+	}
+	return
+}
+
+func GetProxyUpgradeCount(ctx context.Context, db kv.Sorter, proxy common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getProxyHistoryKey(proxy), &kv.ReadOption{Table: share.ProxySortTabl})
+}
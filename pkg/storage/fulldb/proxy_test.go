@@ -0,0 +1,57 @@
+package fulldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestProxyUpgrades(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		db    = mdbx.NewMdbx(t.TempDir(), []string{share.AccountsTbl}, []string{share.ProxySortTabl})
+		proxy = common.HexToAddress("0x1")
+		implA = common.HexToAddress("0xa")
+		implB = common.HexToAddress("0xb")
+	)
+	assert.NoError(t, WriteProxy(ctx, db, proxy, &types.Proxy{Kind: types.ProxyEIP1967, Implementation: implA, BlockNumber: 10}))
+	// the same implementation read again is not an upgrade
+	assert.NoError(t, WriteProxy(ctx, db, proxy, &types.Proxy{Kind: types.ProxyEIP1967, Implementation: implA, BlockNumber: 12}))
+	assert.NoError(t, WriteProxy(ctx, db, proxy, &types.Proxy{Kind: types.ProxyEIP1967, Implementation: implB, BlockNumber: 20, TxHash: common.HexToHash("0x20")}))
+
+	count, err := GetProxyUpgradeCount(ctx, db, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	upgrades, err := ListProxyUpgrades(ctx, db, proxy, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(upgrades))
+	assert.Equal(t, implB, upgrades[0].Implementation)
+	assert.Equal(t, common.HexToHash("0x20"), upgrades[0].TxHash)
+	assert.Equal(t, implA, upgrades[1].Implementation)
+
+	// a backfilled earlier read is added to the history but does not replace the latest
+	assert.NoError(t, WriteProxy(ctx, db, proxy, &types.Proxy{Kind: types.ProxyEIP1967, Implementation: implB, BlockNumber: 5}))
+	upgrades, err = ListProxyUpgrades(ctx, db, proxy, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(upgrades))
+	assert.Equal(t, uint64(5), upgrades[2].BlockNumber)
+	logic, err := ReadProxyContract(ctx, db, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, implB, logic)
+	p, err := ReadProxy(ctx, db, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), p.BlockNumber)
+
+	// an earlier upgrade to the same implementation replaces the later entry
+	assert.NoError(t, WriteProxy(ctx, db, proxy, &types.Proxy{Kind: types.ProxyEIP1967, Implementation: implB, BlockNumber: 15}))
+	upgrades, err = ListProxyUpgrades(ctx, db, proxy, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(upgrades))
+	assert.Equal(t, uint64(15), upgrades[0].BlockNumber)
+	assert.Equal(t, implB, upgrades[0].Implementation)
+}
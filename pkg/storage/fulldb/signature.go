@@ -0,0 +1,66 @@
+package fulldb
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	functionSignaturePrefix = []byte("/signature/function/")
+	eventSignaturePrefix    = []byte("/signature/event/")
+)
+
+// candidates read for a selector, colliding signatures are rare
+const maxSignatureCandidates = 16
+
+/*
+	// key = > sort
+	// every text signature of a selector, colliding ones are all kept
+	/signature/function/<4 bytes selector> => text signature
+	/signature/event/<topic> => text signature
+*/
+
+func getFunctionSignatureKey(selector []byte) []byte {
+	key := make([]byte, 0, len(functionSignaturePrefix)+4)
+	key = append(key, functionSignaturePrefix...)
+	return append(key, selector...)
+}
+
+func getEventSignatureKey(topic common.Hash) []byte {
+	key := make([]byte, 0, len(eventSignaturePrefix)+common.HashLength)
+	key = append(key, eventSignaturePrefix...)
+	return append(key, topic.Bytes()...)
+}
+
+func WriteFunctionSignature(ctx context.Context, db kv.Sorter, selector []byte, signature string) error {
+	return db.SPut(ctx, getFunctionSignatureKey(selector), []byte(signature), &kv.WriteOption{Table: share.SignatureSortTabl})
+}
+
+// ReadFunctionSignatures returns the text signatures of a 4 bytes selector
+func ReadFunctionSignatures(ctx context.Context, db kv.Sorter, selector []byte) ([]string, error) {
+	return readSignatures(ctx, db, getFunctionSignatureKey(selector))
+}
+
+func WriteEventSignature(ctx context.Context, db kv.Sorter, topic common.Hash, signature string) error {
+	return db.SPut(ctx, getEventSignatureKey(topic), []byte(signature), &kv.WriteOption{Table: share.SignatureSortTabl})
+}
+
+// ReadEventSignatures returns the text signatures of an event topic
+func ReadEventSignatures(ctx context.Context, db kv.Sorter, topic common.Hash) ([]string, error) {
+	return readSignatures(ctx, db, getEventSignatureKey(topic))
+}
+
+func readSignatures(ctx context.Context, db kv.Sorter, key []byte) ([]string, error) {
+	res, err := db.SGet(ctx, key, 0, maxSignatureCandidates, &kv.ReadOption{Table: share.SignatureSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]string, len(res))
+	for i, v := range res {
+		signatures[i] = string(v)
+	}
+	return signatures, nil
+}
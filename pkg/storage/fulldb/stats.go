@@ -0,0 +1,73 @@
+package fulldb
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	dailyStatsPrefix  = []byte("/stats/daily/")
+	dailyActivePrefix = []byte("/stats/active/")
+	dailyBlocksPrefix = []byte("/stats/blocks/")
+)
+
+/*
+	// key = value
+	/stats/daily/<yyyymmdd> => daily stats
+
+	// key = > sort
+	/stats/active/<yyyymmdd> => address
+	/stats/blocks/<yyyymmdd> => block number
+*/
+
+func getDailyKey(prefix []byte, date string) []byte {
+	key := make([]byte, 0, len(prefix)+len(date))
+	key = append(key, prefix...)
+	return append(key, date...)
+}
+
+func ReadDailyStats(ctx context.Context, db kv.Reader, date string) (stats *types.DailyStats, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, getDailyKey(dailyStatsPrefix, date), &kv.ReadOption{Table: share.StatsTbl})
+	if err != nil {
+		return
+	}
+	stats = &types.DailyStats{}
+	err = stats.Unmarshal(bytesRes)
+	return
+}
+
+func WriteDailyStats(ctx context.Context, db kv.Writer, stats *types.DailyStats) (err error) {
+	var bytesRes []byte
+	bytesRes, err = stats.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, getDailyKey(dailyStatsPrefix, stats.Date), bytesRes, &kv.WriteOption{Table: share.StatsTbl})
+}
+
+// AddDailyActiveAddress records addr as active on the date, added is false when it was already
+func AddDailyActiveAddress(ctx context.Context, db kv.Sorter, date string, addr common.Address) (added bool, err error) {
+	return addDaily(ctx, db, getDailyKey(dailyActivePrefix, date), addr.Bytes())
+}
+
+// AddDailyBlock records a block as counted in the stats of the date, added is false when it was already
+func AddDailyBlock(ctx context.Context, db kv.Sorter, date string, blockNum uint64) (added bool, err error) {
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, blockNum)
+	return addDaily(ctx, db, getDailyKey(dailyBlocksPrefix, date), val)
+}
+
+func addDaily(ctx context.Context, db kv.Sorter, key, val []byte) (added bool, err error) {
+	var has bool
+	has, err = db.SHas(ctx, key, val, &kv.ReadOption{Table: share.StatsSortTabl})
+	if err != nil || has {
+		return false, err
+	}
+	return true, db.SPut(ctx, key, val, &kv.WriteOption{Table: share.StatsSortTabl})
+}
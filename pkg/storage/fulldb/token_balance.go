@@ -0,0 +1,114 @@
+package fulldb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+/*
+	// key = > sort
+	// balance history, one entry per block
+	/erc20/<contract>/<address> => block number + timestamp + amount
+	/erc1155/<contract>/<tokenId>/<address> => block number + timestamp + quantity
+
+	// every address which has held the token
+	/erc20/<contract> => address
+	/erc1155/<contract>/<tokenId> => address
+*/
+
+func getErc20BalanceHoldersKey(contract common.Address) []byte {
+	key := make([]byte, 0, len(erc20HolderPrefix)+common.AddressLength)
+	key = append(key, erc20HolderPrefix...)
+	return append(key, contract.Bytes()...)
+}
+
+func getErc20BalanceKey(contract, addr common.Address) []byte {
+	return append(getErc20BalanceHoldersKey(contract), addr.Bytes()...)
+}
+
+func getErc1155BalanceHoldersKey(contract common.Address, tokenId *field.BigInt) []byte {
+	key := make([]byte, 0, len(erc1155HolderPrefix)+common.AddressLength+common.HashLength+1)
+	key = append(key, erc1155HolderPrefix...)
+	key = append(key, contract.Bytes()...)
+	key = append(key, '/')
+	return append(key, common.BytesToHash(tokenId.Bytes()).Bytes()...)
+}
+
+func getErc1155BalanceKey(contract common.Address, tokenId *field.BigInt, addr common.Address) []byte {
+	return append(append(getErc1155BalanceHoldersKey(contract, tokenId), '/'), addr.Bytes()...)
+}
+
+// WriteErc20BalanceChange records the amount of addr at the end of a block, it replaces an earlier change of the same block
+func WriteErc20BalanceChange(ctx context.Context, db kv.Sorter, contract, addr common.Address, change *types.BalanceChange) (err error) {
+	return writeTokenBalanceChange(ctx, db, getErc20BalanceKey(contract, addr), getErc20BalanceHoldersKey(contract), addr, change)
+}
+
+func ReadErc20BalanceAt(ctx context.Context, db kv.Sorter, contract, addr common.Address, blockNum uint64) (change *types.BalanceChange, err error) {
+	return readBalanceChangeAt(ctx, db, getErc20BalanceKey(contract, addr), blockNum, share.TokenBalanceSortTabl)
+}
+
+// GetErc20BalanceHolders returns the addresses which have held the token since the start block,
+// in address order starting at from
+func GetErc20BalanceHolders(ctx context.Context, db kv.Sorter, contract, from common.Address, limit uint64) (addrs []common.Address, err error) {
+	return getTokenBalanceHolders(ctx, db, getErc20BalanceHoldersKey(contract), from, limit)
+}
+
+func GetErc20BalanceHolderCount(ctx context.Context, db kv.Sorter, contract common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getErc20BalanceHoldersKey(contract), &kv.ReadOption{Table: share.TokenBalanceSortTabl})
+}
+
+// WriteErc1155BalanceChange records the quantity of a token id of addr at the end of a block,
+// it replaces an earlier change of the same block
+func WriteErc1155BalanceChange(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt, addr common.Address, change *types.BalanceChange) (err error) {
+	return writeTokenBalanceChange(ctx, db, getErc1155BalanceKey(contract, tokenId, addr), getErc1155BalanceHoldersKey(contract, tokenId), addr, change)
+}
+
+func ReadErc1155BalanceAt(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt, addr common.Address, blockNum uint64) (change *types.BalanceChange, err error) {
+	return readBalanceChangeAt(ctx, db, getErc1155BalanceKey(contract, tokenId, addr), blockNum, share.TokenBalanceSortTabl)
+}
+
+// GetErc1155BalanceHolders returns the addresses which have held the token id since the start block,
+// in address order starting at from
+func GetErc1155BalanceHolders(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt, from common.Address, limit uint64) (addrs []common.Address, err error) {
+	return getTokenBalanceHolders(ctx, db, getErc1155BalanceHoldersKey(contract, tokenId), from, limit)
+}
+
+func GetErc1155BalanceHolderCount(ctx context.Context, db kv.Sorter, contract common.Address, tokenId *field.BigInt) (count uint64, err error) {
+	return db.SCount(ctx, getErc1155BalanceHoldersKey(contract, tokenId), &kv.ReadOption{Table: share.TokenBalanceSortTabl})
+}
+
+func writeTokenBalanceChange(ctx context.Context, db kv.Sorter, key, holdersKey []byte, addr common.Address, change *types.BalanceChange) (err error) {
+	var last *types.BalanceChange
+	last, err = readBalanceChangeAt(ctx, db, key, change.BlockNumber, share.TokenBalanceSortTabl)
+	if err != nil && !errors.Is(err, kv.NotFound) {
+		return err
+	}
+	if last != nil && last.BlockNumber == change.BlockNumber {
+		if err = db.SDel(ctx, key, last.ToBytes(), &kv.WriteOption{Table: share.TokenBalanceSortTabl}); err != nil {
+			return err
+		}
+	}
+	if err = db.SPut(ctx, key, change.ToBytes(), &kv.WriteOption{Table: share.TokenBalanceSortTabl}); err != nil {
+		return err
+	}
+	return db.SPut(ctx, holdersKey, addr.Bytes(), &kv.WriteOption{Table: share.TokenBalanceSortTabl})
+}
+
+func getTokenBalanceHolders(ctx context.Context, db kv.Sorter, key []byte, from common.Address, limit uint64) (addrs []common.Address, err error) {
+	var res [][]byte
+	res, err = db.SRange(ctx, key, from.Bytes(), limit, &kv.ReadOption{Table: share.TokenBalanceSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	addrs = make([]common.Address, len(res))
+	for i, v := range res {
+		addrs[i] = common.BytesToAddress(v)
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package fulldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestErc20BalanceChange(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		db       = mdbx.NewMdbx(t.TempDir(), []string{}, []string{share.TokenBalanceSortTabl})
+		contract = common.HexToAddress("0x1")
+		holder   = common.HexToAddress("0x2")
+	)
+	write := func(block uint64, balance int64) {
+		assert.NoError(t, WriteErc20BalanceChange(ctx, db, contract, holder, &types.BalanceChange{BlockNumber: block, Balance: *field.NewInt(balance)}))
+	}
+	write(10, 100)
+	write(20, 300)
+	write(20, 50) // the last change of a block replaces the earlier ones
+
+	_, err := ReadErc20BalanceAt(ctx, db, contract, holder, 9)
+	assert.Error(t, err)
+	for block, balance := range map[uint64]int64{10: 100, 19: 100, 20: 50, 100: 50} {
+		change, err := ReadErc20BalanceAt(ctx, db, contract, holder, block)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, change.Balance.Cmp(field.NewInt(balance)), "block %d", block)
+	}
+
+	holders, err := GetErc20BalanceHolders(ctx, db, contract, common.Address{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Address{holder}, holders)
+
+	other := common.HexToAddress("0x3")
+	assert.NoError(t, WriteErc20BalanceChange(ctx, db, contract, other, &types.BalanceChange{BlockNumber: 10, Balance: *field.NewInt(1)}))
+	holders, err = GetErc20BalanceHolders(ctx, db, contract, other, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Address{other}, holders)
+}
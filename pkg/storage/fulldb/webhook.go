@@ -0,0 +1,142 @@
+package fulldb
+
+import (
+	"context"
+
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	webhookTotalKey       = []byte("/webhook/total")
+	webhookInfoKey        = []byte("/webhook/info/")
+	webhookKey            = []byte("/webhook/")
+	webhookDeliveryKey    = []byte("/delivery/")
+	webhookUnconfirmedKey = []byte("/webhook/unconfirmed/")
+)
+
+/*
+table: webhook
+
+/webhook/total => last webhook id
+/webhook/info/<id> => webhook
+
+/webhook/<id>/delivery/total => num
+/webhook/<id>/delivery/<index> => delivery
+
+/webhook/unconfirmed/<block num> => webhooks notified about the fork block
+*/
+
+// deliveries are written by several goroutines, the key is built on a new slice
+// so that they never append to the same backing array
+func webhookDeliveryKeyOf(id *field.BigInt, suffix []byte) []byte {
+	key := make([]byte, 0, len(webhookKey)+len(id.Bytes())+len(webhookDeliveryKey)+len(suffix))
+	key = append(key, webhookKey...)
+	key = append(key, id.Bytes()...)
+	key = append(key, webhookDeliveryKey...)
+	return append(key, suffix...)
+}
+
+func ReadWebhookTotal(ctx context.Context, db kv.Reader) (total *field.BigInt, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, webhookTotalKey, &kv.ReadOption{Table: share.WebhookTbl})
+	if err != nil {
+		return
+	}
+	total = &field.BigInt{}
+	total.SetBytes(bytesRes)
+	return
+}
+
+func WriteWebhookTotal(ctx context.Context, db kv.Writer, total *field.BigInt) (err error) {
+	return db.Put(ctx, webhookTotalKey, total.Bytes(), &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func ReadWebhook(ctx context.Context, db kv.Reader, id *field.BigInt) (hook *types.Webhook, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, append(webhookInfoKey, id.Bytes()...), &kv.ReadOption{Table: share.WebhookTbl})
+	if err != nil {
+		return
+	}
+	hook = &types.Webhook{}
+	err = hook.Unmarshal(bytesRes)
+	return
+}
+
+func WriteWebhook(ctx context.Context, db kv.Writer, hook *types.Webhook) (err error) {
+	var bytesRes []byte
+	bytesRes, err = hook.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, append(webhookInfoKey, hook.ID.Bytes()...), bytesRes, &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func DeleteWebhook(ctx context.Context, db kv.Writer, id *field.BigInt) (err error) {
+	return db.Del(ctx, append(webhookInfoKey, id.Bytes()...), &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func ReadWebhookDeliveryTotal(ctx context.Context, db kv.Reader, id *field.BigInt) (total *field.BigInt, err error) {
+	key := webhookDeliveryKeyOf(id, []byte("total"))
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, key, &kv.ReadOption{Table: share.WebhookTbl})
+	if err != nil {
+		return
+	}
+	total = &field.BigInt{}
+	total.SetBytes(bytesRes)
+	return
+}
+
+func WriteWebhookDeliveryTotal(ctx context.Context, db kv.Writer, id *field.BigInt, total *field.BigInt) (err error) {
+	key := webhookDeliveryKeyOf(id, []byte("total"))
+	return db.Put(ctx, key, total.Bytes(), &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func ReadWebhookDelivery(ctx context.Context, db kv.Reader, id *field.BigInt, index *field.BigInt) (data *types.WebhookDelivery, err error) {
+	key := webhookDeliveryKeyOf(id, index.Bytes())
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, key, &kv.ReadOption{Table: share.WebhookTbl})
+	if err != nil {
+		return
+	}
+	data = &types.WebhookDelivery{}
+	err = data.Unmarshal(bytesRes)
+	return
+}
+
+func WriteWebhookDelivery(ctx context.Context, db kv.Writer, id *field.BigInt, index *field.BigInt, data *types.WebhookDelivery) (err error) {
+	key := webhookDeliveryKeyOf(id, index.Bytes())
+	var bytesRes []byte
+	bytesRes, err = data.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, key, bytesRes, &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func ReadWebhookUnconfirmed(ctx context.Context, db kv.Reader, blockNum *field.BigInt) (data *types.WebhookUnconfirmed, err error) {
+	var bytesRes []byte
+	bytesRes, err = db.Get(ctx, append(webhookUnconfirmedKey, blockNum.Bytes()...), &kv.ReadOption{Table: share.WebhookTbl})
+	if err != nil {
+		return
+	}
+	data = &types.WebhookUnconfirmed{}
+	err = data.Unmarshal(bytesRes)
+	return
+}
+
+func WriteWebhookUnconfirmed(ctx context.Context, db kv.Writer, blockNum *field.BigInt, data *types.WebhookUnconfirmed) (err error) {
+	var bytesRes []byte
+	bytesRes, err = data.Marshal()
+	if err != nil {
+		return
+	}
+	return db.Put(ctx, append(webhookUnconfirmedKey, blockNum.Bytes()...), bytesRes, &kv.WriteOption{Table: share.WebhookTbl})
+}
+
+func DeleteWebhookUnconfirmed(ctx context.Context, db kv.Writer, blockNum *field.BigInt) (err error) {
+	return db.Del(ctx, append(webhookUnconfirmedKey, blockNum.Bytes()...), &kv.WriteOption{Table: share.WebhookTbl})
+}
@@ -0,0 +1,51 @@
+package fulldb
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+var (
+	withdrawalPrefix = []byte("/withdrawal/")
+)
+
+/*
+	// key = > sort
+	/withdrawal/<address> => index + block number + timestamp + validator index + amount
+*/
+
+func getWithdrawalKey(addr common.Address) []byte {
+	key := make([]byte, 0, len(withdrawalPrefix)+common.AddressLength)
+	key = append(key, withdrawalPrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+// WriteAccountWithdrawal indexes a withdrawal for its recipient, writing it again is a no-op
+func WriteAccountWithdrawal(ctx context.Context, db kv.Sorter, addr common.Address, w *types.AccountWithdrawal) (err error) {
+	return db.SPut(ctx, getWithdrawalKey(addr), w.ToBytes(), &kv.WriteOption{Table: share.WithdrawalSortTabl})
+}
+
+// ListAccountWithdrawals returns the withdrawals to addr, the latest first
+func ListAccountWithdrawals(ctx context.Context, db kv.Sorter, addr common.Address, offset, limit uint64) (ws []*types.AccountWithdrawal, err error) {
+	var res [][]byte
+	res, err = db.SGet(ctx, getWithdrawalKey(addr), offset, limit, &kv.ReadOption{Table: share.WithdrawalSortTabl})
+	if err != nil {
+		return nil, err
+	}
+	ws = make([]*types.AccountWithdrawal, len(res))
+	for i, v := range res {
+		ws[i], err = types.ByteToAccountWithdrawal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func GetAccountWithdrawalCount(ctx context.Context, db kv.Sorter, addr common.Address) (count uint64, err error) {
+	return db.SCount(ctx, getWithdrawalKey(addr), &kv.ReadOption{Table: share.WithdrawalSortTabl})
+}
@@ -0,0 +1,35 @@
+package fulldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/share"
+)
+
+func TestAccountWithdrawals(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		db   = mdbx.NewMdbx(t.TempDir(), []string{}, []string{share.WithdrawalSortTabl})
+		addr = common.HexToAddress("0x1")
+	)
+	for i := uint64(1); i <= 3; i++ {
+		assert.NoError(t, WriteAccountWithdrawal(ctx, db, addr, &types.AccountWithdrawal{Index: i, BlockNumber: 10 * i, Amount: 100}))
+	}
+	// a backfilled block writes its withdrawals again
+	assert.NoError(t, WriteAccountWithdrawal(ctx, db, addr, &types.AccountWithdrawal{Index: 2, BlockNumber: 20, Amount: 100}))
+
+	count, err := GetAccountWithdrawalCount(ctx, db, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	ws, err := ListAccountWithdrawals(ctx, db, addr, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ws))
+	assert.Equal(t, uint64(3), ws[0].Index)
+	assert.Equal(t, uint64(20), ws[1].BlockNumber)
+}
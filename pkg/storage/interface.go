@@ -60,6 +60,7 @@ type Storage interface {
 
 	ReadHome(ctx context.Context) (home *types.Home, err error)
 	ReadSyncingBlock(ctx context.Context) (bk *field.BigInt, err error)
+	ReadStartBlock(ctx context.Context) (bk *field.BigInt, err error)
 
 	ReadITx(ctx context.Context, hash common.Hash, index *field.BigInt) (data *types.InternalTx, err error)
 	ReadITxTotal(ctx context.Context, hash common.Hash) (total *field.BigInt, err error)
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -11,6 +12,7 @@ import (
 	"github.com/uchainorg/uscan/pkg/field"
 	"github.com/uchainorg/uscan/pkg/kv"
 	"github.com/uchainorg/uscan/pkg/kv/mdbx"
+	"github.com/uchainorg/uscan/pkg/signature"
 	"github.com/uchainorg/uscan/pkg/storage/forkdb"
 	"github.com/uchainorg/uscan/pkg/storage/fulldb"
 	"github.com/uchainorg/uscan/pkg/types"
@@ -50,6 +52,8 @@ func NewStorage(path string) *StorageImpl {
 			share.ForkTraceLogTbl,
 			share.ForkTransferTbl,
 			share.ForkIndexTbl,
+			share.ForkJournalTbl,
+			share.ForkRecordTbl,
 		}, []string{}),
 		FullDB: mdbx.NewMdbx(path, []string{
 			share.AccountsTbl,
@@ -60,9 +64,23 @@ func NewStorage(path string) *StorageImpl {
 			share.TransferTbl,
 			share.HolderTbl,
 			share.ValidateContractTbl,
+			share.JournalTbl,
+			share.WebhookTbl,
+			share.StatsTbl,
+			share.ApprovalTbl,
+			share.NftTbl,
 		}, []string{
 			share.HolderSortTabl,
 			share.InventorySortTabl,
+			share.BalanceSortTabl,
+			share.TokenBalanceSortTabl,
+			share.StatsSortTabl,
+			share.WithdrawalSortTabl,
+			share.LogSortTabl,
+			share.SignatureSortTabl,
+			share.ApprovalSortTabl,
+			share.NftSortTabl,
+			share.ProxySortTabl,
 		}),
 	}
 }
@@ -1148,6 +1166,136 @@ func (s *StorageImpl) ReadSyncingBlock(ctx context.Context) (bk *field.BigInt, e
 	return
 }
 
+// ReadConfirmedBlock returns the last block out of the fork window
+func (s *StorageImpl) ReadConfirmedBlock(ctx context.Context) (bk *field.BigInt, err error) {
+	return fulldb.ReadSyncingBlock(ctx, s.FullDB)
+}
+
+func (s *StorageImpl) ReadBalanceAt(ctx context.Context, addr common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return fulldb.ReadBalanceAt(ctx, s.FullDB, addr, blockNum)
+}
+
+func (s *StorageImpl) ListBalanceChanges(ctx context.Context, addr common.Address, offset, limit uint64) ([]*types.BalanceChange, error) {
+	return fulldb.ListBalanceChanges(ctx, s.FullDB, addr, offset, limit)
+}
+
+func (s *StorageImpl) ReadBalanceChangeCount(ctx context.Context, addr common.Address) (uint64, error) {
+	return fulldb.GetBalanceChangeCount(ctx, s.FullDB, addr)
+}
+
+func (s *StorageImpl) ReadErc20BalanceAt(ctx context.Context, contract, addr common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return fulldb.ReadErc20BalanceAt(ctx, s.FullDB, contract, addr, blockNum)
+}
+
+func (s *StorageImpl) ReadErc1155BalanceAt(ctx context.Context, contract common.Address, tokenId *field.BigInt, addr common.Address, blockNum uint64) (*types.BalanceChange, error) {
+	return fulldb.ReadErc1155BalanceAt(ctx, s.FullDB, contract, tokenId, addr, blockNum)
+}
+
+func (s *StorageImpl) GetErc20BalanceHolders(ctx context.Context, contract, from common.Address, limit uint64) ([]common.Address, error) {
+	return fulldb.GetErc20BalanceHolders(ctx, s.FullDB, contract, from, limit)
+}
+
+func (s *StorageImpl) GetErc1155BalanceHolders(ctx context.Context, contract common.Address, tokenId *field.BigInt, from common.Address, limit uint64) ([]common.Address, error) {
+	return fulldb.GetErc1155BalanceHolders(ctx, s.FullDB, contract, tokenId, from, limit)
+}
+
+func (s *StorageImpl) GetErc1155BalanceHolderCount(ctx context.Context, contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return fulldb.GetErc1155BalanceHolderCount(ctx, s.FullDB, contract, tokenId)
+}
+
+func (s *StorageImpl) ListAccountWithdrawals(ctx context.Context, addr common.Address, offset, limit uint64) ([]*types.AccountWithdrawal, error) {
+	return fulldb.ListAccountWithdrawals(ctx, s.FullDB, addr, offset, limit)
+}
+
+func (s *StorageImpl) ReadAccountWithdrawalCount(ctx context.Context, addr common.Address) (uint64, error) {
+	return fulldb.GetAccountWithdrawalCount(ctx, s.FullDB, addr)
+}
+
+func (s *StorageImpl) FilterLogs(ctx context.Context, filter *types.LogFilter, offset, limit uint64) ([]*types.LogEntry, uint64, error) {
+	return fulldb.FilterLogs(ctx, s.FullDB, filter, offset, limit)
+}
+
+func (s *StorageImpl) ListAccountLogs(ctx context.Context, addr common.Address, offset, limit uint64) ([]*types.LogEntry, error) {
+	return fulldb.ListAccountLogs(ctx, s.FullDB, addr, offset, limit)
+}
+
+func (s *StorageImpl) ReadAccountLogCount(ctx context.Context, addr common.Address) (uint64, error) {
+	return fulldb.GetAccountLogCount(ctx, s.FullDB, addr)
+}
+
+func (s *StorageImpl) ListOwnerApprovals(ctx context.Context, owner common.Address, offset, limit uint64) ([]*types.Approval, error) {
+	return fulldb.ListOwnerApprovals(ctx, s.FullDB, owner, offset, limit)
+}
+
+func (s *StorageImpl) ReadOwnerApprovalCount(ctx context.Context, owner common.Address) (uint64, error) {
+	return fulldb.GetOwnerApprovalCount(ctx, s.FullDB, owner)
+}
+
+func (s *StorageImpl) ListSpenderApprovals(ctx context.Context, spender common.Address, offset, limit uint64) ([]*types.Approval, error) {
+	return fulldb.ListSpenderApprovals(ctx, s.FullDB, spender, offset, limit)
+}
+
+func (s *StorageImpl) ReadSpenderApprovalCount(ctx context.Context, spender common.Address) (uint64, error) {
+	return fulldb.GetSpenderApprovalCount(ctx, s.FullDB, spender)
+}
+
+func (s *StorageImpl) ListErc721TokenTransfers(ctx context.Context, contract common.Address, tokenId *field.BigInt, offset, limit uint64) ([]*field.BigInt, error) {
+	return fulldb.ListErc721TokenTransfers(ctx, s.FullDB, contract, tokenId, offset, limit)
+}
+
+func (s *StorageImpl) ReadErc721TokenTransferCount(ctx context.Context, contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return fulldb.GetErc721TokenTransferCount(ctx, s.FullDB, contract, tokenId)
+}
+
+func (s *StorageImpl) ListErc1155TokenTransfers(ctx context.Context, contract common.Address, tokenId *field.BigInt, offset, limit uint64) ([]*field.BigInt, error) {
+	return fulldb.ListErc1155TokenTransfers(ctx, s.FullDB, contract, tokenId, offset, limit)
+}
+
+func (s *StorageImpl) ReadErc1155TokenTransferCount(ctx context.Context, contract common.Address, tokenId *field.BigInt) (uint64, error) {
+	return fulldb.GetErc1155TokenTransferCount(ctx, s.FullDB, contract, tokenId)
+}
+
+func (s *StorageImpl) ReadErc721Owner(ctx context.Context, contract common.Address, tokenId *field.BigInt) (common.Address, error) {
+	return fulldb.ReadErc721Owner(ctx, s.FullDB, contract, tokenId)
+}
+
+func (s *StorageImpl) ReadNftMetadata(ctx context.Context, contract common.Address, tokenId *field.BigInt) (*types.NftMetadata, error) {
+	return fulldb.ReadNftMetadata(ctx, s.FullDB, contract, tokenId)
+}
+
+func (s *StorageImpl) WriteNftMetadata(ctx context.Context, contract common.Address, tokenId *field.BigInt, metadata *types.NftMetadata) error {
+	return fulldb.WriteNftMetadata(ctx, s.FullDB, contract, tokenId, metadata)
+}
+
+func (s *StorageImpl) ReadProxy(ctx context.Context, proxy common.Address) (*types.Proxy, error) {
+	return fulldb.ReadProxy(ctx, s.FullDB, proxy)
+}
+
+func (s *StorageImpl) ListProxyUpgrades(ctx context.Context, proxy common.Address, offset, limit uint64) ([]*types.ProxyUpgrade, error) {
+	return fulldb.ListProxyUpgrades(ctx, s.FullDB, proxy, offset, limit)
+}
+
+func (s *StorageImpl) ReadFunctionSignatures(ctx context.Context, selector []byte) ([]string, error) {
+	return fulldb.ReadFunctionSignatures(ctx, s.FullDB, selector)
+}
+
+func (s *StorageImpl) ReadEventSignatures(ctx context.Context, topic common.Hash) ([]string, error) {
+	return fulldb.ReadEventSignatures(ctx, s.FullDB, topic)
+}
+
+// ImportSignatures writes a function or event signature list, see signature.Parse for the formats
+func (s *StorageImpl) ImportSignatures(ctx context.Context, kind string, r io.Reader) (int, error) {
+	return signature.Import(ctx, s.FullDB, kind, r)
+}
+
+func (s *StorageImpl) ReadDailyStats(ctx context.Context, date string) (*types.DailyStats, error) {
+	return fulldb.ReadDailyStats(ctx, s.FullDB, date)
+}
+
+func (s *StorageImpl) ReadStartBlock(ctx context.Context) (bk *field.BigInt, err error) {
+	return fulldb.ReadStartBlock(ctx, s.FullDB)
+}
+
 func (s *StorageImpl) ReadITx(ctx context.Context, hash common.Hash, index *field.BigInt) (data *types.InternalTx, err error) {
 	i := &field.BigInt{}
 	data = &types.InternalTx{}
@@ -0,0 +1,113 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	EventBlock    = "block"
+	EventTx       = "tx"
+	EventTransfer = "transfer"
+	EventReorg    = "reorg"
+
+	TransferErc20   = "erc20"
+	TransferErc721  = "erc721"
+	TransferErc1155 = "erc1155"
+)
+
+// Block is what is pushed for a committed block
+type Block struct {
+	Number    uint64
+	Block     *types.BkSim
+	Txs       []*types.TxSim
+	Transfers []*Transfer
+}
+
+type Transfer struct {
+	Type            string         `json:"type"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	Contract        common.Address `json:"contract"`
+	From            common.Address `json:"from"`
+	To              common.Address `json:"to"`
+	Amount          *field.BigInt  `json:"amount,omitempty"`
+	TokenID         *field.BigInt  `json:"tokenId,omitempty"`
+}
+
+// Filter keeps the txs and transfers of an address or a contract, a zero value keeps everything.
+// Block summaries are always sent, their ids are the points a subscriber resumes from.
+type Filter struct {
+	Address  common.Address
+	Contract common.Address
+}
+
+func (f *Filter) matchTx(tx *types.TxSim) bool {
+	if f.Address != (common.Address{}) && tx.From != f.Address && tx.To != f.Address {
+		return false
+	}
+	if f.Contract != (common.Address{}) && tx.To != f.Contract {
+		return false
+	}
+	return true
+}
+
+func (f *Filter) matchTransfer(t *Transfer) bool {
+	if f.Address != (common.Address{}) && t.From != f.Address && t.To != f.Address {
+		return false
+	}
+	if f.Contract != (common.Address{}) && t.Contract != f.Contract {
+		return false
+	}
+	return true
+}
+
+// Write writes a message as server-sent events. The txs and transfers of a block come first
+// and the block event, carrying the block number as id, comes last, so that Last-Event-ID
+// is only set once the whole block has been received.
+func (s *Subscriber) Write(w io.Writer, m *Message) (err error) {
+	if m.Reorg {
+		return writeEvent(w, EventReorg, &m.Ancestor, map[string]uint64{"ancestor": m.Ancestor})
+	}
+	for _, tx := range m.Block.Txs {
+		if !s.Filter.matchTx(tx) {
+			continue
+		}
+		if err = writeEvent(w, EventTx, nil, tx); err != nil {
+			return err
+		}
+	}
+	for _, t := range m.Block.Transfers {
+		if !s.Filter.matchTransfer(t) {
+			continue
+		}
+		if err = writeEvent(w, EventTransfer, nil, t); err != nil {
+			return err
+		}
+	}
+	return writeEvent(w, EventBlock, &m.Block.Number, m.Block.Block)
+}
+
+// KeepAlive writes a comment, proxies close connections which stay silent
+func KeepAlive(w io.Writer) error {
+	_, err := io.WriteString(w, ": keep-alive\n\n")
+	return err
+}
+
+func writeEvent(w io.Writer, event string, id *uint64, data interface{}) error {
+	bin, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != nil {
+		if _, err = fmt.Fprintf(w, "id: %d\n", *id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, bin)
+	return err
+}
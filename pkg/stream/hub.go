@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// messages kept for a subscriber which has not written them yet, it is dropped when they overflow
+const subscriberBuffer = 256
+
+var ErrResume = errors.New("resume block is out of the stream buffer")
+
+// Message is a committed block or a reorg back to Ancestor
+type Message struct {
+	Block    *Block
+	Reorg    bool
+	Ancestor uint64
+}
+
+type Subscriber struct {
+	C      chan *Message
+	Replay []*Message // buffered messages from the resume block, written before C
+	Filter *Filter
+	closed bool
+}
+
+// Hub fans the committed blocks out to the subscribers. The last blocks are kept in a ring
+// buffer, so that a subscriber which reconnects can resume from a block it has not seen.
+type Hub struct {
+	mu     sync.Mutex
+	size   int
+	blocks []*Block
+	subs   map[*Subscriber]struct{}
+}
+
+func NewHub(size int) *Hub {
+	if size <= 0 {
+		size = 1
+	}
+	return &Hub{
+		size:   size,
+		blocks: make([]*Block, 0, size),
+		subs:   make(map[*Subscriber]struct{}),
+	}
+}
+
+// Subscribe starts a subscription, from is the first block to send and 0 means only the new blocks
+func (h *Hub) Subscribe(from uint64, filter *Filter) (*Subscriber, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscriber{
+		C:      make(chan *Message, subscriberBuffer),
+		Replay: make([]*Message, 0),
+		Filter: filter,
+	}
+	if from > 0 && len(h.blocks) > 0 && from <= h.blocks[len(h.blocks)-1].Number {
+		if from < h.blocks[0].Number {
+			return nil, fmt.Errorf("%w: oldest block: %d", ErrResume, h.blocks[0].Number)
+		}
+		for _, b := range h.blocks[from-h.blocks[0].Number:] {
+			sub.Replay = append(sub.Replay, &Message{Block: b})
+		}
+	}
+	h.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(sub)
+}
+
+// Publish sends a committed block to the subscribers
+func (h *Hub) Publish(b *Block) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// blocks are published in order, anything else means the buffer can not be resumed from
+	if len(h.blocks) > 0 && b.Number != h.blocks[len(h.blocks)-1].Number+1 {
+		h.blocks = h.blocks[:0]
+	}
+	if len(h.blocks) == h.size {
+		copy(h.blocks, h.blocks[1:])
+		h.blocks = h.blocks[:h.size-1]
+	}
+	h.blocks = append(h.blocks, b)
+	h.send(&Message{Block: b})
+}
+
+// Reorg drops the blocks after ancestor and tells the subscribers to discard them
+func (h *Hub) Reorg(ancestor uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for len(h.blocks) > 0 && h.blocks[len(h.blocks)-1].Number > ancestor {
+		h.blocks = h.blocks[:len(h.blocks)-1]
+	}
+	h.send(&Message{Reorg: true, Ancestor: ancestor})
+}
+
+// Close ends all the subscriptions
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		h.drop(sub)
+	}
+	return nil
+}
+
+func (h *Hub) send(m *Message) {
+	for sub := range h.subs {
+		select {
+		case sub.C <- m:
+		default:
+			// too slow, the client reconnects and resumes from its last block
+			h.drop(sub)
+		}
+	}
+}
+
+func (h *Hub) drop(sub *Subscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.C)
+	delete(h.subs, sub)
+}
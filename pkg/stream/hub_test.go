@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+func testBlock(number uint64) *Block {
+	return &Block{
+		Number: number,
+		Block:  &types.BkSim{Number: *field.NewInt(int64(number))},
+		Txs: []*types.TxSim{
+			{From: common.HexToAddress("0x01"), To: common.HexToAddress("0x02")},
+		},
+		Transfers: []*Transfer{
+			{Type: TransferErc20, Contract: common.HexToAddress("0x03"), From: common.HexToAddress("0x02"), To: common.HexToAddress("0x04")},
+		},
+	}
+}
+
+func TestHubResume(t *testing.T) {
+	h := NewHub(3)
+	for i := uint64(1); i <= 5; i++ {
+		h.Publish(testBlock(i))
+	}
+
+	sub, err := h.Subscribe(4, &Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sub.Replay))
+	assert.Equal(t, uint64(4), sub.Replay[0].Block.Number)
+
+	_, err = h.Subscribe(2, &Filter{})
+	assert.True(t, errors.Is(err, ErrResume))
+
+	// a block which has not been published yet
+	sub, err = h.Subscribe(9, &Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(sub.Replay))
+
+	h.Publish(testBlock(6))
+	m := <-sub.C
+	assert.Equal(t, uint64(6), m.Block.Number)
+}
+
+func TestHubReorg(t *testing.T) {
+	h := NewHub(10)
+	for i := uint64(1); i <= 5; i++ {
+		h.Publish(testBlock(i))
+	}
+	sub, err := h.Subscribe(0, &Filter{})
+	assert.NoError(t, err)
+
+	h.Reorg(3)
+	m := <-sub.C
+	assert.True(t, m.Reorg)
+	assert.Equal(t, uint64(3), m.Ancestor)
+
+	h.Publish(testBlock(4))
+	sub, err = h.Subscribe(2, &Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(sub.Replay))
+	assert.Equal(t, uint64(4), sub.Replay[2].Block.Number)
+}
+
+func TestHubDropSlowSubscriber(t *testing.T) {
+	h := NewHub(1)
+	sub, err := h.Subscribe(0, &Filter{})
+	assert.NoError(t, err)
+	for i := uint64(1); i <= subscriberBuffer+1; i++ {
+		h.Publish(testBlock(i))
+	}
+	n := 0
+	for range sub.C {
+		n++
+	}
+	assert.Equal(t, subscriberBuffer, n)
+}
+
+func TestSubscriberWrite(t *testing.T) {
+	sub := &Subscriber{Filter: &Filter{}}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, sub.Write(buf, &Message{Block: testBlock(7)}))
+	out := buf.String()
+	assert.Equal(t, 3, strings.Count(out, "event: "))
+	assert.True(t, strings.HasSuffix(out, "\n\n"))
+	// the id comes with the block event, after the txs and transfers
+	assert.True(t, strings.Index(out, "event: transfer") < strings.Index(out, "id: 7"))
+
+	sub = &Subscriber{Filter: &Filter{Contract: common.HexToAddress("0x03")}}
+	buf.Reset()
+	assert.NoError(t, sub.Write(buf, &Message{Block: testBlock(7)}))
+	out = buf.String()
+	assert.Equal(t, 0, strings.Count(out, "event: tx"))
+	assert.Equal(t, 1, strings.Count(out, "event: transfer"))
+
+	sub = &Subscriber{Filter: &Filter{Address: common.HexToAddress("0x01")}}
+	buf.Reset()
+	assert.NoError(t, sub.Write(buf, &Message{Block: testBlock(7)}))
+	out = buf.String()
+	assert.Equal(t, 1, strings.Count(out, "event: tx"))
+	assert.Equal(t, 0, strings.Count(out, "event: transfer"))
+}
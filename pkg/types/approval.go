@@ -0,0 +1,46 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+// Approval is the latest approval of a spender by an owner on a token, either an erc20
+// allowance or an erc721/erc1155 ApprovalForAll of an operator
+type Approval struct {
+	Token       common.Address
+	Owner       common.Address
+	Spender     common.Address
+	ForAll      bool
+	Amount      field.BigInt // erc20 allowance
+	Approved    bool         // ApprovalForAll
+	BlockNumber uint64
+	LogIndex    uint64
+	TxHash      common.Hash
+	TimeStamp   uint64
+}
+
+func (b *Approval) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *Approval) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
+
+// Active is false once the allowance is set to zero or the operator is revoked
+func (b *Approval) Active() bool {
+	if b.ForAll {
+		return b.Approved
+	}
+	return b.Amount.Cmp(field.NewInt(0)) > 0
+}
+
+// Before reports whether b has been emitted before a
+func (b *Approval) Before(a *Approval) bool {
+	if b.BlockNumber != a.BlockNumber {
+		return b.BlockNumber < a.BlockNumber
+	}
+	return b.LogIndex < a.LogIndex
+}
@@ -54,6 +54,10 @@ type Block struct {
 	TotalDifficulty  field.BigInt   `json:"totalDifficulty"`
 	Transactions     []common.Hash  `json:"transactions" rlp:"-"`
 	TransactionTotal field.BigInt   `json:"-"`
+
+	// post-shanghai, blocks stored before decode without them
+	WithdrawalsRoot common.Hash   `json:"withdrawalsRoot" rlp:"optional"`
+	Withdrawals     []*Withdrawal `json:"withdrawals" rlp:"optional"`
 }
 
 func (b *Block) Marshal() ([]byte, error) {
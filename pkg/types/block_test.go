@@ -75,3 +75,33 @@ func TestBlockJson(t *testing.T) {
 	assert.NoError(t, err)
 	t.Log(string(byteRes))
 }
+
+var testWithdrawalBlock = []byte(`{"baseFeePerGas":"0x7","difficulty":"0x0","extraData":"0xd883010a0d846765746888676f312e31382e32856c696e757800000000000000a7b58a705aae18821c766200af2808640af36197c5d000fba9c1d121708d303f1eb9b8963f5c5fcba5212bd0ede185a723b35ddd3c78150d0072b4ae138f6c0f00","gasLimit":"0x1c9c380","gasUsed":"0x0","hash":"0x9a5cd5c6cfd18e2bc25d0ac7b3e41a1e0bd1d4dc5f6b6c0c1ecf5e3b29ab1d44","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x0000000000000000000000000000000000000000","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","number":"0x10","parentHash":"0x6aa7ec70ebaf19c604b6412bd0e08d574d167f29c6852d41c43f857fa9e3dcd4","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","size":"0x2c1","stateRoot":"0xdb9e3559eef6ebecda75033eb7b0482bfc8ba2a51e97b8c404521fbe4b4bf84b","timestamp":"0x64373f93","transactions":[],"transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","uncles":[],"withdrawals":[{"index":"0x1d","validatorIndex":"0x3a","address":"0x473780deaf4a2ac070bbba936b0cdefe7f267dfc","amount":"0x2cb417800"},{"index":"0x1e","validatorIndex":"0x3b","address":"0x0000000000000000000000000000000000000001","amount":"0x1"}],"withdrawalsRoot":"0x7a4ecf19774d15cf9c15adf0dd8e8a250c128b26c9e2ab2a08d6c9c8ffbd104f"}`)
+
+func TestBlockWithdrawals(t *testing.T) {
+	b := &Block{}
+	err := json.Unmarshal(testWithdrawalBlock, b)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(b.Withdrawals))
+	assert.Equal(t, uint64(0x1d), uint64(b.Withdrawals[0].Index))
+	assert.Equal(t, uint64(0x3a), uint64(b.Withdrawals[0].Validator))
+	assert.Equal(t, common.HexToAddress("0x473780deaf4a2ac070bbba936b0cdefe7f267dfc"), b.Withdrawals[0].Address)
+	assert.Equal(t, uint64(12000000000), uint64(b.Withdrawals[0].Amount))
+
+	res, err := b.Marshal()
+	assert.NoError(t, err)
+	out := &Block{}
+	assert.NoError(t, out.Unmarshal(res))
+	assert.Equal(t, b.WithdrawalsRoot, out.WithdrawalsRoot)
+	assert.Equal(t, b.Withdrawals, out.Withdrawals)
+
+	// blocks stored before withdrawals still decode
+	b.WithdrawalsRoot = common.Hash{}
+	b.Withdrawals = nil
+	res, err = b.Marshal()
+	assert.NoError(t, err)
+	out = &Block{}
+	assert.NoError(t, out.Unmarshal(res))
+	assert.Equal(t, 0, len(out.Withdrawals))
+	assert.Equal(t, b.Hash, out.Hash)
+}
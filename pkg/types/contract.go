@@ -79,6 +79,7 @@ type ContractVerity struct {
 	Metadata        string `json:"metadata"`
 	CodeHash        string `json:"codeHash"`
 	Object          string `json:"object"`
+	Match           string `json:"match" rlp:"optional"` // full match or partial match
 }
 
 func (b *ContractVerity) Marshal() ([]byte, error) {
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractVerityWithoutMatch(t *testing.T) {
+	// records written before the match was stored
+	old := struct {
+		ContractName    string
+		CompilerVersion string
+		Optimization    uint64
+		Runs            uint64
+		EVMVersion      string
+		LicenseType     uint64
+		ABI             string
+		Metadata        string
+		CodeHash        string
+		Object          string
+	}{ContractName: "Token", CodeHash: "0x01"}
+	bin, err := rlp.EncodeToBytes(&old)
+	assert.NoError(t, err)
+
+	out := &ContractVerity{}
+	assert.NoError(t, out.Unmarshal(bin))
+	assert.Equal(t, "Token", out.ContractName)
+	assert.Equal(t, "", out.Match)
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LogEntry points to an event log, it is stored as a sorted value so that the logs
+// of an address or a topic are ordered by block and by their index in the block
+type LogEntry struct {
+	BlockNumber uint64
+	LogIndex    uint64
+	TxHash      common.Hash
+}
+
+func ByteToLogEntry(bin []byte) (*LogEntry, error) {
+	if len(bin) != 48 {
+		return nil, ErrorInvalidByte
+	}
+	e := &LogEntry{}
+	e.BlockNumber = binary.BigEndian.Uint64(bin[:8])
+	e.LogIndex = binary.BigEndian.Uint64(bin[8:16])
+	e.TxHash.SetBytes(bin[16:])
+	return e, nil
+}
+
+func (e LogEntry) ToBytes() []byte {
+	bin := make([]byte, 16, 48)
+	binary.BigEndian.PutUint64(bin[:8], e.BlockNumber)
+	binary.BigEndian.PutUint64(bin[8:16], e.LogIndex)
+	return append(bin, e.TxHash.Bytes()...)
+}
+
+// LogFilter selects the logs of an address and topics in a block range, nil matches anything
+type LogFilter struct {
+	Address   *common.Address
+	Topics    [4]*common.Hash
+	FromBlock uint64
+	ToBlock   uint64
+}
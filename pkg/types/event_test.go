@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogEntry(t *testing.T) {
+	e := &LogEntry{
+		BlockNumber: 300,
+		LogIndex:    7,
+		TxHash:      common.HexToHash("0x5977673bbb2382d0192dd79e96b2dfb9e3f7752374c5680ffb175767da10d8e9"),
+	}
+	bytesRes := e.ToBytes()
+	assert.Equal(t, 48, len(bytesRes))
+
+	out, err := ByteToLogEntry(bytesRes)
+	assert.NoError(t, err)
+	assert.Equal(t, e, out)
+
+	// entries sort by block, then by index
+	later := LogEntry{BlockNumber: 300, LogIndex: 8}
+	assert.True(t, string(later.ToBytes()) > string(bytesRes))
+}
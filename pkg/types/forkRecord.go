@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+type ForkRecordKeys struct {
+	Table string
+	Keys  [][]byte
+}
+
+type ForkRecordCount struct {
+	Key   string
+	Count field.BigInt
+}
+
+// ForkRecord keeps what has to be cleaned up for a fork block once it leaves the fork window
+type ForkRecord struct {
+	Deletes []*ForkRecordKeys  // table => keys
+	Indexes []*ForkRecordCount // key => index
+	Totals  []*ForkRecordCount // table:key => total
+}
+
+func (b *ForkRecord) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *ForkRecord) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
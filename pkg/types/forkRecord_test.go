@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+func TestForkRecordMarshal(t *testing.T) {
+	r := &ForkRecord{
+		Deletes: []*ForkRecordKeys{
+			{
+				Table: "fork_transactions",
+				Keys:  [][]byte{[]byte("/fork/all/tx/1"), []byte("/fork/all/tx/2")},
+			},
+		},
+		Indexes: []*ForkRecordCount{
+			{
+				Key:   "/fork/all/tx/index",
+				Count: *field.NewInt(2),
+			},
+		},
+		Totals: []*ForkRecordCount{
+			{
+				Key:   "fork_transactions:/fork/all/tx/total",
+				Count: *field.NewInt(5),
+			},
+		},
+	}
+	res, err := r.Marshal()
+	assert.NoError(t, err)
+
+	out := &ForkRecord{}
+	err = out.Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(out.Deletes[0].Keys))
+	assert.Equal(t, "fork_transactions", out.Deletes[0].Table)
+	assert.Equal(t, uint64(2), out.Indexes[0].Count.ToUint64())
+	assert.Equal(t, uint64(5), out.Totals[0].Count.ToUint64())
+}
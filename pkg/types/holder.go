@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/binary"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/uchainorg/uscan/pkg/field"
 )
@@ -42,3 +44,29 @@ func ByteToInventory(bin []byte) (*Inventory, error) {
 func (h Inventory) ToBytes() []byte {
 	return append(common.BytesToHash(h.TokenID.Bytes()).Bytes(), h.Addr.Bytes()...)
 }
+
+// BalanceChange is the native or token balance of an account from a block on, it is stored
+// as a sorted value so that the changes of an account are ordered by block
+type BalanceChange struct {
+	BlockNumber uint64
+	TimeStamp   uint64
+	Balance     field.BigInt
+}
+
+func ByteToBalanceChange(bin []byte) (*BalanceChange, error) {
+	if len(bin) != 48 {
+		return nil, ErrorInvalidByte
+	}
+	c := &BalanceChange{}
+	c.BlockNumber = binary.BigEndian.Uint64(bin[:8])
+	c.TimeStamp = binary.BigEndian.Uint64(bin[8:16])
+	c.Balance.SetBytes(bin[16:])
+	return c, nil
+}
+
+func (c BalanceChange) ToBytes() []byte {
+	bin := make([]byte, 16, 48)
+	binary.BigEndian.PutUint64(bin[:8], c.BlockNumber)
+	binary.BigEndian.PutUint64(bin[8:16], c.TimeStamp)
+	return append(bin, common.BytesToHash(c.Balance.Bytes()).Bytes()...)
+}
@@ -22,3 +22,23 @@ func TestHolder(t *testing.T) {
 	assert.Equal(t, h.Addr, out.Addr)
 	assert.Equal(t, h.Quantity, out.Quantity)
 }
+
+func TestBalanceChange(t *testing.T) {
+	c := &BalanceChange{
+		BlockNumber: 300,
+		TimeStamp:   1666000000,
+		Balance:     *field.NewInt(5000),
+	}
+	bytesRes := c.ToBytes()
+	assert.Equal(t, 48, len(bytesRes))
+
+	out, err := ByteToBalanceChange(bytesRes)
+	assert.NoError(t, err)
+	assert.Equal(t, c.BlockNumber, out.BlockNumber)
+	assert.Equal(t, c.TimeStamp, out.TimeStamp)
+	assert.Equal(t, c.Balance.String(), out.Balance.String())
+
+	// changes sort by block
+	later := BalanceChange{BlockNumber: 301, Balance: *field.NewInt(1)}
+	assert.True(t, string(later.ToBytes()) > string(bytesRes))
+}
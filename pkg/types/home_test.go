@@ -66,3 +66,25 @@ func TestHomeMarshal(t *testing.T) {
 	assert.Equal(t, out.Txs[1].Hash, common.HexToHash("0x866fe28eb38d737da9a10a5dcfad3ce0b1fd517cf853609cffb002166abd55d7"))
 	assert.Equal(t, out.DateTxs["20221011"].String(), field.NewInt(11).String())
 }
+
+func TestDailyStatsMarshal(t *testing.T) {
+	s := &DailyStats{
+		Date:           "20221023",
+		BlockCount:     3,
+		TxCount:        4,
+		GasUsed:        *field.NewInt(84000),
+		GasPriceTotal:  *field.NewInt(10),
+		FirstBlockTime: 100,
+		LastBlockTime:  130,
+	}
+	res, err := s.Marshal()
+	assert.NoError(t, err)
+
+	out := &DailyStats{}
+	assert.NoError(t, out.Unmarshal(res))
+	assert.Equal(t, s.Date, out.Date)
+	assert.Equal(t, uint64(4), out.TxCount)
+	assert.Equal(t, uint64(84000), out.GasUsed.ToUint64())
+	assert.Equal(t, uint64(2), out.AvgGasPrice().ToUint64())
+	assert.Equal(t, float64(15), out.AvgBlockTime())
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// undo operations kept in a block journal
+const (
+	JournalPut  uint8 = iota + 1 // restore Val under Key
+	JournalDel                   // Key did not exist before, remove it
+	JournalSPut                  // sorted value was removed, add it back
+	JournalSDel                  // sorted value was added, remove it
+)
+
+type JournalEntry struct {
+	Op    uint8
+	Table string
+	Key   []byte
+	Val   []byte
+}
+
+// Journal holds everything needed to undo the writes of one synced block.
+// Entries are kept in write order and must be replayed backwards.
+type Journal struct {
+	Entries []*JournalEntry
+}
+
+func (b *Journal) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *Journal) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalMarshal(t *testing.T) {
+	j := &Journal{
+		Entries: []*JournalEntry{
+			{
+				Op:    JournalPut,
+				Table: "home",
+				Key:   []byte("/home"),
+				Val:   []byte{0x1, 0x2},
+			}, {
+				Op:    JournalDel,
+				Table: "blocks",
+				Key:   []byte("/block/1"),
+			}, {
+				Op:    JournalSDel,
+				Table: "holdersSort",
+				Key:   []byte("/erc20/"),
+				Val:   []byte{0x3},
+			},
+		},
+	}
+	res, err := j.Marshal()
+	assert.NoError(t, err)
+
+	out := &Journal{}
+	err = out.Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(out.Entries))
+	assert.Equal(t, JournalPut, out.Entries[0].Op)
+	assert.Equal(t, []byte{0x1, 0x2}, out.Entries[0].Val)
+	assert.Equal(t, JournalDel, out.Entries[1].Op)
+	assert.Equal(t, "blocks", out.Entries[1].Table)
+	assert.Equal(t, []byte("/erc20/"), out.Entries[2].Key)
+}
+
+func TestJournalEmptyMarshal(t *testing.T) {
+	j := &Journal{}
+	res, err := j.Marshal()
+	assert.NoError(t, err)
+
+	out := &Journal{}
+	err = out.Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(out.Entries))
+}
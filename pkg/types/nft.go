@@ -0,0 +1,21 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// NftMetadata is the cached metadata of a token, Error is set when it could not be fetched
+type NftMetadata struct {
+	TokenURI  string
+	Data      []byte
+	Error     string
+	UpdatedAt uint64
+}
+
+func (b *NftMetadata) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *NftMetadata) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
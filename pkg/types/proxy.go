@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	ProxyEIP1967 = "eip1967" // implementation slot
+	ProxyBeacon  = "beacon"  // eip-1967 beacon slot
+	ProxyEIP1822 = "eip1822" // PROXIABLE slot
+)
+
+// Proxy is a standard proxy as read from its storage slots at a block
+type Proxy struct {
+	Kind           string
+	Implementation common.Address
+	Admin          common.Address
+	Beacon         common.Address
+	BlockNumber    uint64
+	TxHash         common.Hash // tx which created or upgraded the proxy, zero when it was found by a delegatecall
+}
+
+func (b *Proxy) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *Proxy) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
+
+// ProxyUpgrade is an entry of the implementation history of a proxy, it is stored as a sorted value
+type ProxyUpgrade struct {
+	BlockNumber    uint64
+	Implementation common.Address
+	TxHash         common.Hash
+}
+
+func ByteToProxyUpgrade(bin []byte) (*ProxyUpgrade, error) {
+	if len(bin) != 8+common.AddressLength+common.HashLength {
+		return nil, ErrorInvalidByte
+	}
+	u := &ProxyUpgrade{}
+	u.BlockNumber = binary.BigEndian.Uint64(bin[:8])
+	u.Implementation.SetBytes(bin[8 : 8+common.AddressLength])
+	u.TxHash.SetBytes(bin[8+common.AddressLength:])
+	return u, nil
+}
+
+func (u ProxyUpgrade) ToBytes() []byte {
+	bin := make([]byte, 8, 8+common.AddressLength+common.HashLength)
+	binary.BigEndian.PutUint64(bin, u.BlockNumber)
+	bin = append(bin, u.Implementation.Bytes()...)
+	return append(bin, u.TxHash.Bytes()...)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyUpgrade(t *testing.T) {
+	u := &ProxyUpgrade{
+		BlockNumber:    300,
+		Implementation: common.HexToAddress("0x473780deaf4a2ac070bbba936b0cdefe7f267dfc"),
+		TxHash:         common.HexToHash("0x01"),
+	}
+	bytesRes := u.ToBytes()
+	assert.Equal(t, 60, len(bytesRes))
+
+	out, err := ByteToProxyUpgrade(bytesRes)
+	assert.NoError(t, err)
+	assert.Equal(t, u, out)
+
+	_, err = ByteToProxyUpgrade(bytesRes[:59])
+	assert.Error(t, err)
+}
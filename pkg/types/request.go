@@ -27,7 +27,7 @@ type SearchFilter struct {
 type ValidateContractTmpReq struct {
 	ContractAddress  []string `json:"contractAddress"`
 	ContractName     []string `json:"contractName"`
-	CompilerType     []string `json:"compilerType"` // solidity-single-file / solidity-standard-json-input
+	CompilerType     []string `json:"compilerType"` // solidity-single-file / solidity-standard-json-input / vyper-single-file / vyper-json
 	CompilerVersion  []string `json:"compilerVersion"`
 	CompilerFileName []string `json:"compilerFileName"`
 	LicenseType      []string `json:"licenseType"` // int
@@ -79,7 +79,7 @@ func (r *ValidateContractTmpReq) ToValidateContractReq() *ValidateContractReq {
 type ValidateContractReq struct {
 	ContractAddress  string `json:"contractAddress"`
 	ContractName     string `json:"contractName"`
-	CompilerType     string `json:"compilerType"` // solidity-single-file / solidity-standard-json-input
+	CompilerType     string `json:"compilerType"` // solidity-single-file / solidity-standard-json-input / vyper-single-file / vyper-json
 	CompilerVersion  string `json:"compilerVersion"`
 	CompilerFileName string `json:"compilerFileName"`
 	LicenseType      uint64 `json:"licenseType"` // int
@@ -92,4 +92,69 @@ type ValidateContractReq struct {
 const (
 	SoliditySingleFile        = "solidity-single-file"
 	SolidityStandardJsonInput = "solidity-standard-json-input"
+	VyperSingleFile           = "vyper-single-file"
+	VyperJson                 = "vyper-json"
 )
+
+type WebhookReq struct {
+	URL      string `json:"url"`
+	Secret   string `json:"secret"`
+	Address  string `json:"address"`
+	Contract string `json:"contract"`
+	Topic    string `json:"topic"`
+	MinValue string `json:"minValue"` // decimal
+}
+
+// SignatureImportReq imports a signature list from a file of the server, type is function or event
+type SignatureImportReq struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// ApprovalQuery lists the approvals given by the account as owner, the default, or to it as spender
+type NftQuery struct {
+	OwnersAfter string `query:"ownersAfter"` // a page of the erc1155 owners starts after this address
+}
+
+type ApprovalQuery struct {
+	Role string `query:"role"`
+}
+
+type StreamFilter struct {
+	Address  string `query:"address"`
+	Contract string `query:"contract"`
+	From     uint64 `query:"from"` // first block to send after a reconnect
+}
+
+// BalanceQuery selects a block by number or by time, the last confirmed block when both are empty
+type BalanceQuery struct {
+	Block     uint64 `query:"block"`
+	Timestamp uint64 `query:"timestamp"`
+}
+
+// TokenBalanceQuery selects a token and a block, type is erc20 or erc1155 which requires tokenId
+type TokenBalanceQuery struct {
+	Type      string `query:"type"`
+	TokenID   string `query:"tokenId"`
+	Block     uint64 `query:"block"`
+	Timestamp uint64 `query:"timestamp"`
+	After     string `query:"after"` // a page of the snapshot starts after this address
+}
+
+// DailyStatsQuery selects the days from and to, as yyyymmdd, and optionally a single metric
+type DailyStatsQuery struct {
+	From   string `query:"from"`
+	To     string `query:"to"`
+	Metric string `query:"metric"`
+}
+
+// LogQuery is an eth_getLogs style filter, toBlock 0 is the last indexed block
+type LogQuery struct {
+	Address   string `query:"address"`
+	Topic0    string `query:"topic0"`
+	Topic1    string `query:"topic1"`
+	Topic2    string `query:"topic2"`
+	Topic3    string `query:"topic3"`
+	FromBlock uint64 `query:"fromBlock"`
+	ToBlock   uint64 `query:"toBlock"`
+}
@@ -1,5 +1,7 @@
 package types
 
+import "encoding/json"
+
 type HomeBlock struct {
 	Number            string `json:"number"`
 	Timestamp         uint64 `json:"timestamp"`
@@ -18,27 +20,29 @@ type HomeTx struct {
 }
 
 type BlockResp struct {
-	BaseFeePerGas     *string  `json:"baseFeePerGas"`
-	Difficulty        string   `json:"difficulty"`
-	ExtraData         []byte   `json:"extraData"`
-	GasLimit          string   `json:"gasLimit"` // int64 改成string
-	GasUsed           string   `json:"gasUsed"`  // int64 改成string
-	Hash              string   `json:"hash"`
-	LogsBloom         string   `json:"logsBloom"`
-	Miner             string   `json:"miner"`
-	MixHash           string   `json:"mixHash"`
-	Nonce             string   `json:"nonce"`
-	Number            string   `json:"number"`
-	ParentHash        string   `json:"parentHash"`
-	ReceiptsRoot      string   `json:"receiptsRoot"`
-	Sha3Uncles        string   `json:"sha3Uncles"`
-	Size              string   `json:"size"`
-	StateRoot         string   `json:"stateRoot"`
-	Timestamp         uint64   `json:"timestamp"`
-	TotalDifficulty   uint64   `json:"totalDifficulty"`
-	Transactions      []string `json:"transactions"`
-	TransactionsTotal uint64   `json:"transactionsTotal"`
-	TransactionsRoot  string   `json:"transactionsRoot"`
+	BaseFeePerGas     *string           `json:"baseFeePerGas"`
+	Difficulty        string            `json:"difficulty"`
+	ExtraData         []byte            `json:"extraData"`
+	GasLimit          string            `json:"gasLimit"` // int64 改成string
+	GasUsed           string            `json:"gasUsed"`  // int64 改成string
+	Hash              string            `json:"hash"`
+	LogsBloom         string            `json:"logsBloom"`
+	Miner             string            `json:"miner"`
+	MixHash           string            `json:"mixHash"`
+	Nonce             string            `json:"nonce"`
+	Number            string            `json:"number"`
+	ParentHash        string            `json:"parentHash"`
+	ReceiptsRoot      string            `json:"receiptsRoot"`
+	Sha3Uncles        string            `json:"sha3Uncles"`
+	Size              string            `json:"size"`
+	StateRoot         string            `json:"stateRoot"`
+	Timestamp         uint64            `json:"timestamp"`
+	TotalDifficulty   uint64            `json:"totalDifficulty"`
+	Transactions      []string          `json:"transactions"`
+	TransactionsTotal uint64            `json:"transactionsTotal"`
+	TransactionsRoot  string            `json:"transactionsRoot"`
+	WithdrawalsRoot   *string           `json:"withdrawalsRoot"`
+	Withdrawals       []*WithdrawalResp `json:"withdrawals"`
 	//CreatedTime       uint64   `json:"createdTime"`
 }
 
@@ -82,14 +86,17 @@ type TxResp struct {
 	GasLimit             string               `json:"gasLimit"` // change string
 	MethodName           string               `json:"methodName"`
 	Logs                 []*RtLogResp         `json:"logs"`
+	DecodedInput         *DecodedInputResp    `json:"decodedInput"`
+	Pending              bool                 `json:"pending"` // in the pool of the node, not mined yet
 	RtResp                                    // 新增
 }
 
 type RtLogResp struct {
-	Address  string   `json:"address"`
-	Topics   []string `json:"topics"`
-	Data     string   `json:"data"`
-	LogIndex uint64   `json:"logIndex"`
+	Address  string            `json:"address"`
+	Topics   []string          `json:"topics"`
+	Data     string            `json:"data"`
+	LogIndex uint64            `json:"logIndex"`
+	Decoded  *DecodedEventResp `json:"decoded"`
 }
 
 type RtResp struct {
@@ -245,7 +252,8 @@ type TraceTxResp struct {
 }
 
 type TraceTx2Resp struct {
-	Res string `json:"res"`
+	Res         string `json:"res"`
+	Unavailable bool   `json:"unavailable,omitempty"`
 }
 type HolderResp struct {
 	Address  string
@@ -267,12 +275,29 @@ type ContractVerityInfo struct {
 	ABI             string            `json:"abi"`
 	Metadata        map[string]string `json:"metadata"`
 	Object          string            `json:"object"`
+	Match           string            `json:"match"` // full match or partial match
 }
 
 type ContractVerityInfoResp struct {
 	Contract             *ContractVerityInfo `json:"contract"`
 	ProxyContractAddress string              `json:"proxyContractAddress"`
 	ProxyContract        *ContractVerityInfo `json:"proxyContract"`
+	Proxy                *ProxyResp          `json:"proxy"`
+}
+
+type ProxyResp struct {
+	Type                  string              `json:"type"` // eip1967, beacon or eip1822
+	Implementation        string              `json:"implementation"`
+	Admin                 *string             `json:"admin"`
+	Beacon                *string             `json:"beacon"`
+	BlockNumber           uint64              `json:"blockNumber"`
+	ImplementationHistory []*ProxyUpgradeResp `json:"implementationHistory"`
+}
+
+type ProxyUpgradeResp struct {
+	Implementation  string  `json:"implementation"`
+	BlockNumber     uint64  `json:"blockNumber"`
+	TransactionHash *string `json:"transactionHash"`
 }
 
 type ContractType uint8
@@ -300,3 +325,208 @@ type EventTransferData struct {
 	TokenIDToNums    []*TokenNum  `json:"tokenIDToNums"`
 	Value            string       `json:"value"`
 }
+
+type WebhookResp struct {
+	ID          uint64  `json:"id"`
+	URL         string  `json:"url"`
+	Secret      *string `json:"secret,omitempty"` // only returned on creation
+	Address     *string `json:"address"`
+	Contract    *string `json:"contract"`
+	Topic       *string `json:"topic"`
+	MinValue    string  `json:"minValue"`
+	CreatedTime uint64  `json:"createTime"`
+}
+
+type WebhookDeliveryResp struct {
+	BlockNumber uint64          `json:"blockNumber"`
+	BlockHash   string          `json:"blockHash"`
+	Confirmed   bool            `json:"confirmed"`
+	Removed     bool            `json:"removed"`
+	Attempts    uint64          `json:"attempts"`
+	StatusCode  uint64          `json:"statusCode"`
+	Error       string          `json:"error"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedTime uint64          `json:"createTime"`
+}
+
+type BalanceResp struct {
+	Address     string `json:"address"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Balance     string `json:"balance"`
+	ChangedAt   uint64 `json:"changedAt"` // block of the last change up to BlockNumber
+}
+
+type BalanceChangeResp struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	TimeStamp   uint64 `json:"timestamp"`
+	Balance     string `json:"balance"`
+}
+
+type EventLogResp struct {
+	Address     string            `json:"address"`
+	Topics      []string          `json:"topics"`
+	Data        string            `json:"data"`
+	BlockNumber uint64            `json:"blockNumber"`
+	TxHash      string            `json:"transactionHash"`
+	LogIndex    uint64            `json:"logIndex"`
+	Decoded     *DecodedEventResp `json:"decoded"` // nil when the contract is not verified
+}
+
+type DecodedInputResp struct {
+	Name      string              `json:"name"`
+	Signature string              `json:"signature"`
+	Params    []*DecodedParamResp `json:"params"`
+	Source    string              `json:"source"` // abi or signature
+}
+
+type DecodedEventResp struct {
+	Name      string              `json:"name"`
+	Signature string              `json:"signature"`
+	Params    []*DecodedParamResp `json:"params"`
+	Source    string              `json:"source"` // abi or signature
+}
+
+type DecodedParamResp struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+type WithdrawalResp struct {
+	Index          uint64 `json:"index"`
+	ValidatorIndex uint64 `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"` // wei
+	BlockNumber    uint64 `json:"blockNumber"`
+	TimeStamp      uint64 `json:"timestamp"`
+}
+
+type NftResp struct {
+	Contract       string             `json:"contract"`
+	ContractName   string             `json:"contractName"`
+	ContractSymbol string             `json:"contractSymbol"`
+	TokenType      string             `json:"tokenType"` // erc721 or erc1155
+	TokenID        string             `json:"tokenID"`   // decimal
+	Owners         []*HolderResp      `json:"owners"`
+	OwnerCount     uint64             `json:"ownerCount"` // the erc1155 addresses which have held the token
+	OwnersNext     *string            `json:"ownersNext"` // where the following page of the erc1155 owners starts
+	Transfers      []*NftTransferResp `json:"transfers"`
+	TransferCount  uint64             `json:"transferCount"`
+	Metadata       *NftMetadataResp   `json:"metadata"`
+}
+
+type NftTransferResp struct {
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	Method          string `json:"method"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Quantity        string `json:"quantity"`
+	TimeStamp       uint64 `json:"timestamp"`
+}
+
+type NftMetadataResp struct {
+	TokenURI    string          `json:"tokenURI"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Image       string          `json:"image"`
+	ImageURL    string          `json:"imageURL"` // image through the ipfs gateway
+	Attributes  json.RawMessage `json:"attributes"`
+	Raw         json.RawMessage `json:"raw"`
+	Error       string          `json:"error,omitempty"`
+	UpdatedAt   uint64          `json:"updatedAt"`
+}
+
+type ApprovalResp struct {
+	Contract         string `json:"contract"`
+	ContractName     string `json:"contractName"`
+	ContractSymbol   string `json:"contractSymbol"`
+	ContractDecimals uint64 `json:"contractDecimals"`
+	TokenType        string `json:"tokenType"` // erc20, erc721 or erc1155, empty when unknown
+	Type             string `json:"type"`      // approval or approvalForAll
+	Owner            string `json:"owner"`
+	Spender          string `json:"spender"`
+	Allowance        string `json:"allowance,omitempty"`    // erc20 allowance left, read from the token
+	LastApproved     string `json:"lastApproved,omitempty"` // erc20 amount of the last approval
+	Unlimited        bool   `json:"unlimited"`              // allowance is the max uint256
+	TransactionHash  string `json:"transactionHash"`
+	BlockNumber      uint64 `json:"blockNumber"`
+	TimeStamp        uint64 `json:"timestamp"`
+}
+
+type TokenBalanceResp struct {
+	Contract    string  `json:"contract"`
+	Holder      string  `json:"holder"`
+	TokenID     *string `json:"tokenId"`
+	BlockNumber uint64  `json:"blockNumber"`
+	Balance     string  `json:"balance"`
+	ChangedAt   uint64  `json:"changedAt"`
+}
+
+type DailyStatsResp struct {
+	Date             string  `json:"date"`
+	BlockCount       uint64  `json:"blockCount"`
+	TxCount          uint64  `json:"txCount"`
+	GasUsed          string  `json:"gasUsed"`
+	AvgGasPrice      string  `json:"avgGasPrice"`
+	BaseFeeBurned    string  `json:"baseFeeBurned"`
+	ActiveAddresses  uint64  `json:"activeAddresses"`
+	NewAddresses     uint64  `json:"newAddresses"`
+	NewContracts     uint64  `json:"newContracts"`
+	Erc20Transfers   uint64  `json:"erc20Transfers"`
+	Erc721Transfers  uint64  `json:"erc721Transfers"`
+	Erc1155Transfers uint64  `json:"erc1155Transfers"`
+	AvgBlockTime     float64 `json:"avgBlockTime"`
+}
+
+type DailyMetricResp struct {
+	Date  string      `json:"date"`
+	Value interface{} `json:"value"`
+}
+
+type GasTrackerResp struct {
+	BlockNumber uint64            `json:"blockNumber"`
+	BaseFee     string            `json:"baseFee"`
+	NextBaseFee string            `json:"nextBaseFee"`
+	Slow        *GasEstimateResp  `json:"slow"`
+	Standard    *GasEstimateResp  `json:"standard"`
+	Fast        *GasEstimateResp  `json:"fast"`
+	History     []*GasHistoryResp `json:"history"`
+}
+
+type GasEstimateResp struct {
+	GasPrice    string `json:"gasPrice"`
+	PriorityFee string `json:"priorityFee"`
+}
+
+type GasHistoryResp struct {
+	BlockNumber  uint64  `json:"blockNumber"`
+	Timestamp    uint64  `json:"timestamp"`
+	BaseFee      string  `json:"baseFee"`
+	GasUsedRatio float64 `json:"gasUsedRatio"`
+	TxCount      int     `json:"txCount"`
+	Slow         string  `json:"slow"`
+	Standard     string  `json:"standard"`
+	Fast         string  `json:"fast"`
+}
+
+type PendingTxResp struct {
+	Hash                 string  `json:"hash"`
+	Method               string  `json:"method"`
+	From                 string  `json:"from"`
+	To                   *string `json:"to"`
+	Gas                  string  `json:"gas"`
+	GasPrice             string  `json:"gasPrice"`
+	MaxFeePerGas         *string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *string `json:"maxPriorityFeePerGas"`
+	Value                string  `json:"value"`
+	Nonce                string  `json:"nonce"`
+	SeenTime             uint64  `json:"seenTime"`
+}
+
+type SignatureImportResp struct {
+	Type  string `json:"type"`
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+// DailyStats are the totals of the blocks mined in a utc day
+type DailyStats struct {
+	Date             string // yyyymmdd
+	BlockCount       uint64
+	TxCount          uint64
+	GasUsed          field.BigInt
+	GasPriceTotal    field.BigInt // sum of the effective gas price of the txs
+	BaseFeeBurned    field.BigInt
+	ActiveAddresses  uint64 // senders and receivers of the txs
+	NewAddresses     uint64
+	NewContracts     uint64
+	Erc20Transfers   uint64
+	Erc721Transfers  uint64
+	Erc1155Transfers uint64
+	FirstBlockTime   uint64
+	LastBlockTime    uint64
+}
+
+func (b *DailyStats) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *DailyStats) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
+
+// AvgGasPrice is the average effective gas price of the txs
+func (b *DailyStats) AvgGasPrice() *field.BigInt {
+	if b.TxCount == 0 {
+		return field.NewInt(0)
+	}
+	res := new(big.Int).Div((*big.Int)(&b.GasPriceTotal), new(big.Int).SetUint64(b.TxCount))
+	return (*field.BigInt)(res)
+}
+
+// AvgBlockTime is the average number of seconds between the blocks of the day
+func (b *DailyStats) AvgBlockTime() float64 {
+	if b.BlockCount < 2 {
+		return 0
+	}
+	return float64(b.LastBlockTime-b.FirstBlockTime) / float64(b.BlockCount-1)
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+// Webhook is a subscription to the activity of an address, a contract or an event.
+// A zero Address, Contract or Topic matches anything.
+type Webhook struct {
+	ID       field.BigInt
+	URL      string
+	Secret   string
+	Address  common.Address // sender or receiver of a tx, or an indexed argument of an event
+	Contract common.Address // contract emitting the event
+	Topic    common.Hash    // event signature
+	MinValue field.BigInt   // value of a tx or amount of a token transfer
+	Created  field.BigInt
+}
+
+func (b *Webhook) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *Webhook) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
+
+// WebhookDelivery is one POST of a block to a webhook
+type WebhookDelivery struct {
+	BlockNumber field.BigInt
+	BlockHash   common.Hash
+	Confirmed   bool
+	Removed     bool
+	Attempts    uint64
+	StatusCode  uint64
+	Error       string
+	Payload     []byte
+	TimeStamp   field.BigInt
+}
+
+func (b *WebhookDelivery) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *WebhookDelivery) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
+
+// WebhookUnconfirmed records the webhooks notified about a block still in the fork window
+type WebhookUnconfirmed struct {
+	BlockHash common.Hash
+	Webhooks  []uint64
+}
+
+func (b *WebhookUnconfirmed) Marshal() ([]byte, error) {
+	return rlp.EncodeToBytes(b)
+}
+
+func (b *WebhookUnconfirmed) Unmarshal(bin []byte) error {
+	return rlp.DecodeBytes(bin, &b)
+}
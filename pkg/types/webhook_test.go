@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/field"
+)
+
+func TestWebhookMarshal(t *testing.T) {
+	w := &Webhook{
+		ID:       *field.NewInt(3),
+		URL:      "https://example.com/hook",
+		Secret:   "secret",
+		Address:  common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Topic:    common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		MinValue: *field.NewInt(100),
+	}
+	res, err := w.Marshal()
+	assert.NoError(t, err)
+
+	out := &Webhook{}
+	err = out.Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), out.ID.ToUint64())
+	assert.Equal(t, w.URL, out.URL)
+	assert.Equal(t, w.Address, out.Address)
+	assert.Equal(t, common.Address{}, out.Contract)
+	assert.Equal(t, w.Topic, out.Topic)
+	assert.Equal(t, uint64(100), out.MinValue.ToUint64())
+}
+
+func TestWebhookUnconfirmedMarshal(t *testing.T) {
+	u := &WebhookUnconfirmed{
+		BlockHash: common.HexToHash("0x01"),
+		Webhooks:  []uint64{1, 4},
+	}
+	res, err := u.Marshal()
+	assert.NoError(t, err)
+
+	out := &WebhookUnconfirmed{}
+	err = out.Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, u.BlockHash, out.BlockHash)
+	assert.Equal(t, 2, len(out.Webhooks))
+	assert.Equal(t, uint64(4), out.Webhooks[1])
+}
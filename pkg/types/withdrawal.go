@@ -0,0 +1,49 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Withdrawal is a beacon-chain withdrawal of a block, the amount is in gwei
+type Withdrawal struct {
+	Index     hexutil.Uint64 `json:"index"`
+	Validator hexutil.Uint64 `json:"validatorIndex"`
+	Address   common.Address `json:"address"`
+	Amount    hexutil.Uint64 `json:"amount"`
+}
+
+// AccountWithdrawal is a withdrawal to an account, it is stored as a sorted value
+// so that the withdrawals of an account are ordered by their index
+type AccountWithdrawal struct {
+	Index       uint64
+	BlockNumber uint64
+	TimeStamp   uint64
+	Validator   uint64
+	Amount      uint64
+}
+
+func ByteToAccountWithdrawal(bin []byte) (*AccountWithdrawal, error) {
+	if len(bin) != 40 {
+		return nil, ErrorInvalidByte
+	}
+	return &AccountWithdrawal{
+		Index:       binary.BigEndian.Uint64(bin[:8]),
+		BlockNumber: binary.BigEndian.Uint64(bin[8:16]),
+		TimeStamp:   binary.BigEndian.Uint64(bin[16:24]),
+		Validator:   binary.BigEndian.Uint64(bin[24:32]),
+		Amount:      binary.BigEndian.Uint64(bin[32:]),
+	}, nil
+}
+
+func (w AccountWithdrawal) ToBytes() []byte {
+	bin := make([]byte, 40)
+	binary.BigEndian.PutUint64(bin[:8], w.Index)
+	binary.BigEndian.PutUint64(bin[8:16], w.BlockNumber)
+	binary.BigEndian.PutUint64(bin[16:24], w.TimeStamp)
+	binary.BigEndian.PutUint64(bin[24:32], w.Validator)
+	binary.BigEndian.PutUint64(bin[32:], w.Amount)
+	return bin
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountWithdrawal(t *testing.T) {
+	w := &AccountWithdrawal{
+		Index:       29,
+		BlockNumber: 16,
+		TimeStamp:   1681342355,
+		Validator:   58,
+		Amount:      12000000000,
+	}
+	bytesRes := w.ToBytes()
+	assert.Equal(t, 40, len(bytesRes))
+
+	out, err := ByteToAccountWithdrawal(bytesRes)
+	assert.NoError(t, err)
+	assert.Equal(t, w, out)
+
+	// withdrawals sort by index
+	later := AccountWithdrawal{Index: 30}
+	assert.True(t, string(later.ToBytes()) > string(bytesRes))
+}
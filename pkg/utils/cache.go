@@ -29,3 +29,7 @@ func (c *Cache) Remove(key interface{}) bool {
 func (c *Cache) Get(key interface{}) (interface{}, bool) {
 	return c.cache.Get(key)
 }
+
+func (c *Cache) Purge() {
+	c.cache.Purge()
+}
@@ -0,0 +1,219 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	FullMatch    = "full match"    // the metadata hash, the creation code and the constructor arguments match too
+	PartialMatch = "partial match" // the runtime code matches once its metadata is stripped
+)
+
+var ErrMismatch = errors.New("bytecode does not match")
+
+// Reference is a range of the code filled in at link or deploy time
+type Reference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// Links are the library placeholders of a code, file => library => references
+type Links map[string]map[string][]Reference
+
+func (l Links) References() []Reference {
+	refs := make([]Reference, 0)
+	for _, libs := range l {
+		for _, v := range libs {
+			refs = append(refs, v...)
+		}
+	}
+	return refs
+}
+
+// Output is the part of the standard json output which locates the references of the code,
+// it is read from the same output as the bytecode
+type Output struct {
+	Contracts map[string]map[string]struct {
+		EVM struct {
+			Bytecode         OutputBytecode `json:"bytecode"`
+			DeployedBytecode OutputBytecode `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+}
+
+type OutputBytecode struct {
+	LinkReferences      Links                  `json:"linkReferences"`
+	ImmutableReferences map[string][]Reference `json:"immutableReferences"` // runtime code only
+}
+
+// Compiled is the code of a contract as compiled
+type Compiled struct {
+	Creation      []byte
+	Runtime       []byte
+	CreationLinks []Reference
+	RuntimeLinks  []Reference
+	Immutables    []Reference
+}
+
+// NewCompiled decodes the bytecode objects of the compiler, the library placeholders are zeroed
+func NewCompiled(creation, runtime string, out *Output, file, name string) (*Compiled, error) {
+	c := &Compiled{}
+	if out != nil {
+		if contract, ok := out.Contracts[file][name]; ok {
+			c.CreationLinks = contract.EVM.Bytecode.LinkReferences.References()
+			c.RuntimeLinks = contract.EVM.DeployedBytecode.LinkReferences.References()
+			for _, v := range contract.EVM.DeployedBytecode.ImmutableReferences {
+				c.Immutables = append(c.Immutables, v...)
+			}
+		}
+	}
+	var err error
+	if c.Creation, err = decodeObject(creation, c.CreationLinks); err != nil {
+		return nil, err
+	}
+	if c.Runtime, err = decodeObject(runtime, c.RuntimeLinks); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Deployed is the code of a contract on chain
+type Deployed struct {
+	Creation             []byte // input of the creation, constructor arguments included
+	Runtime              []byte
+	ConstructorArguments []byte // as split by the sync, empty when it could not split them
+}
+
+// Verify compares the runtime codes with the references masked and their metadata stripped,
+// a full match also requires the same metadata, creation code and constructor arguments
+func Verify(c *Compiled, d *Deployed) (string, error) {
+	if len(c.Runtime) == 0 {
+		return "", ErrMismatch
+	}
+	compiledBody, compiledMetadata := SplitMetadata(Mask(c.Runtime, c.RuntimeLinks, c.Immutables))
+	deployedBody, deployedMetadata := SplitMetadata(Mask(d.Runtime, c.RuntimeLinks, c.Immutables))
+	if !bytes.Equal(compiledBody, deployedBody) {
+		return "", ErrMismatch
+	}
+	// code without a trailer is told apart by its creation code only
+	if !bytes.Equal(compiledMetadata, deployedMetadata) {
+		return PartialMatch, nil
+	}
+
+	if len(d.Creation) < len(c.Creation) ||
+		!bytes.Equal(Mask(c.Creation, c.CreationLinks), Mask(d.Creation[:len(c.Creation)], c.CreationLinks)) {
+		return PartialMatch, nil
+	}
+	// what follows the creation code is only known to be the constructor arguments when they
+	// are stored, the contract could have been deployed with more code than the compiled one
+	if !bytes.Equal(d.Creation[len(c.Creation):], d.ConstructorArguments) {
+		return PartialMatch, nil
+	}
+	return FullMatch, nil
+}
+
+// Mask returns a copy of code with the references zeroed
+func Mask(code []byte, refs ...[]Reference) []byte {
+	masked := append([]byte{}, code...)
+	for _, v := range refs {
+		for _, ref := range v {
+			if ref.Start < 0 || ref.Length < 0 || ref.Start+ref.Length > len(masked) {
+				continue
+			}
+			for i := ref.Start; i < ref.Start+ref.Length; i++ {
+				masked[i] = 0
+			}
+		}
+	}
+	return masked
+}
+
+// SplitMetadata splits the cbor metadata trailer off a code, it ends with the big endian length
+// of the cbor map. The metadata is nil when the code has no well formed trailer.
+func SplitMetadata(code []byte) (body, metadata []byte) {
+	if len(code) < 2 {
+		return code, nil
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	start := len(code) - 2 - length
+	if length == 0 || start < 0 || code[start]>>5 != 5 || cborItem(code[start:len(code)-2]) != length {
+		return code, nil
+	}
+	return code[:start], code[start:]
+}
+
+// cborItem returns the length of the cbor item at the start of b, -1 when it is not well formed.
+// Indefinite lengths are not emitted by the compilers and are not accepted.
+func cborItem(b []byte) int {
+	if len(b) == 0 {
+		return -1
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	n, arg := 1, uint64(0)
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(b) < 1+size {
+			return -1
+		}
+		for _, v := range b[1 : 1+size] {
+			arg = arg<<8 | uint64(v)
+		}
+		n += size
+	default:
+		return -1
+	}
+	if arg > uint64(len(b)) && major != 0 && major != 1 && major != 7 {
+		return -1
+	}
+
+	switch major {
+	case 0, 1, 7:
+		return n
+	case 2, 3:
+		if uint64(len(b)-n) < arg {
+			return -1
+		}
+		return n + int(arg)
+	case 4, 5:
+		items := int(arg)
+		if major == 5 {
+			items *= 2
+		}
+		for i := 0; i < items; i++ {
+			m := cborItem(b[n:])
+			if m < 0 {
+				return -1
+			}
+			n += m
+		}
+		return n
+	case 6:
+		m := cborItem(b[n:])
+		if m < 0 {
+			return -1
+		}
+		return n + m
+	}
+	return -1
+}
+
+// decodeObject decodes a hex bytecode object, the placeholders of the links are replaced by zeros
+func decodeObject(object string, links []Reference) ([]byte, error) {
+	text := []byte(strings.TrimPrefix(object, "0x"))
+	for _, ref := range links {
+		if ref.Start < 0 || ref.Length < 0 || 2*(ref.Start+ref.Length) > len(text) {
+			return nil, ErrMismatch
+		}
+		for i := 2 * ref.Start; i < 2*(ref.Start+ref.Length); i++ {
+			text[i] = '0'
+		}
+	}
+	return hex.DecodeString(string(text))
+}
@@ -0,0 +1,96 @@
+package verify
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// trailer of solc 0.8.17: {"ipfs": <34 bytes>, "solc": 0.8.17}
+func solcTrailer(hash byte) []byte {
+	bin, _ := hex.DecodeString("a2646970667358221220" + strings.Repeat(hex.EncodeToString([]byte{hash}), 32) + "64736f6c63430008110033")
+	return bin
+}
+
+func TestSplitMetadata(t *testing.T) {
+	code := append([]byte{0x60, 0x80, 0x60, 0x40}, solcTrailer(1)...)
+	body, metadata := SplitMetadata(code)
+	assert.Equal(t, []byte{0x60, 0x80, 0x60, 0x40}, body)
+	assert.Equal(t, 53, len(metadata))
+
+	// {"vyper": [0, 3, 7]}
+	vy, _ := hex.DecodeString("6003a165767970657283000307000b")
+	body, metadata = SplitMetadata(vy)
+	assert.Equal(t, []byte{0x60, 0x03}, body)
+	assert.Equal(t, 13, len(metadata))
+
+	// the last two bytes are not the length of a cbor map
+	body, metadata = SplitMetadata([]byte{0x60, 0x80, 0x00, 0x01})
+	assert.Equal(t, []byte{0x60, 0x80, 0x00, 0x01}, body)
+	assert.Nil(t, metadata)
+}
+
+func TestVerify(t *testing.T) {
+	runtime := append([]byte{0x73, 0, 0, 0, 0, 0x7f, 0, 0, 0, 0, 0x56}, solcTrailer(1)...)
+	creation := append([]byte{0x60, 0x80, 0x39}, runtime...)
+	compiled := &Compiled{
+		Creation:   creation,
+		Runtime:    runtime,
+		Immutables: []Reference{{Start: 6, Length: 4}},
+	}
+
+	deployedRuntime := append([]byte{}, runtime...)
+	copy(deployedRuntime[6:10], []byte{1, 2, 3, 4}) // immutable set by the constructor
+	args := []byte{0, 0, 0, 9}
+	deployed := &Deployed{
+		Creation:             append(append([]byte{}, creation...), args...),
+		Runtime:              deployedRuntime,
+		ConstructorArguments: args,
+	}
+	match, err := Verify(compiled, deployed)
+	assert.NoError(t, err)
+	assert.Equal(t, FullMatch, match)
+
+	// other constructor arguments
+	deployed.ConstructorArguments = []byte{0, 0, 0, 8}
+	match, err = Verify(compiled, deployed)
+	assert.NoError(t, err)
+	assert.Equal(t, PartialMatch, match)
+
+	// constructor arguments which are not known
+	deployed.ConstructorArguments = nil
+	match, err = Verify(compiled, deployed)
+	assert.NoError(t, err)
+	assert.Equal(t, PartialMatch, match)
+
+	// another metadata hash
+	deployed.Runtime = append(append([]byte{}, deployedRuntime[:11]...), solcTrailer(2)...)
+	match, err = Verify(compiled, deployed)
+	assert.NoError(t, err)
+	assert.Equal(t, PartialMatch, match)
+
+	// other code
+	deployed.Runtime[0] = 0x72
+	_, err = Verify(compiled, deployed)
+	assert.ErrorIs(t, err, ErrMismatch)
+}
+
+func TestNewCompiled(t *testing.T) {
+	out := &Output{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"contracts":{"a.sol":{"A":{"evm":{"deployedBytecode":{"linkReferences":{"lib.sol":{"Lib":[{"start":1,"length":20}]}}}}}}}}`), out))
+
+	placeholder := "__$" + strings.Repeat("a", 34) + "$__"
+	compiled, err := NewCompiled("0x6001", "73"+placeholder+"56", out, "a.sol", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 22, len(compiled.Runtime))
+	assert.Equal(t, byte(0x56), compiled.Runtime[21])
+	assert.Equal(t, 1, len(compiled.RuntimeLinks))
+
+	// a linked library is masked
+	linked := append([]byte{0x73}, append([]byte(strings.Repeat("\x11", 20)), 0x56)...)
+	_, err = Verify(compiled, &Deployed{Runtime: linked})
+	assert.NoError(t, err)
+}
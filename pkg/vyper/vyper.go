@@ -0,0 +1,144 @@
+package vyper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Input is the standard json input of the vyper compiler
+type Input struct {
+	Language   string                     `json:"language"`
+	Sources    map[string]Source          `json:"sources"`
+	Interfaces map[string]json.RawMessage `json:"interfaces,omitempty"`
+	Settings   Settings                   `json:"settings"`
+}
+
+type Source struct {
+	Content string `json:"content"`
+}
+
+type Settings struct {
+	EVMVersion string `json:"evmVersion,omitempty"`
+	// a bool before vyper 0.3.10, "gas", "codesize" or "none" since
+	Optimize        interface{}         `json:"optimize,omitempty"`
+	OutputSelection map[string][]string `json:"outputSelection"`
+}
+
+type Output struct {
+	Errors    []Error                        `json:"errors,omitempty"`
+	Contracts map[string]map[string]Contract `json:"contracts,omitempty"`
+}
+
+type Error struct {
+	Type             string `json:"type,omitempty"`
+	Component        string `json:"component,omitempty"`
+	Severity         string `json:"severity,omitempty"`
+	Message          string `json:"message,omitempty"`
+	FormattedMessage string `json:"formattedMessage,omitempty"`
+}
+
+type Contract struct {
+	ABI    []json.RawMessage `json:"abi,omitempty"`
+	EVM    EVM               `json:"evm,omitempty"`
+	Layout Layout            `json:"layout,omitempty"`
+}
+
+// Layout is the storage and code layout of a contract, the code layout holds the immutables
+// which are appended to the runtime code on deployment
+type Layout struct {
+	CodeLayout map[string]Immutable `json:"code_layout,omitempty"`
+}
+
+type Immutable struct {
+	Type   string `json:"type,omitempty"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+}
+
+// ImmutablesSize returns the bytes of the immutables appended to the runtime code
+func (c *Contract) ImmutablesSize() int {
+	size := 0
+	for _, v := range c.Layout.CodeLayout {
+		if end := v.Offset + v.Length; end > size {
+			size = end
+		}
+	}
+	return size
+}
+
+// EVM holds the output of the evm, unlike solc the bytecode objects and method identifiers are 0x prefixed
+type EVM struct {
+	Bytecode          Bytecode          `json:"bytecode,omitempty"`
+	DeployedBytecode  Bytecode          `json:"deployedBytecode,omitempty"`
+	MethodIdentifiers map[string]string `json:"methodIdentifiers,omitempty"`
+}
+
+type Bytecode struct {
+	Object string `json:"object,omitempty"`
+}
+
+// Err returns the errors of the compilation, warnings are ignored
+func (o *Output) Err() error {
+	msgs := make([]string, 0)
+	for _, e := range o.Errors {
+		if e.Severity == "" || strings.EqualFold(e.Severity, "error") {
+			if e.FormattedMessage != "" {
+				msgs = append(msgs, e.FormattedMessage)
+			} else {
+				msgs = append(msgs, e.Message)
+			}
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// a source is compiled by one job after another, a compilation which does not end must not hold up the others
+var compileTimeout = 2 * time.Minute
+
+// Vyper runs a vyper binary in standard json mode
+type Vyper struct {
+	bin string
+}
+
+func NewVyper(bin string) *Vyper {
+	return &Vyper{
+		bin: bin,
+	}
+}
+
+// Compile runs the compiler on the input, it is killed when it has not finished in compileTimeout
+func (v *Vyper) Compile(in *Input) (*Output, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshal input: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), compileTimeout)
+	defer cancel()
+	stderr := &bytes.Buffer{}
+	command := exec.CommandContext(ctx, v.bin, "--standard-json")
+	command.Stdin = bytes.NewReader(b)
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("compiler killed after %s", compileTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed output: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	out := Output{}
+	if err = json.Unmarshal(output, &out); err != nil {
+		return nil, fmt.Errorf("failed unmarshal output: %v", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,66 @@
+package vyper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVyper writes a script which checks its flag, saves its input and prints out
+func fakeVyper(t *testing.T, out string) (bin, input string) {
+	dir := t.TempDir()
+	bin = filepath.Join(dir, "vyper")
+	input = filepath.Join(dir, "input.json")
+	script := "#!/bin/sh\n[ \"$1\" = \"--standard-json\" ] || exit 2\ncat > " + input + "\ncat <<'EOF'\n" + out + "\nEOF\n"
+	assert.NoError(t, os.WriteFile(bin, []byte(script), 0o755))
+	return
+}
+
+func TestCompile(t *testing.T) {
+	bin, input := fakeVyper(t, `{"contracts":{"Token.vy":{"Token":{"abi":[{"type":"function","name":"decimals"}],"evm":{"bytecode":{"object":"0x6001"},"deployedBytecode":{"object":"0x6002"},"methodIdentifiers":{"decimals()":"0x313ce567"}}}}}}`)
+	out, err := NewVyper(bin).Compile(&Input{
+		Language: "Vyper",
+		Sources:  map[string]Source{"Token.vy": {Content: "# @version 0.3.7"}},
+		Settings: Settings{OutputSelection: map[string][]string{"*": {"abi"}}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, out.Err())
+	c := out.Contracts["Token.vy"]["Token"]
+	assert.Equal(t, 1, len(c.ABI))
+	assert.Equal(t, "0x6002", c.EVM.DeployedBytecode.Object)
+	assert.Equal(t, "0x313ce567", c.EVM.MethodIdentifiers["decimals()"])
+
+	in, err := os.ReadFile(input)
+	assert.NoError(t, err)
+	assert.Contains(t, string(in), `"language":"Vyper"`)
+}
+
+func TestImmutablesSize(t *testing.T) {
+	c := &Contract{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"layout":{"storage_layout":{},"code_layout":{"OWNER":{"type":"address","offset":0,"length":32},"NAME":{"type":"String[10]","offset":32,"length":64}}}}`), c))
+	assert.Equal(t, 96, c.ImmutablesSize())
+	assert.Equal(t, 0, (&Contract{}).ImmutablesSize())
+}
+
+func TestCompileTimeout(t *testing.T) {
+	defer func(d time.Duration) { compileTimeout = d }(compileTimeout)
+	compileTimeout = 100 * time.Millisecond
+
+	bin := filepath.Join(t.TempDir(), "vyper")
+	assert.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\nexec sleep 10\n"), 0o755))
+	start := time.Now()
+	_, err := NewVyper(bin).Compile(&Input{Language: "Vyper"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestOutputErr(t *testing.T) {
+	out := &Output{Errors: []Error{{Severity: "warning", Message: "unused"}}}
+	assert.NoError(t, out.Err())
+	out.Errors = append(out.Errors, Error{Severity: "error", Message: "bad syntax"})
+	assert.EqualError(t, out.Err(), "bad syntax")
+}
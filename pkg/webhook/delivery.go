@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	SignatureHeader = "X-Uscan-Signature"
+	WebhookIDHeader = "X-Uscan-Webhook-Id"
+
+	deliveryTimeout  = 10 * time.Second
+	deliveryAttempts = 5
+	deliveryBackoff  = time.Second
+)
+
+type delivery struct {
+	hook   *types.Webhook
+	record *types.WebhookDelivery
+}
+
+// Sign returns the signature of a payload, the receiver checks it with the secret of the webhook
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for {
+		select {
+		case dl := <-d.queue:
+			d.deliver(dl)
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// deliver posts the payload until the webhook answers with a 2xx, waiting twice as long after each failure
+func (d *Dispatcher) deliver(dl *delivery) {
+	backoff := deliveryBackoff
+	for dl.record.Attempts < deliveryAttempts {
+		dl.record.Attempts++
+		status, err := d.post(dl)
+		dl.record.StatusCode = uint64(status)
+		if err == nil {
+			dl.record.Error = ""
+			break
+		}
+		dl.record.Error = err.Error()
+		if dl.record.Attempts == deliveryAttempts {
+			log.Errorf("webhook %d delivery of block %d failed: %v", dl.hook.ID.ToUint64(), dl.record.BlockNumber.ToUint64(), err)
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-d.quit:
+			dl.record.Error = "stopped before delivered: " + dl.record.Error
+			d.writeDelivery(dl)
+			return
+		}
+		backoff *= 2
+	}
+	d.writeDelivery(dl)
+}
+
+func (d *Dispatcher) post(dl *delivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, dl.hook.URL, bytes.NewReader(dl.record.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(dl.hook.Secret, dl.record.Payload))
+	req.Header.Set(WebhookIDHeader, strconv.FormatUint(dl.hook.ID.ToUint64(), 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
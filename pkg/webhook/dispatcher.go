@@ -0,0 +1,307 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/kv"
+	"github.com/uchainorg/uscan/pkg/log"
+	"github.com/uchainorg/uscan/pkg/storage/fulldb"
+	"github.com/uchainorg/uscan/pkg/types"
+	"github.com/uchainorg/uscan/pkg/utils"
+	"github.com/uchainorg/uscan/share"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// Dispatcher posts the activity of the committed blocks to the webhooks subscribed to it.
+// Webhooks, deliveries and unconfirmed blocks are written outside of the sync transactions,
+// so a rollback of the synced blocks keeps them.
+type Dispatcher struct {
+	db     kv.Database
+	client *http.Client
+	queue  chan *delivery
+	quit   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+
+	mu    sync.RWMutex
+	hooks []*types.Webhook
+
+	// serializes the writes of the webhook ids and the delivery logs
+	logMu sync.Mutex
+}
+
+func NewDispatcher(db kv.Database, workers int) (*Dispatcher, error) {
+	d := &Dispatcher{
+		db:     db,
+		client: utils.NewPublicClient(deliveryTimeout),
+		queue:  make(chan *delivery, share.MaxChanSize),
+		quit:   make(chan struct{}),
+	}
+	if err := d.reload(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d, nil
+}
+
+// Stop drops the queued deliveries and waits for the running ones
+func (d *Dispatcher) Stop() error {
+	d.once.Do(func() {
+		close(d.quit)
+		d.wg.Wait()
+	})
+	return nil
+}
+
+func (d *Dispatcher) reload() error {
+	ctx := context.Background()
+	total, err := fulldb.ReadWebhookTotal(ctx, d.db)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return nil
+		}
+		return err
+	}
+	hooks := make([]*types.Webhook, 0)
+	for i := uint64(1); i <= total.ToUint64(); i++ {
+		hook, err := fulldb.ReadWebhook(ctx, d.db, field.NewInt(int64(i)))
+		if err != nil {
+			if errors.Is(err, kv.NotFound) {
+				continue
+			}
+			return err
+		}
+		hooks = append(hooks, hook)
+	}
+
+	d.mu.Lock()
+	d.hooks = hooks
+	d.mu.Unlock()
+	return nil
+}
+
+// Create stores a new webhook, a secret is generated when none is given
+func (d *Dispatcher) Create(hook *types.Webhook) (*types.Webhook, error) {
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: url must be http or https", ErrInvalidWebhook)
+	}
+	// the payloads must not be posted into the network of uscan
+	if err = utils.CheckPublicHost(context.Background(), u.Hostname()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if hook.Address == (common.Address{}) && hook.Contract == (common.Address{}) && hook.Topic == (common.Hash{}) {
+		return nil, fmt.Errorf("%w: one of address, contract or topic is required", ErrInvalidWebhook)
+	}
+	if hook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err = rand.Read(secret); err != nil {
+			return nil, err
+		}
+		hook.Secret = hex.EncodeToString(secret)
+	}
+
+	ctx := context.Background()
+	d.logMu.Lock()
+	total, err := fulldb.ReadWebhookTotal(ctx, d.db)
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			d.logMu.Unlock()
+			return nil, err
+		}
+		total = field.NewInt(0)
+	}
+	total.Add(field.NewInt(1))
+	hook.ID = *total
+	hook.Created = *field.NewInt(time.Now().Unix())
+	if err = fulldb.WriteWebhook(ctx, d.db, hook); err == nil {
+		err = fulldb.WriteWebhookTotal(ctx, d.db, total)
+	}
+	d.logMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return hook, d.reload()
+}
+
+func (d *Dispatcher) Delete(id uint64) error {
+	if err := fulldb.DeleteWebhook(context.Background(), d.db, field.NewInt(int64(id))); err != nil {
+		return err
+	}
+	return d.reload()
+}
+
+func (d *Dispatcher) Get(id uint64) (*types.Webhook, error) {
+	return fulldb.ReadWebhook(context.Background(), d.db, field.NewInt(int64(id)))
+}
+
+func (d *Dispatcher) List() []*types.Webhook {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]*types.Webhook{}, d.hooks...)
+}
+
+// Deliveries returns the delivery log of a webhook, newest first
+func (d *Dispatcher) Deliveries(id uint64, offset, limit int64) ([]*types.WebhookDelivery, uint64, error) {
+	ctx := context.Background()
+	hookID := field.NewInt(int64(id))
+	res := make([]*types.WebhookDelivery, 0)
+	total, err := fulldb.ReadWebhookDeliveryTotal(ctx, d.db, hookID)
+	if err != nil {
+		if errors.Is(err, kv.NotFound) {
+			return res, 0, nil
+		}
+		return nil, 0, err
+	}
+	for i := int64(total.ToUint64()) - offset; i > 0 && i > int64(total.ToUint64())-offset-limit; i-- {
+		data, err := fulldb.ReadWebhookDelivery(ctx, d.db, hookID, field.NewInt(i))
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, data)
+	}
+	return res, total.ToUint64(), nil
+}
+
+// Notify delivers a committed block to the webhooks matching it.
+// Blocks in the fork window are delivered unconfirmed and remembered, so that a follow-up
+// can be sent if they are reorged out, and are delivered again once they are confirmed.
+func (d *Dispatcher) Notify(data *job.SyncJob, confirmed bool) {
+	ctx := context.Background()
+	number := data.BlockData.Number
+	if confirmed {
+		// the block was replaced without going through a reorg of the sync
+		if unconfirmed, err := fulldb.ReadWebhookUnconfirmed(ctx, d.db, number); err == nil && unconfirmed.BlockHash != data.BlockData.Hash {
+			d.removed(number, unconfirmed)
+		}
+		if err := fulldb.DeleteWebhookUnconfirmed(ctx, d.db, number); err != nil {
+			log.Errorf("delete unconfirmed webhook block %d: %v", number.ToUint64(), err)
+		}
+	}
+
+	notified := &types.WebhookUnconfirmed{BlockHash: data.BlockData.Hash}
+	for _, hook := range d.List() {
+		events := Match(hook, data)
+		if len(events) == 0 {
+			continue
+		}
+		d.enqueue(hook, &Payload{
+			WebhookID:   hook.ID.ToUint64(),
+			BlockNumber: number.ToUint64(),
+			BlockHash:   data.BlockData.Hash,
+			Confirmed:   confirmed,
+			Events:      events,
+		})
+		notified.Webhooks = append(notified.Webhooks, hook.ID.ToUint64())
+	}
+
+	if !confirmed && len(notified.Webhooks) > 0 {
+		if err := fulldb.WriteWebhookUnconfirmed(ctx, d.db, number, notified); err != nil {
+			log.Errorf("write unconfirmed webhook block %d: %v", number.ToUint64(), err)
+		}
+	}
+}
+
+// Reorged sends the follow-ups of the unconfirmed blocks from `from` to `to` which have been rolled back
+func (d *Dispatcher) Reorged(from, to uint64) {
+	ctx := context.Background()
+	for i := from; i <= to; i++ {
+		number := field.NewInt(int64(i))
+		unconfirmed, err := fulldb.ReadWebhookUnconfirmed(ctx, d.db, number)
+		if err != nil {
+			if !errors.Is(err, kv.NotFound) {
+				log.Errorf("read unconfirmed webhook block %d: %v", i, err)
+			}
+			continue
+		}
+		d.removed(number, unconfirmed)
+		if err = fulldb.DeleteWebhookUnconfirmed(ctx, d.db, number); err != nil {
+			log.Errorf("delete unconfirmed webhook block %d: %v", i, err)
+		}
+	}
+}
+
+func (d *Dispatcher) removed(number *field.BigInt, unconfirmed *types.WebhookUnconfirmed) {
+	hooks := make(map[uint64]*types.Webhook)
+	for _, hook := range d.List() {
+		hooks[hook.ID.ToUint64()] = hook
+	}
+	for _, id := range unconfirmed.Webhooks {
+		hook, ok := hooks[id]
+		if !ok {
+			continue
+		}
+		d.enqueue(hook, &Payload{
+			WebhookID:   hook.ID.ToUint64(),
+			BlockNumber: number.ToUint64(),
+			BlockHash:   unconfirmed.BlockHash,
+			Removed:     true,
+			Events:      make([]*Event, 0),
+		})
+	}
+}
+
+// enqueue never blocks the sync, a delivery which does not fit in the queue is logged as failed
+func (d *Dispatcher) enqueue(hook *types.Webhook, payload *Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorf("marshal webhook %d payload: %v", payload.WebhookID, err)
+		return
+	}
+	dl := &delivery{
+		hook: hook,
+		record: &types.WebhookDelivery{
+			BlockNumber: *field.NewInt(int64(payload.BlockNumber)),
+			BlockHash:   payload.BlockHash,
+			Confirmed:   payload.Confirmed,
+			Removed:     payload.Removed,
+			Payload:     body,
+		},
+	}
+	select {
+	case d.queue <- dl:
+	default:
+		dl.record.Error = "delivery queue is full"
+		d.writeDelivery(dl)
+	}
+}
+
+func (d *Dispatcher) writeDelivery(dl *delivery) {
+	ctx := context.Background()
+	dl.record.TimeStamp = *field.NewInt(time.Now().Unix())
+
+	d.logMu.Lock()
+	defer d.logMu.Unlock()
+	total, err := fulldb.ReadWebhookDeliveryTotal(ctx, d.db, &dl.hook.ID)
+	if err != nil {
+		if !errors.Is(err, kv.NotFound) {
+			log.Errorf("read webhook %d delivery total: %v", dl.hook.ID.ToUint64(), err)
+			return
+		}
+		total = field.NewInt(0)
+	}
+	total.Add(field.NewInt(1))
+	if err = fulldb.WriteWebhookDelivery(ctx, d.db, &dl.hook.ID, total, dl.record); err != nil {
+		log.Errorf("write webhook %d delivery: %v", dl.hook.ID.ToUint64(), err)
+		return
+	}
+	if err = fulldb.WriteWebhookDeliveryTotal(ctx, d.db, &dl.hook.ID, total); err != nil {
+		log.Errorf("write webhook %d delivery total: %v", dl.hook.ID.ToUint64(), err)
+	}
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+const (
+	EventTx         = "transaction"
+	EventInternalTx = "internalTransaction"
+	EventLog        = "log"
+)
+
+// Payload is the body posted to a webhook, one per block.
+// Removed payloads are sent for unconfirmed blocks which have been reorged out, they carry no events.
+type Payload struct {
+	WebhookID   uint64      `json:"webhookId"`
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	Confirmed   bool        `json:"confirmed"`
+	Removed     bool        `json:"removed"`
+	Events      []*Event    `json:"events"`
+}
+
+type Event struct {
+	Type     string          `json:"type"`
+	TxHash   common.Hash     `json:"txHash"`
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	Value    *field.BigInt   `json:"value,omitempty"`
+	Contract *common.Address `json:"contract,omitempty"`
+	LogIndex *field.BigInt   `json:"logIndex,omitempty"`
+	Topics   []common.Hash   `json:"topics,omitempty"`
+	Data     hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// Match returns the events of the block the webhook is subscribed to.
+// Txs and internal txs only match a webhook watching an address without contract or topic,
+// logs match when the contract, the topic and an indexed argument are the ones of the webhook.
+func Match(hook *types.Webhook, data *job.SyncJob) []*Event {
+	events := make([]*Event, 0)
+	watchTx := hook.Address != (common.Address{}) && hook.Contract == (common.Address{}) && hook.Topic == (common.Hash{})
+
+	for _, tx := range data.TransactionDatas {
+		if watchTx && (tx.From == hook.Address || (tx.To != nil && *tx.To == hook.Address)) && tx.Value.Cmp(&hook.MinValue) >= 0 {
+			events = append(events, &Event{
+				Type:   EventTx,
+				TxHash: tx.Hash,
+				From:   &tx.From,
+				To:     tx.To,
+				Value:  &tx.Value,
+			})
+		}
+		if !watchTx {
+			continue
+		}
+		for _, itx := range data.InternalTxs[tx.Hash] {
+			if (itx.From == hook.Address || itx.To == hook.Address) && itx.Amount.Cmp(&hook.MinValue) >= 0 {
+				events = append(events, &Event{
+					Type:   EventInternalTx,
+					TxHash: tx.Hash,
+					From:   &itx.From,
+					To:     &itx.To,
+					Value:  &itx.Amount,
+				})
+			}
+		}
+	}
+
+	for _, rt := range data.ReceiptDatas {
+		for _, l := range rt.Logs {
+			if !matchLog(hook, l) {
+				continue
+			}
+			events = append(events, &Event{
+				Type:     EventLog,
+				TxHash:   rt.TxHash,
+				Contract: &l.Address,
+				LogIndex: &l.LogIndex,
+				Topics:   l.Topics,
+				Data:     l.Data,
+			})
+		}
+	}
+	return events
+}
+
+func matchLog(hook *types.Webhook, l *types.Log) bool {
+	if hook.Contract != (common.Address{}) && l.Address != hook.Contract {
+		return false
+	}
+	if hook.Topic != (common.Hash{}) && (len(l.Topics) == 0 || l.Topics[0] != hook.Topic) {
+		return false
+	}
+	if hook.Address != (common.Address{}) && !indexed(l, hook.Address) {
+		return false
+	}
+	if hook.MinValue.Cmp(field.NewInt(0)) > 0 {
+		amount, ok := transferAmount(l)
+		if !ok || amount.Cmp(&hook.MinValue) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// indexed reports whether addr is one of the indexed arguments of the log
+func indexed(l *types.Log, addr common.Address) bool {
+	topic := common.BytesToHash(addr.Bytes())
+	for i := 1; i < len(l.Topics); i++ {
+		if l.Topics[i] == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// transferAmount returns the amount of an erc20 Transfer or an erc1155 TransferSingle
+func transferAmount(l *types.Log) (*field.BigInt, bool) {
+	if len(l.Topics) == 0 {
+		return nil, false
+	}
+	var bin []byte
+	switch {
+	case l.Topics[0] == contract.TransferEventTopic && len(l.Topics) == 3 && len(l.Data) >= 32:
+		bin = l.Data[:32]
+	case l.Topics[0] == contract.TransferSingleEventTopic && len(l.Topics) == 4 && len(l.Data) >= 64:
+		bin = l.Data[32:64]
+	default:
+		return nil, false
+	}
+	return (*field.BigInt)(new(big.Int).SetBytes(bin)), true
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uchainorg/uscan/pkg/contract"
+	"github.com/uchainorg/uscan/pkg/field"
+	"github.com/uchainorg/uscan/pkg/job"
+	"github.com/uchainorg/uscan/pkg/types"
+)
+
+var (
+	watched = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	token   = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+func testJob() *job.SyncJob {
+	txHash := common.HexToHash("0x01")
+	amount := common.LeftPadBytes(field.NewInt(500).Bytes(), 32)
+	return &job.SyncJob{
+		TransactionDatas: []*types.Tx{
+			{Hash: txHash, From: watched, To: &token, Value: *field.NewInt(0)},
+		},
+		ReceiptDatas: []*types.Rt{
+			{
+				TxHash: txHash,
+				Logs: []*types.Log{
+					{
+						Address: token,
+						Topics: []common.Hash{
+							contract.TransferEventTopic,
+							common.BytesToHash(watched.Bytes()),
+							common.BytesToHash(other.Bytes()),
+						},
+						Data: amount,
+					},
+				},
+			},
+		},
+		InternalTxs: map[common.Hash][]*types.InternalTx{
+			txHash: {{From: token, To: other, Amount: *field.NewInt(7)}},
+		},
+	}
+}
+
+func TestMatchAddress(t *testing.T) {
+	events := Match(&types.Webhook{Address: watched}, testJob())
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, EventTx, events[0].Type)
+	assert.Equal(t, EventLog, events[1].Type)
+
+	events = Match(&types.Webhook{Address: other}, testJob())
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, EventInternalTx, events[0].Type)
+	assert.Equal(t, EventLog, events[1].Type)
+}
+
+func TestMatchContractAndTopic(t *testing.T) {
+	events := Match(&types.Webhook{Contract: token, Topic: contract.TransferEventTopic}, testJob())
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, token, *events[0].Contract)
+
+	events = Match(&types.Webhook{Contract: token, Topic: contract.TransferSingleEventTopic}, testJob())
+	assert.Equal(t, 0, len(events))
+
+	events = Match(&types.Webhook{Contract: other}, testJob())
+	assert.Equal(t, 0, len(events))
+}
+
+func TestMatchMinValue(t *testing.T) {
+	events := Match(&types.Webhook{Contract: token, MinValue: *field.NewInt(500)}, testJob())
+	assert.Equal(t, 1, len(events))
+
+	events = Match(&types.Webhook{Contract: token, MinValue: *field.NewInt(501)}, testJob())
+	assert.Equal(t, 0, len(events))
+
+	// the tx has no value
+	events = Match(&types.Webhook{Address: watched, MinValue: *field.NewInt(1)}, testJob())
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, EventLog, events[0].Type)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"webhookId":1}`)
+	sig := Sign("secret", body)
+	assert.True(t, hmac.Equal([]byte(sig), []byte(Sign("secret", body))))
+	assert.NotEqual(t, sig, Sign("other", body))
+	assert.Equal(t, "sha256=", sig[:7])
+}
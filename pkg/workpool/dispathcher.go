@@ -1,17 +1,22 @@
 package workpool
 
+import "sync"
+
 type workerPoolType chan chan Job
 
 type dispathcherImpl struct {
 	workerPool workerPoolType
 	works      []*worker
 	workQueue  chan Job
+	quit       chan struct{}
+	once       sync.Once
 }
 
 func NewDispathcher(num int) *dispathcherImpl {
 	workQueue := make(chan Job, num*2)
 	workerPool := make(workerPoolType, num)
 	works := make([]*worker, 0, num)
+	quit := make(chan struct{})
 	for i := 0; i < num; i++ {
 		work := newWorker(i, workerPool)
 		work.start()
@@ -19,24 +24,46 @@ func NewDispathcher(num int) *dispathcherImpl {
 	}
 
 	go func() {
-		for work := range workQueue {
-			workqueue := <-workerPool
-			workqueue <- work
+		for {
+			select {
+			case work := <-workQueue:
+				select {
+				case workqueue := <-workerPool:
+					select {
+					case workqueue <- work:
+					case <-quit:
+						return
+					}
+				case <-quit:
+					return
+				}
+			case <-quit:
+				return
+			}
 		}
 	}()
 	return &dispathcherImpl{
 		workerPool: workerPool,
 		works:      works,
 		workQueue:  workQueue,
+		quit:       quit,
 	}
 }
 
+// AddJob drops the job once the dispathcher is stopped
 func (d *dispathcherImpl) AddJob(job Job) {
-	d.workQueue <- job
+	select {
+	case d.workQueue <- job:
+	case <-d.quit:
+	}
 }
 
+// Stop discards the queued jobs and waits for the running ones
 func (d *dispathcherImpl) Stop() {
-	for _, work := range d.works {
-		work.stop()
-	}
+	d.once.Do(func() {
+		close(d.quit)
+		for _, work := range d.works {
+			work.stop()
+		}
+	})
 }
@@ -43,3 +43,30 @@ func TestStartDispathcher(t *testing.T) {
 	sub := time.Since(start).Seconds()
 	t.Log(sub)
 }
+
+type sleeper struct {
+	started chan struct{}
+	done    bool
+}
+
+func (s *sleeper) Execute() {
+	close(s.started)
+	time.Sleep(time.Millisecond * 200)
+	s.done = true
+}
+
+func TestStopDispathcher(t *testing.T) {
+	d := NewDispathcher(1)
+	s := &sleeper{started: make(chan struct{})}
+	d.AddJob(s)
+	<-s.started
+
+	d.Stop()
+	if !s.done {
+		t.Fatal("stop returned before the running job was done")
+	}
+
+	// jobs added after stop are dropped
+	d.AddJob(&sleeper{started: make(chan struct{})})
+	d.Stop()
+}
@@ -5,12 +5,18 @@ type worker struct {
 	work     chan Job
 	workPool chan chan Job
 	end      chan struct{}
+	done     chan struct{}
 }
 
 func (w *worker) start() {
 	go func() {
+		defer close(w.done)
 		for {
-			w.workPool <- w.work
+			select {
+			case w.workPool <- w.work:
+			case <-w.end:
+				return
+			}
 			select {
 			case work := <-w.work:
 				work.Execute()
@@ -20,8 +26,11 @@ func (w *worker) start() {
 		}
 	}()
 }
+
+// stop returns after the running job of the worker is done
 func (w *worker) stop() {
 	w.end <- struct{}{}
+	<-w.done
 }
 
 func newWorker(id int, workerQueue chan chan Job) *worker {
@@ -30,6 +39,7 @@ func newWorker(id int, workerQueue chan chan Job) *worker {
 		work:     make(chan Job),
 		workPool: workerQueue,
 		end:      make(chan struct{}, 1),
+		done:     make(chan struct{}),
 	}
 	return work
 }
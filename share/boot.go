@@ -22,12 +22,25 @@ const (
 	TlsPath  = "tls_path"
 	TLS      = "tls"
 
-	RpcUrls  = "rpc_urls"
-	WorkChan = "work_chan"
+	RpcUrls      = "rpc_urls"
+	HeadSource   = "head_source"
+	PollInterval = "poll_interval"
+	WorkChan     = "work_chan"
 
 	MdbxPath = "db_path"
 
 	ForkBlockNum = "fork_block_number"
+	ReorgDepth   = "reorg_depth"
+	StartBlock   = "start_block"
+	TracingMode  = "tracing_mode"
+
+	StreamBuffer     = "stream_buffer"
+	GasTrackerBlocks = "gas_tracker_blocks"
+	PendingSource    = "pending_source"
+	PendingPoolSize  = "pending_pool_size"
+
+	AdminToken  = "admin_token"
+	IpfsGateway = "ipfs_gateway"
 
 	APPTitle    = "app_title"    //app_title是用户自定义的浏览器标题，比如是Coq的话就显示 Coq Chain Scan
 	UnitDisplay = "unit_display" //unit_display是用户指定显示的单位，比如是Eth、Peel、Bnb
@@ -11,4 +11,6 @@ const (
 	PartNum      = 100
 	WriteTimeout = time.Minute
 	ReadTimeout  = time.Minute
+
+	WebhookWorkers = 8
 )
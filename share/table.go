@@ -17,16 +17,30 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package share
 
 const (
-	HomeTbl             = "home"
-	AccountsTbl         = "accounts"
-	TxTbl               = "transactions"
-	BlockTbl            = "blocks"
-	TraceLogTbl         = "traceLogs"
-	TransferTbl         = "transfers"
-	HolderTbl           = "holders"
-	HolderSortTabl      = "holdersSort"
-	InventorySortTabl   = "inventorysSort"
-	ValidateContractTbl = "validateContract"
+	HomeTbl              = "home"
+	AccountsTbl          = "accounts"
+	TxTbl                = "transactions"
+	BlockTbl             = "blocks"
+	TraceLogTbl          = "traceLogs"
+	TransferTbl          = "transfers"
+	HolderTbl            = "holders"
+	HolderSortTabl       = "holdersSort"
+	InventorySortTabl    = "inventorysSort"
+	BalanceSortTabl      = "balanceSort"
+	TokenBalanceSortTabl = "tokenBalanceSort"
+	StatsSortTabl        = "statsSort"
+	WithdrawalSortTabl   = "withdrawalSort"
+	LogSortTabl          = "logSort"
+	SignatureSortTabl    = "signatureSort"
+	ApprovalSortTabl     = "approvalSort"
+	NftSortTabl          = "nftSort"
+	ProxySortTabl        = "proxySort"
+	ValidateContractTbl  = "validateContract"
+	JournalTbl           = "journal"
+	WebhookTbl           = "webhook"
+	StatsTbl             = "stats"
+	ApprovalTbl          = "approvals"
+	NftTbl               = "nfts"
 
 	ForkHomeTbl     = "fork_home"
 	ForkAccountsTbl = "fork_accounts"
@@ -35,4 +49,6 @@ const (
 	ForkTraceLogTbl = "fork_traceLogs"
 	ForkTransferTbl = "fork_transfers"
 	ForkIndexTbl    = "fork_index"
+	ForkJournalTbl  = "fork_journal"
+	ForkRecordTbl   = "fork_record"
 )